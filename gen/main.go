@@ -20,6 +20,26 @@ func main() {
 	// 后续添加新模型时，只需在此处添加即可
 	modelsToGenerate := []interface{}{
 		models.User{},
+		models.Admin{},
+		models.MFARecoveryCode{},
+		models.UserIdentity{},
+		models.APIKey{},
+		models.Role{},
+		models.Permission{},
+		models.RolePermission{},
+		models.AuditLog{},
+		models.PointsJournal{},
+		models.PointsJournalHead{},
+		models.PointsTransaction{},
+		models.ProxyCharge{},
+		models.PointsHold{},
+		models.RedeemCampaign{},
+		models.RedeemCode{},
+		models.RedeemRedemption{},
+		models.RewardGrant{},
+		models.PointsLot{},
+		models.PointsProduct{},
+		models.PointsOrder{},
 		// 后续添加新模型示例：
 		// models.Article{},
 		// models.Comment{},
@@ -2,8 +2,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/Company-Automation-1/video-backend-go/src/config"
 	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
@@ -14,6 +16,13 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// holdExpiryInterval 积分预留过期扫描间隔
+	holdExpiryInterval = time.Minute
+	// lotExpiryInterval 积分批次过期扫描间隔
+	lotExpiryInterval = time.Minute
+)
+
 func main() {
 	// 加载配置
 	cfg, err := config.Load("config.yaml")
@@ -43,19 +52,58 @@ func main() {
 	email := infrastructure.NewEmail(cfg)
 
 	// 初始化业务服务层
-	captchaService := services.NewCaptchaService(redis, email)
-	userService := services.NewUserService(captchaService)
-	authService := services.NewAuthService(&cfg.JWT, redis)
+	auditService := services.NewAuditService()
+	captchaService := services.NewCaptchaService(&cfg.Captcha, redis, email)
+	loginGuardService := services.NewLoginGuardService(&cfg.LoginGuard, redis, auditService)
+	mfaService := services.NewMFAService(&cfg.MFA, redis, auditService)
+	jwtSigner, err := services.NewJWTSigner(&cfg.JWT)
+	if err != nil {
+		log.Fatalf("加载JWT密钥失败: %v", err)
+	}
+	apiKeyService := services.NewAPIKeyService(auditService)
+	authService := services.NewAuthService(
+		&cfg.JWT, jwtSigner, redis, loginGuardService, mfaService, apiKeyService, auditService,
+	)
+	journalService := services.NewPointsJournalService(jwtSigner)
+	if !journalService.CheckpointEnabled() {
+		log.Println("警告: 未配置非对称签名密钥（jwt.checkpoint_key_id 或 jwt.signing_key_id），积分日志检查点导出已禁用")
+	}
+	ledgerService := services.NewPointsLedgerService(journalService, auditService, &cfg.Points)
+	holdService := services.NewPointsHoldService(ledgerService)
+	proxyChargeService := services.NewProxyChargeService(holdService)
+	redeemService := services.NewRedeemService(ledgerService, auditService)
+	rewardService := services.NewRewardService(ledgerService, &cfg.Rewards)
+	paymentProvider, err := infrastructure.NewPaymentProvider(&cfg.Payment)
+	if err != nil {
+		log.Fatalf("初始化支付渠道失败: %v", err)
+	}
+	if paymentProvider != nil && paymentProvider.Name() == infrastructure.FakePaymentProviderName &&
+		gin.Mode() == gin.ReleaseMode {
+		log.Println("!!!!!!!! 警告: release 模式下开启了本地模拟支付（payment.provider: fake），" +
+			"任何登录用户都可以免费购买积分，生产环境请立即关闭 !!!!!!!!")
+	}
+	paymentService := services.NewPaymentService(paymentProvider, ledgerService, auditService)
+	userService := services.NewUserService(captchaService, authService, auditService, rewardService)
+	oidcService := services.NewOIDCService(&cfg.OIDC, redis, authService, auditService, rewardService)
+
+	// 后台任务：释放超时未结算的积分预留
+	go holdService.RunExpiry(context.Background(), holdExpiryInterval)
+	// 后台任务：扣除已过期积分批次的剩余积分
+	go ledgerService.RunLotExpiry(context.Background(), lotExpiryInterval)
 
 	// 注册中间件
+	r.Use(middleware.RequestID())               // 请求ID
 	r.Use(middleware.CORS(&cfg.CORS))           // 跨域处理
 	r.Use(middleware.ErrorRecoveryMiddleware()) // 错误恢复
 	r.Use(middleware.ResponseMiddleware())      // 响应处理
 	r.Use(middleware.Logger())                  // 日志记录
 
 	// 注册路由
-	pythonURL := "http://192.168.14.70:6869" // Python服务地址
-	routes.RegisterRoutes(r, userService, authService, pythonURL)
+	routes.RegisterRoutes(
+		r, userService, authService, loginGuardService, mfaService, oidcService,
+		apiKeyService, auditService, journalService, ledgerService, holdService, proxyChargeService, redeemService,
+		rewardService, paymentService, &cfg.Proxy,
+	)
 
 	// 启动服务器
 	addr := fmt.Sprintf(":%d", cfg.Server.Port)
@@ -0,0 +1,13 @@
+-- a_users 新增列：积分预留、账号状态、两步验证、邀请
+ALTER TABLE `a_users`
+  ADD COLUMN `held_points` int NOT NULL DEFAULT 0 COMMENT '已预留积分（可用积分=积分-已预留积分）' AFTER `points`,
+  ADD COLUMN `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态：1正常 2暂停 3封禁' AFTER `held_points`,
+  ADD COLUMN `status_reason` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '暂停或封禁原因' AFTER `status`,
+  ADD COLUMN `suspended_until` bigint DEFAULT NULL COMMENT '暂停截止时间：秒级时间戳' AFTER `status_reason`,
+  ADD COLUMN `totp_secret` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'TOTP密钥' AFTER `suspended_until`,
+  ADD COLUMN `totp_enabled` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否启用TOTP两步验证' AFTER `totp_secret`,
+  ADD COLUMN `invite_code` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT '邀请码（首次使用时生成）' AFTER `totp_enabled`,
+  ADD COLUMN `invited_by` int UNSIGNED DEFAULT NULL COMMENT '邀请人ID' AFTER `invite_code`,
+  ADD UNIQUE INDEX `idx_invite_code`(`invite_code` ASC) USING BTREE COMMENT '邀请码',
+  ADD INDEX `idx_status`(`status` ASC) USING BTREE COMMENT '状态',
+  ADD INDEX `idx_invited_by`(`invited_by` ASC) USING BTREE COMMENT '邀请人';
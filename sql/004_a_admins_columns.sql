@@ -0,0 +1,7 @@
+-- a_admins 新增列：角色、账号状态、两步验证
+ALTER TABLE `a_admins`
+  ADD COLUMN `role_id` int UNSIGNED DEFAULT NULL COMMENT '角色ID，NULL表示未分配角色（无任何权限）' AFTER `password`,
+  ADD COLUMN `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态：1正常 2禁用' AFTER `role_id`,
+  ADD COLUMN `totp_secret` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'TOTP密钥' AFTER `status`,
+  ADD COLUMN `totp_enabled` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否启用TOTP两步验证' AFTER `totp_secret`,
+  ADD INDEX `idx_role_id`(`role_id` ASC) USING BTREE COMMENT '角色';
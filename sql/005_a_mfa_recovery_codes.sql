@@ -0,0 +1,10 @@
+CREATE TABLE `a_mfa_recovery_codes`  (
+  `id` int UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `role` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '账号角色：user/admin',
+  `account_id` int UNSIGNED NOT NULL COMMENT '账号ID',
+  `code_hash` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '恢复码SHA-256摘要',
+  `used_at` bigint DEFAULT NULL COMMENT '使用时间：秒级时间戳',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  INDEX `idx_owner`(`role` ASC, `account_id` ASC) USING BTREE COMMENT '所属账号'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
@@ -0,0 +1,12 @@
+CREATE TABLE `a_user_identities`  (
+  `id` int UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `user_id` int UNSIGNED NOT NULL COMMENT '用户ID',
+  `provider` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '身份提供方',
+  `subject` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '提供方用户标识（sub）',
+  `email` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '提供方邮箱',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `idx_provider_subject`(`provider` ASC, `subject` ASC) USING BTREE COMMENT '同一提供方的用户标识唯一',
+  INDEX `idx_user_id`(`user_id` ASC) USING BTREE COMMENT '所属用户'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
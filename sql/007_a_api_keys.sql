@@ -0,0 +1,16 @@
+CREATE TABLE `a_api_keys`  (
+  `id` int UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `user_id` int UNSIGNED NOT NULL COMMENT '所属用户ID',
+  `name` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '名称',
+  `prefix` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'Key前缀（用于查找和展示）',
+  `key_hash` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'Key SHA-256摘要',
+  `scopes` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '授权范围（逗号分隔）',
+  `expires_at` bigint DEFAULT NULL COMMENT '过期时间：秒级时间戳，NULL表示永不过期',
+  `last_used_at` bigint DEFAULT NULL COMMENT '最近使用时间：秒级时间戳',
+  `revoked_at` bigint DEFAULT NULL COMMENT '吊销时间：秒级时间戳',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `idx_prefix`(`prefix` ASC) USING BTREE COMMENT 'Key前缀唯一',
+  INDEX `idx_user_id`(`user_id` ASC) USING BTREE COMMENT '所属用户'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
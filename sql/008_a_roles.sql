@@ -0,0 +1,19 @@
+CREATE TABLE `a_roles`  (
+  `id` int UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `code` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '角色编码',
+  `name` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '角色名称',
+  `description` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '角色描述',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `idx_code`(`code` ASC) USING BTREE COMMENT '角色编码'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
+
+-- 预置角色（super_admin 在代码中拥有全部权限，无需在 a_role_permissions 中逐条授权）
+INSERT INTO `a_roles` (`id`, `code`, `name`, `description`, `created_at`, `updated_at`) VALUES
+  (1, 'super_admin', '超级管理员', '拥有全部权限，可管理管理员和角色', UNIX_TIMESTAMP(), UNIX_TIMESTAMP()),
+  (2, 'support', '客服', '查看用户、暂停/封禁用户、处理登录锁定', UNIX_TIMESTAMP(), UNIX_TIMESTAMP()),
+  (3, 'finance', '财务', '查看用户、调整用户积分、查看积分流水、导出积分日志检查点、管理兑换码、管理积分套餐和订单', UNIX_TIMESTAMP(), UNIX_TIMESTAMP());
+
+-- 已有管理员升级为超级管理员（原有管理员均拥有全部权限；新建的库中没有管理员，不影响）
+UPDATE `a_admins` SET `role_id` = 1 WHERE `role_id` IS NULL;
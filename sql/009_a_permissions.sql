@@ -0,0 +1,28 @@
+CREATE TABLE `a_permissions`  (
+  `id` int UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `code` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '权限编码（资源:操作）',
+  `name` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '权限名称',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `idx_code`(`code` ASC) USING BTREE COMMENT '权限编码'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
+
+-- 预置权限（与 services/rbac_service.go 中的权限常量一致）
+INSERT INTO `a_permissions` (`code`, `name`, `created_at`) VALUES
+  ('admins:read', '查看管理员', UNIX_TIMESTAMP()),
+  ('admins:write', '创建管理员', UNIX_TIMESTAMP()),
+  ('roles:read', '查看角色', UNIX_TIMESTAMP()),
+  ('users:read', '查看用户', UNIX_TIMESTAMP()),
+  ('users:points:write', '调整用户积分', UNIX_TIMESTAMP()),
+  ('users:status:write', '暂停/封禁用户', UNIX_TIMESTAMP()),
+  ('points:read', '查看积分流水', UNIX_TIMESTAMP()),
+  ('login_lockouts:read', '查看登录锁定', UNIX_TIMESTAMP()),
+  ('login_lockouts:write', '解除登录锁定', UNIX_TIMESTAMP()),
+  ('audit_logs:read', '查看审计日志', UNIX_TIMESTAMP()),
+  ('points_journal:read', '导出积分日志检查点', UNIX_TIMESTAMP()),
+  ('redeem:read', '查看和导出兑换码', UNIX_TIMESTAMP()),
+  ('redeem:write', '创建兑换码活动', UNIX_TIMESTAMP()),
+  ('products:read', '查看积分套餐', UNIX_TIMESTAMP()),
+  ('products:write', '管理积分套餐', UNIX_TIMESTAMP()),
+  ('orders:read', '查看积分订单', UNIX_TIMESTAMP()),
+  ('orders:refund', '积分订单退款', UNIX_TIMESTAMP());
@@ -0,0 +1,13 @@
+CREATE TABLE `a_role_permissions`  (
+  `role_id` int UNSIGNED NOT NULL COMMENT '角色ID',
+  `permission_id` int UNSIGNED NOT NULL COMMENT '权限ID',
+  PRIMARY KEY (`role_id`, `permission_id`) USING BTREE,
+  INDEX `idx_permission_id`(`permission_id` ASC) USING BTREE COMMENT '权限'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
+
+-- 预置角色权限（super_admin 拥有全部权限，无需授权）
+INSERT INTO `a_role_permissions` (`role_id`, `permission_id`)
+SELECT r.`id`, p.`id` FROM `a_roles` r JOIN `a_permissions` p
+WHERE (r.`code` = 'support' AND p.`code` IN ('users:read', 'users:status:write', 'login_lockouts:read', 'login_lockouts:write'))
+   OR (r.`code` = 'finance' AND p.`code` IN ('users:read', 'users:points:write', 'points:read', 'points_journal:read', 'redeem:read', 'redeem:write',
+     'products:read', 'products:write', 'orders:read', 'orders:refund'));
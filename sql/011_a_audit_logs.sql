@@ -0,0 +1,26 @@
+CREATE TABLE `a_audit_logs`  (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `actor_id` int UNSIGNED NOT NULL COMMENT '操作者ID（0表示未登录）',
+  `actor_role` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '操作者角色：user/admin',
+  `action` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '操作',
+  `target_type` varchar(30) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '操作对象类型',
+  `target_id` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '操作对象ID',
+  `before` json DEFAULT NULL COMMENT '变更前（仅变更字段）',
+  `after` json DEFAULT NULL COMMENT '变更后（仅变更字段）',
+  `ip` varchar(45) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '请求IP',
+  `request_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '请求ID',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  INDEX `idx_actor`(`actor_id` ASC, `actor_role` ASC) USING BTREE COMMENT '操作者',
+  INDEX `idx_action`(`action` ASC) USING BTREE COMMENT '操作',
+  INDEX `idx_target`(`target_type` ASC, `target_id` ASC) USING BTREE COMMENT '操作对象',
+  INDEX `idx_request_id`(`request_id` ASC) USING BTREE COMMENT '请求ID',
+  INDEX `idx_created_at`(`created_at` ASC) USING BTREE COMMENT '创建时间'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
+
+-- 审计日志只允许追加：禁止修改和删除（应用账号另应仅授予 INSERT、SELECT 权限）
+CREATE TRIGGER `trg_a_audit_logs_no_update` BEFORE UPDATE ON `a_audit_logs` FOR EACH ROW
+  SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'a_audit_logs is append-only';
+
+CREATE TRIGGER `trg_a_audit_logs_no_delete` BEFORE DELETE ON `a_audit_logs` FOR EACH ROW
+  SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'a_audit_logs is append-only';
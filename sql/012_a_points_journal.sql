@@ -0,0 +1,37 @@
+CREATE TABLE `a_points_journal`  (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `seq` bigint UNSIGNED NOT NULL COMMENT '链上序号（从1开始连续递增）',
+  `user_id` int UNSIGNED NOT NULL COMMENT '用户ID',
+  `delta` int NOT NULL COMMENT '变动值',
+  `points_before` int DEFAULT NULL COMMENT '变动前积分',
+  `points_after` int DEFAULT NULL COMMENT '变动后积分',
+  `reason` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '变动原因',
+  `actor_id` int UNSIGNED NOT NULL COMMENT '操作者ID',
+  `actor_role` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '操作者角色：user/admin',
+  `request_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '请求ID',
+  `prev_hash` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '上一行哈希（第一行为64个0）',
+  `hash` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '本行哈希：SHA-256(本行内容 + prev_hash)',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `idx_seq`(`seq` ASC) USING BTREE COMMENT '链上序号唯一',
+  INDEX `idx_user_id`(`user_id` ASC) USING BTREE COMMENT '用户'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
+
+-- 积分变更日志只允许追加：禁止修改和删除
+CREATE TRIGGER `trg_a_points_journal_no_update` BEFORE UPDATE ON `a_points_journal` FOR EACH ROW
+  SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'a_points_journal is append-only';
+
+CREATE TRIGGER `trg_a_points_journal_no_delete` BEFORE DELETE ON `a_points_journal` FOR EACH ROW
+  SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'a_points_journal is append-only';
+
+-- 链头（单行）：追加日志时 SELECT ... FOR UPDATE 串行化，保证序号连续
+CREATE TABLE `a_points_journal_head`  (
+  `id` int UNSIGNED NOT NULL COMMENT 'ID（固定为1）',
+  `seq` bigint UNSIGNED NOT NULL COMMENT '最新序号',
+  `hash` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '最新哈希',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
+
+INSERT INTO `a_points_journal_head` (`id`, `seq`, `hash`, `updated_at`) VALUES
+  (1, 0, '0000000000000000000000000000000000000000000000000000000000000000', UNIX_TIMESTAMP());
@@ -0,0 +1,24 @@
+CREATE TABLE `a_points_transactions`  (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `user_id` int UNSIGNED NOT NULL COMMENT '用户ID',
+  `delta` int NOT NULL COMMENT '变动值（正数增加，负数扣除）',
+  `balance_after` int NOT NULL COMMENT '变动后余额',
+  `reason` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '变动原因：admin_grant/admin_deduct/process_image/process_video/refund/purchase 等',
+  `counter_account` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '对方系统账户（用户侧与系统侧变动之和为0）',
+  `reference_id` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '关联业务ID（如订单号、请求ID）',
+  `actor_id` int UNSIGNED NOT NULL COMMENT '操作者ID（0表示系统）',
+  `actor_role` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '操作者角色：user/admin，系统操作为空',
+  `request_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '请求ID',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  INDEX `idx_user_id`(`user_id` ASC, `id` ASC) USING BTREE COMMENT '用户流水',
+  INDEX `idx_reason`(`reason` ASC) USING BTREE COMMENT '变动原因',
+  INDEX `idx_reference_id`(`reference_id` ASC) USING BTREE COMMENT '关联业务',
+  INDEX `idx_created_at`(`created_at` ASC) USING BTREE COMMENT '创建时间'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
+
+-- 存量余额迁移：为已有积分的用户写入期初流水，使流水合计与 a_users.points 一致
+INSERT INTO `a_points_transactions`
+  (`user_id`, `delta`, `balance_after`, `reason`, `counter_account`, `reference_id`, `actor_id`, `actor_role`, `request_id`, `created_at`)
+SELECT `id`, `points`, `points`, 'opening_balance', 'system:migration', '', 0, '', '', UNIX_TIMESTAMP()
+FROM `a_users` WHERE `points` IS NOT NULL AND `points` <> 0;
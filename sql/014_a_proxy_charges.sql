@@ -0,0 +1,18 @@
+CREATE TABLE `a_proxy_charges`  (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `user_id` int UNSIGNED NOT NULL COMMENT '用户ID',
+  `request_id` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '请求ID',
+  `path` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '接口路径',
+  `points` int NOT NULL COMMENT '价格',
+  `media_duration_ms` bigint NOT NULL DEFAULT 0 COMMENT '视频时长（毫秒，按视频计价时记录）',
+  `media_width` int NOT NULL DEFAULT 0 COMMENT '视频宽度（像素）',
+  `media_height` int NOT NULL DEFAULT 0 COMMENT '视频高度（像素）',
+  `hold_id` bigint UNSIGNED NOT NULL COMMENT '积分预留ID（扣除或释放及原因见 a_points_holds）',
+  `upstream_status` int NOT NULL DEFAULT 0 COMMENT '上游响应状态码（0表示未收到响应）',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  INDEX `idx_request_id`(`request_id` ASC) USING BTREE COMMENT '请求ID',
+  INDEX `idx_user_id`(`user_id` ASC) USING BTREE COMMENT '用户',
+  INDEX `idx_hold_id`(`hold_id` ASC) USING BTREE COMMENT '积分预留'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
@@ -0,0 +1,19 @@
+CREATE TABLE `a_points_holds`  (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `user_id` int UNSIGNED NOT NULL COMMENT '用户ID',
+  `points` int NOT NULL COMMENT '预留积分',
+  `reason` varchar(50) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '扣除时使用的积分变动原因',
+  `reference_id` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '关联业务ID（如请求ID）',
+  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态：1预留中 2已扣除 3已释放 4已过期',
+  `expires_at` bigint NOT NULL COMMENT '过期时间：秒级时间戳（到期未结算自动释放）',
+  `captured_points` int NOT NULL DEFAULT 0 COMMENT '实际扣除积分',
+  `release_reason` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '释放原因',
+  `transaction_id` bigint UNSIGNED NULL DEFAULT NULL COMMENT '扣除流水ID',
+  `settled_at` bigint NULL DEFAULT NULL COMMENT '结算时间：秒级时间戳',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  INDEX `idx_user_id`(`user_id` ASC) USING BTREE COMMENT '用户',
+  INDEX `idx_reference_id`(`reference_id` ASC) USING BTREE COMMENT '关联业务',
+  INDEX `idx_status_expires`(`status` ASC, `expires_at` ASC) USING BTREE COMMENT '过期扫描'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
@@ -0,0 +1,14 @@
+CREATE TABLE `a_redeem_campaigns`  (
+  `id` int UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `name` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '活动名称',
+  `points` int NOT NULL COMMENT '每次兑换获得的积分',
+  `code_count` int NOT NULL COMMENT '兑换码数量',
+  `max_uses_per_code` int NOT NULL DEFAULT 1 COMMENT '每个兑换码可使用次数',
+  `per_user_limit` int NOT NULL DEFAULT 1 COMMENT '每个用户在本活动中可兑换次数',
+  `starts_at` bigint NOT NULL COMMENT '生效时间：秒级时间戳',
+  `ends_at` bigint NOT NULL COMMENT '截止时间：秒级时间戳',
+  `created_by` int UNSIGNED NOT NULL COMMENT '创建管理员ID',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
@@ -0,0 +1,11 @@
+CREATE TABLE `a_redeem_codes`  (
+  `id` int UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `campaign_id` int UNSIGNED NOT NULL COMMENT '活动ID',
+  `code` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '兑换码（大写，不含分隔符）',
+  `used_count` int NOT NULL DEFAULT 0 COMMENT '已使用次数',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `uk_code`(`code` ASC) USING BTREE COMMENT '兑换码唯一',
+  INDEX `idx_campaign_id`(`campaign_id` ASC) USING BTREE COMMENT '活动'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
@@ -0,0 +1,12 @@
+CREATE TABLE `a_redeem_redemptions`  (
+  `id` int UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `campaign_id` int UNSIGNED NOT NULL COMMENT '活动ID',
+  `code_id` int UNSIGNED NOT NULL COMMENT '兑换码ID',
+  `user_id` int UNSIGNED NOT NULL COMMENT '用户ID',
+  `points` int NOT NULL COMMENT '获得积分',
+  `transaction_id` bigint UNSIGNED NOT NULL COMMENT '积分流水ID',
+  `created_at` bigint NOT NULL COMMENT '兑换时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `uk_code_user`(`code_id` ASC, `user_id` ASC) USING BTREE COMMENT '同一用户对同一兑换码只兑换一次',
+  INDEX `idx_campaign_user`(`campaign_id` ASC, `user_id` ASC) USING BTREE COMMENT '每用户兑换次数'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
@@ -0,0 +1,13 @@
+CREATE TABLE `a_reward_grants`  (
+  `id` int UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `user_id` int UNSIGNED NOT NULL COMMENT '用户ID',
+  `type` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '奖励类型（与积分变动原因一致）',
+  `grant_key` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '发放键（签到为日期，邀请为对方用户）',
+  `day` varchar(10) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '发放日期（YYYY-MM-DD，服务器时区）',
+  `points` int NOT NULL COMMENT '奖励积分',
+  `transaction_id` bigint UNSIGNED NOT NULL COMMENT '积分流水ID',
+  `created_at` bigint NOT NULL COMMENT '发放时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `uk_user_type_key`(`user_id` ASC, `type` ASC, `grant_key` ASC) USING BTREE COMMENT '同一奖励不重复发放',
+  INDEX `idx_user_type_day`(`user_id` ASC, `type` ASC, `day` ASC) USING BTREE COMMENT '每日次数上限'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
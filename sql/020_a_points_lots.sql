@@ -0,0 +1,21 @@
+CREATE TABLE `a_points_lots`  (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `user_id` int UNSIGNED NOT NULL COMMENT '用户ID',
+  `reason` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '积分来源（变动原因）',
+  `transaction_id` bigint UNSIGNED NOT NULL COMMENT '获得积分的流水ID（存量迁移为0）',
+  `points` int NOT NULL COMMENT '获得积分',
+  `remaining` int NOT NULL COMMENT '剩余积分',
+  `expired_points` int NOT NULL DEFAULT 0 COMMENT '已过期积分',
+  `expires_at` bigint DEFAULT NULL COMMENT '过期时间：秒级时间戳（NULL 表示永不过期）',
+  `expired_at` bigint DEFAULT NULL COMMENT '过期处理时间：秒级时间戳',
+  `created_at` bigint NOT NULL COMMENT '获得时间：秒级时间戳',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  INDEX `idx_user_id`(`user_id` ASC) USING BTREE COMMENT '用户',
+  INDEX `idx_expires_at`(`expires_at` ASC) USING BTREE COMMENT '过期扫描'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
+
+-- 存量余额迁移：已有积分作为一个永不过期的批次，使各用户批次剩余合计与 a_users.points 一致
+INSERT INTO `a_points_lots` (`user_id`, `reason`, `transaction_id`, `points`, `remaining`, `created_at`, `updated_at`)
+SELECT `id`, 'opening_balance', 0, `points`, `points`, UNIX_TIMESTAMP(), UNIX_TIMESTAMP()
+FROM `a_users` WHERE `points` IS NOT NULL AND `points` > 0;
@@ -0,0 +1,11 @@
+CREATE TABLE `a_points_products`  (
+  `id` int UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `name` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '套餐名称',
+  `points` int NOT NULL COMMENT '获得积分',
+  `price` int NOT NULL COMMENT '价格（分）',
+  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态：1上架 2下架',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  INDEX `idx_status`(`status` ASC) USING BTREE COMMENT '状态'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
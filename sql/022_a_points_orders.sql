@@ -0,0 +1,24 @@
+CREATE TABLE `a_points_orders`  (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT COMMENT 'ID',
+  `order_no` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '订单号',
+  `user_id` int UNSIGNED NOT NULL COMMENT '用户ID',
+  `product_id` int UNSIGNED NOT NULL COMMENT '积分套餐ID',
+  `product_name` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '套餐名称（下单时快照）',
+  `points` int NOT NULL COMMENT '获得积分（下单时快照）',
+  `amount` int NOT NULL COMMENT '支付金额（分，下单时快照）',
+  `provider` varchar(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '支付渠道',
+  `payment_id` varchar(100) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '渠道支付ID',
+  `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态：1待支付 2已支付 3已到账 4已退款',
+  `transaction_id` bigint UNSIGNED NULL DEFAULT NULL COMMENT '到账流水ID',
+  `refund_transaction_id` bigint UNSIGNED NULL DEFAULT NULL COMMENT '退款扣回流水ID',
+  `refund_reason` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '退款原因',
+  `paid_at` bigint NULL DEFAULT NULL COMMENT '支付时间：秒级时间戳',
+  `fulfilled_at` bigint NULL DEFAULT NULL COMMENT '到账时间：秒级时间戳',
+  `refunded_at` bigint NULL DEFAULT NULL COMMENT '退款时间：秒级时间戳',
+  `created_at` bigint NOT NULL COMMENT '创建时间：秒级时间戳',
+  `updated_at` bigint NOT NULL COMMENT '更新时间：秒级时间戳',
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE INDEX `uk_order_no`(`order_no` ASC) USING BTREE COMMENT '订单号',
+  INDEX `idx_user_id`(`user_id` ASC) USING BTREE COMMENT '用户',
+  INDEX `idx_status`(`status` ASC) USING BTREE COMMENT '状态'
+) ENGINE = InnoDB CHARACTER SET = utf8mb4 COLLATE = utf8mb4_general_ci ROW_FORMAT = Dynamic;
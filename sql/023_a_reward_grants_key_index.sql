@@ -0,0 +1,3 @@
+-- 注册和邀请奖励按邮箱发放：发放前按 (奖励类型, 发放键) 查询全部用户的发放记录
+ALTER TABLE `a_reward_grants`
+  ADD INDEX `idx_type_key`(`type` ASC, `grant_key` ASC) USING BTREE COMMENT '按发放键查询（注册和邀请奖励在全部用户中只发放一次）';
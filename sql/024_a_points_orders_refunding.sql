@@ -0,0 +1,3 @@
+-- 积分订单退款拆成两步：先扣回积分并标记退款中，渠道退款成功后再标记已退款
+ALTER TABLE `a_points_orders`
+  MODIFY COLUMN `status` tinyint NOT NULL DEFAULT 1 COMMENT '状态：1待支付 2已支付 3已到账 4已退款 5退款中';
@@ -0,0 +1,6 @@
+-- 账号令牌版本保存在用户/管理员表中（Redis 只作缓存），Redis 数据丢失后已吊销的令牌不会重新生效
+ALTER TABLE `a_users`
+  ADD COLUMN `token_version` bigint NOT NULL DEFAULT 0 COMMENT '令牌版本（递增即吊销全部令牌）' AFTER `invited_by`;
+
+ALTER TABLE `a_admins`
+  ADD COLUMN `token_version` bigint NOT NULL DEFAULT 0 COMMENT '令牌版本（递增即吊销全部令牌）' AFTER `totp_enabled`;
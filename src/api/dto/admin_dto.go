@@ -11,6 +11,20 @@ type AdminLoginRequest struct {
 type AdminCreateRequest struct {
 	Username string `json:"username" binding:"required,min=3,max=100"`
 	Password string `json:"password" binding:"required,min=6"`
+	RoleID   uint   `json:"role_id" binding:"required"` // 角色ID（见 GET /admin/roles）
+}
+
+// AdminUpdateRequest 更新管理员请求（字段均可选，不传表示不修改）
+type AdminUpdateRequest struct {
+	Username *string `json:"username,omitempty" binding:"omitempty,min=3,max=100"`
+	Password *string `json:"password,omitempty" binding:"omitempty,min=6"`
+	RoleID   *uint   `json:"role_id,omitempty"`
+}
+
+// AdminPasswordChangeRequest 管理员修改自己的密码请求
+type AdminPasswordChangeRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
 }
 
 // AdminListQueryRequest 管理员列表查询请求（管理员权限）
@@ -34,3 +48,10 @@ type AdminListQueryRequest struct {
 	OrderBy string `form:"order_by" json:"order_by"` // 排序字段（id, username, created_at, updated_at）
 	Order   string `form:"order" json:"order"`       // 排序方向（asc, desc），默认 desc
 }
+
+// LoginLockoutClearRequest 解除登录锁定请求（查询参数）
+type LoginLockoutClearRequest struct {
+	Role  string `form:"role" json:"role" binding:"required,oneof=user admin"`  // 登录角色
+	Type  string `form:"type" json:"type" binding:"required,oneof=username ip"` // 锁定维度
+	Value string `form:"value" json:"value" binding:"required"`                 // 用户名或IP
+}
@@ -0,0 +1,9 @@
+// Package dto API Key 相关DTO
+package dto
+
+// APIKeyCreateRequest 创建 API Key 请求
+type APIKeyCreateRequest struct {
+	Name      string   `json:"name" binding:"required,max=50"`
+	Scopes    []string `json:"scopes" binding:"required,min=1"` // 授权范围：profile:read、py:process_image、py:process_video
+	ExpiresAt *int64   `json:"expires_at,omitempty"`            // 过期时间（Unix时间戳），不传表示永不过期
+}
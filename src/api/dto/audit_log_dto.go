@@ -0,0 +1,19 @@
+// Package dto 审计日志相关DTO
+package dto
+
+// AuditLogListQueryRequest 审计日志查询请求（管理员权限）
+type AuditLogListQueryRequest struct {
+	PaginationRequest // 嵌入分页参数
+
+	// 精确查询
+	ActorID    *uint  `form:"actor_id" json:"actor_id"`                                          // 操作者ID
+	ActorRole  string `form:"actor_role" json:"actor_role" binding:"omitempty,oneof=user admin"` // 操作者角色
+	Action     string `form:"action" json:"action"`                                              // 操作，如 user.points.update
+	TargetType string `form:"target_type" json:"target_type"`                                    // 操作对象类型，如 user、admin
+	TargetID   string `form:"target_id" json:"target_id"`                                        // 操作对象ID
+	RequestID  string `form:"request_id" json:"request_id"`                                      // 请求ID
+
+	// 范围查询
+	CreatedAtMin *int64 `form:"created_at_min" json:"created_at_min"` // 创建时间最小值（>=，Unix时间戳）
+	CreatedAtMax *int64 `form:"created_at_max" json:"created_at_max"` // 创建时间最大值（<=，Unix时间戳）
+}
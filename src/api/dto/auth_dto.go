@@ -7,3 +7,29 @@ type UserLoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// RefreshTokenRequest 刷新Token请求
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// MFAVerifyRequest 两步验证请求（登录第二步）
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"` // 6位动态口令或恢复码
+}
+
+// MFATokenRequest 两步验证挑战令牌请求（登录时绑定两步验证）
+type MFATokenRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+}
+
+// MFACodeRequest 两步验证口令请求（启用、关闭两步验证）
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// OIDCCallbackRequest 第三方登录回调请求（授权回调页面拿到的 code 和 state）
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
@@ -0,0 +1,52 @@
+// Package dto 积分套餐和订单相关DTO
+package dto
+
+// PointsProductCreateRequest 创建积分套餐请求（管理员权限）
+type PointsProductCreateRequest struct {
+	Name   string `json:"name" binding:"required,max=100"`      // 套餐名称
+	Points int    `json:"points" binding:"required,min=1"`      // 获得积分
+	Price  int    `json:"price" binding:"required,min=1"`       // 价格（分）
+	Status int8   `json:"status" binding:"omitempty,oneof=1 2"` // 状态：1=上架，2=下架（默认上架）
+}
+
+// PointsProductUpdateRequest 修改积分套餐请求（字段均可选，不传表示不修改；已下单的订单不受影响）
+type PointsProductUpdateRequest struct {
+	Name   *string `json:"name,omitempty" binding:"omitempty,max=100"`
+	Points *int    `json:"points,omitempty" binding:"omitempty,min=1"`
+	Price  *int    `json:"price,omitempty" binding:"omitempty,min=1"`
+	Status *int8   `json:"status,omitempty" binding:"omitempty,oneof=1 2"`
+}
+
+// PointsProductListQueryRequest 积分套餐查询请求（管理员权限）
+type PointsProductListQueryRequest struct {
+	PaginationRequest // 嵌入分页参数
+
+	Name   string `form:"name" json:"name"`     // 套餐名称（模糊匹配）
+	Status *int8  `form:"status" json:"status"` // 状态：1=上架，2=下架
+}
+
+// PointsOrderCreateRequest 购买积分套餐请求
+type PointsOrderCreateRequest struct {
+	ProductID uint `json:"product_id" binding:"required"` // 积分套餐ID
+}
+
+// PointsOrderListQueryRequest 本人积分订单查询请求
+type PointsOrderListQueryRequest struct {
+	PaginationRequest // 嵌入分页参数
+
+	Status *int8 `form:"status" json:"status"` // 状态：1=待支付，2=已支付，3=已到账，4=已退款，5=退款中
+}
+
+// PointsOrderAdminListQueryRequest 积分订单查询请求（管理员权限）
+type PointsOrderAdminListQueryRequest struct {
+	PaginationRequest // 嵌入分页参数
+
+	UserID  *uint  `form:"user_id" json:"user_id"`   // 用户ID
+	OrderNo string `form:"order_no" json:"order_no"` // 订单号
+	Status  *int8  `form:"status" json:"status"`     // 状态：1=待支付，2=已支付，3=已到账，4=已退款，5=退款中
+}
+
+// PointsOrderRefundRequest 积分订单退款请求（管理员权限）
+type PointsOrderRefundRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"` // 退款原因
+}
@@ -0,0 +1,33 @@
+// Package dto 积分相关DTO
+package dto
+
+// PointsHistoryRequest 用户积分流水查询请求
+type PointsHistoryRequest struct {
+	PaginationRequest // 嵌入分页参数
+
+	Reason       string `form:"reason" json:"reason"`                 // 变动原因（精确匹配）
+	CreatedAtMin *int64 `form:"created_at_min" json:"created_at_min"` // 创建时间最小值（>=，Unix时间戳）
+	CreatedAtMax *int64 `form:"created_at_max" json:"created_at_max"` // 创建时间最大值（<=，Unix时间戳）
+}
+
+// PointsTransactionListQueryRequest 积分流水查询请求（管理员权限）
+type PointsTransactionListQueryRequest struct {
+	PaginationRequest // 嵌入分页参数
+
+	// 精确查询
+	UserID      *uint  `form:"user_id" json:"user_id"`           // 用户ID
+	Reason      string `form:"reason" json:"reason"`             // 变动原因
+	ReferenceID string `form:"reference_id" json:"reference_id"` // 关联业务ID
+	ActorID     *uint  `form:"actor_id" json:"actor_id"`         // 操作者ID
+
+	// 范围查询
+	CreatedAtMin *int64 `form:"created_at_min" json:"created_at_min"` // 创建时间最小值（>=，Unix时间戳）
+	CreatedAtMax *int64 `form:"created_at_max" json:"created_at_max"` // 创建时间最大值（<=，Unix时间戳）
+}
+
+// PointsHoldSettleRequest 积分预留结算请求（Python 服务任务完成后回调）
+type PointsHoldSettleRequest struct {
+	Status string `json:"status" binding:"required,oneof=succeeded failed"` // 任务最终状态：succeeded=扣除，failed=释放
+	Points *int   `json:"points" binding:"omitempty,min=0"`                 // 实际扣除积分（仅 succeeded，不超过预留积分，不传时全额扣除）
+	Reason string `json:"reason" binding:"max=255"`                         // 失败原因（仅 failed，记录为释放原因）
+}
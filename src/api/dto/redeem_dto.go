@@ -0,0 +1,25 @@
+// Package dto 兑换码相关DTO
+package dto
+
+// RedeemCampaignCreateRequest 创建兑换码活动请求（管理员权限）
+type RedeemCampaignCreateRequest struct {
+	Name           string `json:"name" binding:"required,max=100"`               // 活动名称
+	Points         int    `json:"points" binding:"required,min=1"`               // 每次兑换获得的积分
+	CodeCount      int    `json:"code_count" binding:"required,min=1,max=10000"` // 生成的兑换码数量
+	MaxUsesPerCode int    `json:"max_uses_per_code" binding:"omitempty,min=1"`   // 每个兑换码可使用次数（默认1）
+	PerUserLimit   int    `json:"per_user_limit" binding:"omitempty,min=1"`      // 每个用户在本活动中可兑换次数（默认1）
+	StartsAt       *int64 `json:"starts_at" binding:"omitempty,min=0"`           // 生效时间（Unix时间戳，默认立即生效）
+	EndsAt         int64  `json:"ends_at" binding:"required,min=1"`              // 截止时间（Unix时间戳）
+}
+
+// RedeemCampaignListQueryRequest 兑换码活动查询请求（管理员权限）
+type RedeemCampaignListQueryRequest struct {
+	PaginationRequest // 嵌入分页参数
+
+	Name string `form:"name" json:"name"` // 活动名称（模糊匹配）
+}
+
+// RedeemRequest 兑换积分请求
+type RedeemRequest struct {
+	Code string `json:"code" binding:"required,max=64"` // 兑换码（不区分大小写，可包含 - 分隔符）
+}
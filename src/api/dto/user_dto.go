@@ -12,9 +12,22 @@ type SendVerificationCodeRequest struct {
 
 // UserRegisterRequest 用户注册请求
 type UserRegisterRequest struct {
-	Username string `json:"username" binding:"required,min=3,max=100"`
+	Username   string `json:"username" binding:"required,min=3,max=100"`
+	Email      string `json:"email" binding:"required,email"`
+	Captcha    string `json:"captcha" binding:"required"`
+	Password   string `json:"password" binding:"required,min=6"`
+	InviteCode string `json:"invite_code" binding:"omitempty,max=32"` // 邀请人的邀请码（可选）
+}
+
+// ForgotPasswordRequest 忘记密码请求
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest 重置密码请求
+type ResetPasswordRequest struct {
 	Email    string `json:"email" binding:"required,email"`
-	Captcha  string `json:"captcha" binding:"required"`
+	Captcha  string `json:"captcha" binding:"required,len=6"`
 	Password string `json:"password" binding:"required,min=6"`
 }
 
@@ -46,6 +59,13 @@ type AdminUserUpdateRequest struct {
 	Points *int `json:"points,omitempty"` // 积分值。null=置空（等同于0），0=置为0，其他数值=设置对应积分
 }
 
+// AdminUserStatusRequest 管理员设置用户状态请求
+type AdminUserStatusRequest struct {
+	Status         int8   `json:"status" binding:"required,oneof=1 2 3"` // 1=恢复正常，2=暂停，3=封禁
+	Reason         string `json:"reason" binding:"max=255"`              // 暂停/封禁原因（暂停和封禁时必填）
+	SuspendedUntil *int64 `json:"suspended_until,omitempty"`             // 暂停截止时间（Unix时间戳，暂停时必填）
+}
+
 // UserListQueryRequest 用户列表查询请求（管理员权限）
 type UserListQueryRequest struct {
 	PaginationRequest // 嵌入分页参数
@@ -55,6 +75,7 @@ type UserListQueryRequest struct {
 	Username      string `form:"username" json:"username"`             // 用户名（精确匹配）
 	Email         string `form:"email" json:"email"`                   // 邮箱（精确匹配）
 	EmailVerified *bool  `form:"email_verified" json:"email_verified"` // 邮箱是否已验证
+	Status        *int8  `form:"status" json:"status"`                 // 账号状态：1=正常，2=暂停，3=封禁（按当前生效状态，暂停到期视为正常）
 
 	// 模糊查询
 	UsernameLike string `form:"username_like" json:"username_like"` // 用户名（模糊匹配，LIKE %value%）
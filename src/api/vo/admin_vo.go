@@ -7,19 +7,25 @@ import (
 
 // AdminVO 管理员值对象
 type AdminVO struct {
-	ID        uint   `json:"id"`
-	Username  string `json:"username"`
-	CreatedAt int64  `json:"created_at"`
-	UpdatedAt int64  `json:"updated_at"`
+	ID          uint   `json:"id"`
+	Username    string `json:"username"`
+	RoleID      *uint  `json:"role_id"`      // 角色ID（null 表示未分配角色）
+	Status      int8   `json:"status"`       // 状态：1正常 2禁用
+	TOTPEnabled bool   `json:"totp_enabled"` // 是否已启用两步验证
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
 }
 
 // FromAdminModel 从模型转换为VO
 func FromAdminModel(admin *models.Admin) *AdminVO {
 	return &AdminVO{
-		ID:        admin.ID,
-		Username:  admin.Username,
-		CreatedAt: admin.CreatedAt,
-		UpdatedAt: admin.UpdatedAt,
+		ID:          admin.ID,
+		Username:    admin.Username,
+		RoleID:      admin.RoleID,
+		Status:      admin.Status,
+		TOTPEnabled: admin.TOTPEnabled,
+		CreatedAt:   admin.CreatedAt,
+		UpdatedAt:   admin.UpdatedAt,
 	}
 }
 
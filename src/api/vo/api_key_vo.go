@@ -0,0 +1,49 @@
+// Package vo API Key 相关值对象
+package vo
+
+import (
+	"strings"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+)
+
+// APIKeyVO API Key 值对象（不包含 Key 明文）
+type APIKeyVO struct {
+	ID         uint     `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  *int64   `json:"expires_at"`
+	LastUsedAt *int64   `json:"last_used_at"`
+	RevokedAt  *int64   `json:"revoked_at"`
+	CreatedAt  int64    `json:"created_at"`
+}
+
+// APIKeyCreatedVO 新建 API Key 值对象（Key 明文仅此一次返回）
+type APIKeyCreatedVO struct {
+	*APIKeyVO
+	Key string `json:"key"`
+}
+
+// FromAPIKeyModel 从模型转换为VO
+func FromAPIKeyModel(apiKey *models.APIKey) *APIKeyVO {
+	return &APIKeyVO{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     strings.Split(apiKey.Scopes, ","),
+		ExpiresAt:  apiKey.ExpiresAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
+
+// FromAPIKeyModelList 从模型列表转换为VO列表
+func FromAPIKeyModelList(apiKeys []*models.APIKey) []*APIKeyVO {
+	result := make([]*APIKeyVO, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		result[i] = FromAPIKeyModel(apiKey)
+	}
+	return result
+}
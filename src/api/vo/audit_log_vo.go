@@ -0,0 +1,57 @@
+// Package vo 审计日志相关值对象
+package vo
+
+import (
+	"encoding/json"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+)
+
+// AuditLogVO 审计日志值对象
+type AuditLogVO struct {
+	ID         uint            `json:"id"`
+	ActorID    uint            `json:"actor_id"`
+	ActorRole  string          `json:"actor_role"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   string          `json:"target_id"`
+	Before     json.RawMessage `json:"before"` // 变更前（仅变更字段），创建时为 null
+	After      json.RawMessage `json:"after"`  // 变更后（仅变更字段），删除时为 null
+	IP         string          `json:"ip"`
+	RequestID  string          `json:"request_id"`
+	CreatedAt  int64           `json:"created_at"`
+}
+
+// FromAuditLogModel 从模型转换为VO
+func FromAuditLogModel(log *models.AuditLog) *AuditLogVO {
+	return &AuditLogVO{
+		ID:         log.ID,
+		ActorID:    log.ActorID,
+		ActorRole:  log.ActorRole,
+		Action:     log.Action,
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID,
+		Before:     rawJSON(log.Before),
+		After:      rawJSON(log.After),
+		IP:         log.IP,
+		RequestID:  log.RequestID,
+		CreatedAt:  log.CreatedAt,
+	}
+}
+
+// FromAuditLogModelList 从模型列表转换为VO列表
+func FromAuditLogModelList(logs []*models.AuditLog) []*AuditLogVO {
+	result := make([]*AuditLogVO, len(logs))
+	for i, log := range logs {
+		result[i] = FromAuditLogModel(log)
+	}
+	return result
+}
+
+// rawJSON 将数据库中的 JSON 字符串原样输出（NULL 输出为 null）
+func rawJSON(value *string) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(*value)
+}
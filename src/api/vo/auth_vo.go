@@ -3,7 +3,28 @@ package vo
 
 // TokenVO Token响应值对象
 type TokenVO struct {
-	AccessToken string `json:"access_token"`
-	ExpiresIn   int64  `json:"expires_in"` // 过期时间戳
+	AccessToken      string `json:"access_token"`
+	ExpiresIn        int64  `json:"expires_in"` // 过期时间戳
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresIn int64  `json:"refresh_expires_in"` // Refresh Token 过期时间戳
 }
 
+// MFAChallengeVO 两步验证挑战值对象（密码校验通过、等待两步验证时返回）
+type MFAChallengeVO struct {
+	MFARequired           bool   `json:"mfa_required"`
+	MFAEnrollmentRequired bool   `json:"mfa_enrollment_required"` // 账号需先绑定两步验证（/auth/mfa/enroll/...）
+	MFAToken              string `json:"mfa_token"`
+	ExpiresIn             int64  `json:"expires_in"` // 挑战令牌过期时间戳
+}
+
+// MFAEnrollVO 登录时绑定两步验证结果值对象（恢复码仅此一次展示）
+type MFAEnrollVO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	Tokens        *TokenVO `json:"tokens"`
+}
+
+// OIDCAuthorizeVO 第三方登录授权地址值对象
+type OIDCAuthorizeVO struct {
+	AuthorizationURL string `json:"authorization_url"` // 身份提供方授权地址（前端跳转）
+	State            string `json:"state"`             // 授权请求标识（回调时原样返回）
+}
@@ -0,0 +1,35 @@
+// Package vo 登录锁定值对象
+package vo
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+)
+
+// LoginLockoutVO 登录锁定值对象
+type LoginLockoutVO struct {
+	Role       string `json:"role"`
+	Type       string `json:"type"`
+	Value      string `json:"value"`
+	Failures   int64  `json:"failures"`
+	RetryAfter int64  `json:"retry_after"` // 剩余锁定秒数
+}
+
+// FromLoginLockoutModel 从模型转换为VO
+func FromLoginLockoutModel(lockout *models.LoginLockout) *LoginLockoutVO {
+	return &LoginLockoutVO{
+		Role:       lockout.Role,
+		Type:       lockout.Type,
+		Value:      lockout.Value,
+		Failures:   lockout.Failures,
+		RetryAfter: lockout.RetryAfter,
+	}
+}
+
+// FromLoginLockoutModelList 从模型列表转换为VO列表
+func FromLoginLockoutModelList(lockouts []*models.LoginLockout) []*LoginLockoutVO {
+	result := make([]*LoginLockoutVO, len(lockouts))
+	for i, lockout := range lockouts {
+		result[i] = FromLoginLockoutModel(lockout)
+	}
+	return result
+}
@@ -0,0 +1,13 @@
+// Package vo 两步验证相关值对象
+package vo
+
+// TOTPSetupVO TOTP 密钥值对象
+type TOTPSetupVO struct {
+	Secret     string `json:"secret"`      // Base32 密钥（手动输入用）
+	OtpauthURI string `json:"otpauth_uri"` // otpauth URI（生成二维码用）
+}
+
+// RecoveryCodesVO 恢复码值对象（仅启用时展示一次）
+type RecoveryCodesVO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,119 @@
+// Package vo 积分套餐和订单相关值对象
+package vo
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+)
+
+// PointsProductVO 积分套餐值对象
+type PointsProductVO struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Points    int    `json:"points"` // 获得积分
+	Price     int    `json:"price"`  // 价格（分）
+	Status    int8   `json:"status"` // 1=上架，2=下架
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// FromPointsProductModel 从模型转换为VO
+func FromPointsProductModel(product *models.PointsProduct) *PointsProductVO {
+	return &PointsProductVO{
+		ID:        product.ID,
+		Name:      product.Name,
+		Points:    product.Points,
+		Price:     product.Price,
+		Status:    product.Status,
+		CreatedAt: product.CreatedAt,
+		UpdatedAt: product.UpdatedAt,
+	}
+}
+
+// FromPointsProductModelList 从模型列表转换为VO列表
+func FromPointsProductModelList(products []*models.PointsProduct) []*PointsProductVO {
+	result := make([]*PointsProductVO, len(products))
+	for i, product := range products {
+		result[i] = FromPointsProductModel(product)
+	}
+	return result
+}
+
+// PointsOrderVO 积分订单值对象（用户视图）
+type PointsOrderVO struct {
+	ID            uint   `json:"id"`
+	OrderNo       string `json:"order_no"`
+	ProductID     uint   `json:"product_id"`
+	ProductName   string `json:"product_name"`
+	Points        int    `json:"points"`         // 获得积分
+	Amount        int    `json:"amount"`         // 支付金额（分）
+	Status        int8   `json:"status"`         // 1=待支付，2=已支付，3=已到账，4=已退款
+	TransactionID *uint  `json:"transaction_id"` // 到账流水ID
+	PaidAt        *int64 `json:"paid_at"`
+	FulfilledAt   *int64 `json:"fulfilled_at"`
+	RefundedAt    *int64 `json:"refunded_at"`
+	CreatedAt     int64  `json:"created_at"`
+}
+
+// AdminPointsOrderVO 积分订单值对象（管理员视图）
+type AdminPointsOrderVO struct {
+	*PointsOrderVO
+	UserID              uint   `json:"user_id"`
+	Provider            string `json:"provider"`              // 支付渠道
+	PaymentID           string `json:"payment_id"`            // 渠道支付ID
+	RefundTransactionID *uint  `json:"refund_transaction_id"` // 退款扣回流水ID
+	RefundReason        string `json:"refund_reason"`
+}
+
+// PointsOrderCreateVO 下单结果值对象
+type PointsOrderCreateVO struct {
+	Order  *PointsOrderVO `json:"order"`
+	PayURL string         `json:"pay_url"` // 完成支付的地址
+}
+
+// FromPointsOrderModel 从模型转换为VO
+func FromPointsOrderModel(order *models.PointsOrder) *PointsOrderVO {
+	return &PointsOrderVO{
+		ID:            order.ID,
+		OrderNo:       order.OrderNo,
+		ProductID:     order.ProductID,
+		ProductName:   order.ProductName,
+		Points:        order.Points,
+		Amount:        order.Amount,
+		Status:        order.Status,
+		TransactionID: order.TransactionID,
+		PaidAt:        order.PaidAt,
+		FulfilledAt:   order.FulfilledAt,
+		RefundedAt:    order.RefundedAt,
+		CreatedAt:     order.CreatedAt,
+	}
+}
+
+// FromPointsOrderModelList 从模型列表转换为VO列表
+func FromPointsOrderModelList(orders []*models.PointsOrder) []*PointsOrderVO {
+	result := make([]*PointsOrderVO, len(orders))
+	for i, order := range orders {
+		result[i] = FromPointsOrderModel(order)
+	}
+	return result
+}
+
+// FromAdminPointsOrderModel 从模型转换为管理员视图VO
+func FromAdminPointsOrderModel(order *models.PointsOrder) *AdminPointsOrderVO {
+	return &AdminPointsOrderVO{
+		PointsOrderVO:       FromPointsOrderModel(order),
+		UserID:              order.UserID,
+		Provider:            order.Provider,
+		PaymentID:           order.PaymentID,
+		RefundTransactionID: order.RefundTransactionID,
+		RefundReason:        order.RefundReason,
+	}
+}
+
+// FromAdminPointsOrderModelList 从模型列表转换为管理员视图VO列表
+func FromAdminPointsOrderModelList(orders []*models.PointsOrder) []*AdminPointsOrderVO {
+	result := make([]*AdminPointsOrderVO, len(orders))
+	for i, order := range orders {
+		result[i] = FromAdminPointsOrderModel(order)
+	}
+	return result
+}
@@ -0,0 +1,10 @@
+// Package vo 积分变更日志值对象
+package vo
+
+// JournalCheckpointVO 积分变更日志检查点值对象
+type JournalCheckpointVO struct {
+	Seq       uint64 `json:"seq"`        // 链头序号（已写入的日志行数）
+	Hash      string `json:"hash"`       // 链头哈希
+	CreatedAt int64  `json:"created_at"` // 签发时间
+	Signature string `json:"signature"`  // 签名令牌（JWS，载荷包含 seq 和 hash，可通过 JWKS 验签）
+}
@@ -0,0 +1,93 @@
+// Package vo 积分相关值对象
+package vo
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+)
+
+// PointsTransactionVO 积分流水值对象（用户视图）
+type PointsTransactionVO struct {
+	ID           uint   `json:"id"`
+	Delta        int    `json:"delta"`         // 变动值（正数增加，负数扣除）
+	BalanceAfter int    `json:"balance_after"` // 变动后余额
+	Reason       string `json:"reason"`        // 变动原因
+	ReferenceID  string `json:"reference_id"`  // 关联业务ID
+	CreatedAt    int64  `json:"created_at"`
+}
+
+// AdminPointsTransactionVO 积分流水值对象（管理员视图）
+type AdminPointsTransactionVO struct {
+	*PointsTransactionVO
+	UserID         uint   `json:"user_id"`
+	CounterAccount string `json:"counter_account"` // 对方系统账户
+	ActorID        uint   `json:"actor_id"`
+	ActorRole      string `json:"actor_role"`
+	RequestID      string `json:"request_id"`
+}
+
+// FromPointsTransactionModel 从模型转换为VO
+func FromPointsTransactionModel(txn *models.PointsTransaction) *PointsTransactionVO {
+	return &PointsTransactionVO{
+		ID:           txn.ID,
+		Delta:        txn.Delta,
+		BalanceAfter: txn.BalanceAfter,
+		Reason:       txn.Reason,
+		ReferenceID:  txn.ReferenceID,
+		CreatedAt:    txn.CreatedAt,
+	}
+}
+
+// FromPointsTransactionModelList 从模型列表转换为VO列表
+func FromPointsTransactionModelList(txns []*models.PointsTransaction) []*PointsTransactionVO {
+	result := make([]*PointsTransactionVO, len(txns))
+	for i, txn := range txns {
+		result[i] = FromPointsTransactionModel(txn)
+	}
+	return result
+}
+
+// FromAdminPointsTransactionModelList 从模型列表转换为管理员视图VO列表
+func FromAdminPointsTransactionModelList(txns []*models.PointsTransaction) []*AdminPointsTransactionVO {
+	result := make([]*AdminPointsTransactionVO, len(txns))
+	for i, txn := range txns {
+		result[i] = &AdminPointsTransactionVO{
+			PointsTransactionVO: FromPointsTransactionModel(txn),
+			UserID:              txn.UserID,
+			CounterAccount:      txn.CounterAccount,
+			ActorID:             txn.ActorID,
+			ActorRole:           txn.ActorRole,
+			RequestID:           txn.RequestID,
+		}
+	}
+	return result
+}
+
+// PointsHoldVO 积分预留值对象
+type PointsHoldVO struct {
+	ID             uint   `json:"id"`
+	UserID         uint   `json:"user_id"`
+	Points         int    `json:"points"`          // 预留积分
+	Status         int8   `json:"status"`          // 1=预留中，2=已扣除，3=已释放，4=已过期
+	CapturedPoints int    `json:"captured_points"` // 实际扣除积分
+	ReleaseReason  string `json:"release_reason"`  // 释放原因
+	ReferenceID    string `json:"reference_id"`    // 关联业务ID
+	ExpiresAt      int64  `json:"expires_at"`
+	SettledAt      *int64 `json:"settled_at"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+// FromPointsHoldModel 从模型转换为VO
+func FromPointsHoldModel(hold *models.PointsHold) *PointsHoldVO {
+	return &PointsHoldVO{
+		ID:             hold.ID,
+		UserID:         hold.UserID,
+		Points:         hold.Points,
+		Status:         hold.Status,
+		CapturedPoints: hold.CapturedPoints,
+		ReleaseReason:  hold.ReleaseReason,
+		ReferenceID:    hold.ReferenceID,
+		ExpiresAt:      hold.ExpiresAt,
+		SettledAt:      hold.SettledAt,
+		CreatedAt:      hold.CreatedAt,
+	}
+}
@@ -0,0 +1,67 @@
+// Package vo 兑换码相关值对象
+package vo
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+)
+
+// RedeemCampaignVO 兑换码活动值对象
+type RedeemCampaignVO struct {
+	ID             uint   `json:"id"`
+	Name           string `json:"name"`
+	Points         int    `json:"points"`            // 每次兑换获得的积分
+	CodeCount      int    `json:"code_count"`        // 兑换码数量
+	MaxUsesPerCode int    `json:"max_uses_per_code"` // 每个兑换码可使用次数
+	PerUserLimit   int    `json:"per_user_limit"`    // 每个用户可兑换次数
+	StartsAt       int64  `json:"starts_at"`
+	EndsAt         int64  `json:"ends_at"`
+	Redeemed       int    `json:"redeemed"` // 已兑换次数
+	CreatedBy      uint   `json:"created_by"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+// FromRedeemCampaignModel 从模型转换为VO
+func FromRedeemCampaignModel(campaign *models.RedeemCampaign, redeemed int) *RedeemCampaignVO {
+	return &RedeemCampaignVO{
+		ID:             campaign.ID,
+		Name:           campaign.Name,
+		Points:         campaign.Points,
+		CodeCount:      campaign.CodeCount,
+		MaxUsesPerCode: campaign.MaxUsesPerCode,
+		PerUserLimit:   campaign.PerUserLimit,
+		StartsAt:       campaign.StartsAt,
+		EndsAt:         campaign.EndsAt,
+		Redeemed:       redeemed,
+		CreatedBy:      campaign.CreatedBy,
+		CreatedAt:      campaign.CreatedAt,
+	}
+}
+
+// FromRedeemCampaignModelList 从模型列表转换为VO列表（redeemed 为各活动的已兑换次数）
+func FromRedeemCampaignModelList(campaigns []*models.RedeemCampaign, redeemed map[uint]int) []*RedeemCampaignVO {
+	result := make([]*RedeemCampaignVO, len(campaigns))
+	for i, campaign := range campaigns {
+		result[i] = FromRedeemCampaignModel(campaign, redeemed[campaign.ID])
+	}
+	return result
+}
+
+// RedeemRedemptionVO 兑换结果值对象
+type RedeemRedemptionVO struct {
+	ID            uint  `json:"id"`
+	CampaignID    uint  `json:"campaign_id"`
+	Points        int   `json:"points"`         // 获得积分
+	TransactionID uint  `json:"transaction_id"` // 积分流水ID
+	CreatedAt     int64 `json:"created_at"`     // 兑换时间
+}
+
+// FromRedeemRedemptionModel 从模型转换为VO
+func FromRedeemRedemptionModel(redemption *models.RedeemRedemption) *RedeemRedemptionVO {
+	return &RedeemRedemptionVO{
+		ID:            redemption.ID,
+		CampaignID:    redemption.CampaignID,
+		Points:        redemption.Points,
+		TransactionID: redemption.TransactionID,
+		CreatedAt:     redemption.CreatedAt,
+	}
+}
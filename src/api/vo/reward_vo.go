@@ -0,0 +1,36 @@
+// Package vo 积分奖励相关值对象
+package vo
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+)
+
+// RewardGrantVO 积分奖励值对象
+type RewardGrantVO struct {
+	ID            uint   `json:"id"`
+	Type          string `json:"type"`           // 奖励类型（与积分变动原因一致）
+	Day           string `json:"day"`            // 发放日期
+	Points        int    `json:"points"`         // 奖励积分
+	TransactionID uint   `json:"transaction_id"` // 积分流水ID
+	CreatedAt     int64  `json:"created_at"`
+}
+
+// FromRewardGrantModel 从模型转换为VO
+func FromRewardGrantModel(grant *models.RewardGrant) *RewardGrantVO {
+	return &RewardGrantVO{
+		ID:            grant.ID,
+		Type:          grant.Type,
+		Day:           grant.Day,
+		Points:        grant.Points,
+		TransactionID: grant.TransactionID,
+		CreatedAt:     grant.CreatedAt,
+	}
+}
+
+// ReferralVO 邀请信息值对象
+type ReferralVO struct {
+	InviteCode   string `json:"invite_code"`   // 本人的邀请码
+	InvitedCount int64  `json:"invited_count"` // 已邀请注册的用户数
+	RewardCount  int64  `json:"reward_count"`  // 获得邀请奖励的次数（超出上限的邀请不发放）
+	RewardPoints int    `json:"reward_points"` // 邀请奖励积分合计
+}
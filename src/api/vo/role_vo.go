@@ -0,0 +1,38 @@
+// Package vo 角色值对象
+package vo
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+)
+
+// RoleVO 角色值对象
+type RoleVO struct {
+	ID          uint     `json:"id"`
+	Code        string   `json:"code"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"` // 权限编码（super_admin 拥有全部权限，此处为空）
+}
+
+// FromRoleModel 从模型转换为VO
+func FromRoleModel(role *models.Role, permissions []string) *RoleVO {
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return &RoleVO{
+		ID:          role.ID,
+		Code:        role.Code,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+	}
+}
+
+// FromRoleModelList 从模型列表转换为VO列表
+func FromRoleModelList(roles []*models.Role, permissions map[uint][]string) []*RoleVO {
+	result := make([]*RoleVO, len(roles))
+	for i, role := range roles {
+		result[i] = FromRoleModel(role, permissions[role.ID])
+	}
+	return result
+}
@@ -0,0 +1,37 @@
+// Package vo 会话相关值对象
+package vo
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+)
+
+// SessionVO 登录会话值对象
+type SessionVO struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	CreatedAt  int64  `json:"created_at"`
+	LastSeenAt int64  `json:"last_seen_at"`
+	Current    bool   `json:"current"` // 是否为当前请求所在会话
+}
+
+// FromSessionModel 从模型转换为VO
+func FromSessionModel(session *models.Session, currentID string) *SessionVO {
+	return &SessionVO{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		Current:    session.ID == currentID,
+	}
+}
+
+// FromSessionModelList 从模型列表转换为VO列表
+func FromSessionModelList(sessions []*models.Session, currentID string) []*SessionVO {
+	result := make([]*SessionVO, len(sessions))
+	for i, session := range sessions {
+		result[i] = FromSessionModel(session, currentID)
+	}
+	return result
+}
@@ -2,17 +2,38 @@
 package vo
 
 import (
+	"time"
+
 	"github.com/Company-Automation-1/video-backend-go/src/models"
 )
 
 // UserVO 用户值对象
 type UserVO struct {
-	ID        uint   `json:"id"`
-	Username  string `json:"username"`
-	Email     string `json:"email"`
-	Points    int    `json:"points"` // 前端显示：NULL 显示为 0
-	CreatedAt int64  `json:"created_at"`
-	UpdatedAt int64  `json:"updated_at"`
+	ID              uint   `json:"id"`
+	Username        string `json:"username"`
+	Email           string `json:"email"`
+	Points          int    `json:"points"`                    // 前端显示：NULL 显示为 0
+	HeldPoints      int    `json:"held_points"`               // 已预留积分（处理中的任务）
+	AvailablePoints int    `json:"available_points"`          // 可用积分（积分 - 已预留积分）
+	TOTPEnabled     bool   `json:"totp_enabled"`              // 是否已启用两步验证
+	Status          int8   `json:"status"`                    // 当前生效状态：1=正常，2=暂停，3=封禁
+	StatusReason    string `json:"status_reason,omitempty"`   // 暂停/封禁原因
+	SuspendedUntil  *int64 `json:"suspended_until,omitempty"` // 暂停截止时间（Unix时间戳）
+	CreatedAt       int64  `json:"created_at"`
+	UpdatedAt       int64  `json:"updated_at"`
+}
+
+// UserProfileVO 个人信息值对象（本人视图）
+type UserProfileVO struct {
+	*UserVO
+	ExpiringSoon *ExpiringPointsVO `json:"expiring_soon"` // 即将过期的积分
+}
+
+// ExpiringPointsVO 即将过期的积分
+type ExpiringPointsVO struct {
+	Points    int    `json:"points"`     // 统计范围内将过期的积分合计
+	ExpiresAt *int64 `json:"expires_at"` // 最早的过期时间（Unix时间戳，没有时为 null）
+	Days      int    `json:"days"`       // 统计范围（天）
 }
 
 // FromModel 从模型转换为VO
@@ -21,14 +42,24 @@ func FromModel(user *models.User) *UserVO {
 	if user.Points != nil {
 		points = *user.Points
 	}
-	return &UserVO{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		Points:    points, // NULL 时显示为 0
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	status := user.CurrentStatus(time.Now().Unix())
+	vo := &UserVO{
+		ID:              user.ID,
+		Username:        user.Username,
+		Email:           user.Email,
+		Points:          points, // NULL 时显示为 0
+		HeldPoints:      user.HeldPoints,
+		AvailablePoints: points - user.HeldPoints,
+		TOTPEnabled:     user.TOTPEnabled,
+		Status:          status,
+		CreatedAt:       user.CreatedAt,
+		UpdatedAt:       user.UpdatedAt,
+	}
+	if status != models.UserStatusActive {
+		vo.StatusReason = user.StatusReason
+		vo.SuspendedUntil = user.SuspendedUntil
 	}
+	return vo
 }
 
 // FromModelList 从模型列表转换为VO列表
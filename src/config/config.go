@@ -3,6 +3,7 @@ package config
 
 import (
 	"fmt"
+	"log"
 	"os"
 
 	"github.com/goccy/go-yaml"
@@ -10,12 +11,20 @@ import (
 
 // Config 应用配置
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	CORS     CORSConfig     `yaml:"cors"`
-	Redis    RedisConfig    `yaml:"redis"`
-	Email    EmailConfig    `yaml:"email"`
-	JWT      JWTConfig      `yaml:"jwt"`
+	Server     ServerConfig     `yaml:"server"`
+	Database   DatabaseConfig   `yaml:"database"`
+	CORS       CORSConfig       `yaml:"cors"`
+	Redis      RedisConfig      `yaml:"redis"`
+	Email      EmailConfig      `yaml:"email"`
+	JWT        JWTConfig        `yaml:"jwt"`
+	LoginGuard LoginGuardConfig `yaml:"login_guard"`
+	Captcha    CaptchaConfig    `yaml:"captcha"`
+	MFA        MFAConfig        `yaml:"mfa"`
+	OIDC       OIDCConfig       `yaml:"oidc"`
+	Proxy      ProxyConfig      `yaml:"proxy"`
+	Rewards    RewardsConfig    `yaml:"rewards"`
+	Points     PointsConfig     `yaml:"points"`
+	Payment    PaymentConfig    `yaml:"payment"`
 }
 
 // ServerConfig 服务器配置
@@ -68,10 +77,150 @@ type EmailConfig struct {
 
 // JWTConfig JWT配置
 type JWTConfig struct {
-	Secret     string `yaml:"secret"`      // JWT密钥
-	ExpireTime int    `yaml:"expire_time"` // 过期时间（小时）
+	Secret            string         `yaml:"secret"`              // JWT密钥（HS256，未配置 signing_key_id 时必填）
+	AccessExpireTime  int            `yaml:"access_expire_time"`  // Access Token 过期时间（分钟）
+	ExpireTime        int            `yaml:"expire_time"`         // 已废弃：旧版 Token 过期时间（小时），未配置 access_expire_time 时沿用
+	RefreshExpireTime int            `yaml:"refresh_expire_time"` // Refresh Token 过期时间（小时）
+	SigningKeyID      string         `yaml:"signing_key_id"`      // 当前用于签名的非对称密钥ID（kid），为空时使用 HS256
+	CheckpointKeyID   string         `yaml:"checkpoint_key_id"`   // 积分日志检查点签名密钥ID（kid），为空时使用 signing_key_id
+	HS256GracePeriod  int            `yaml:"hs256_grace_period"`  // 启用 signing_key_id 后继续接受 HS256 令牌的时长（分钟，从启动时算起，0 表示不接受）
+	Keys              []JWTKeyConfig `yaml:"keys"`                // 非对称密钥列表（轮换期间旧密钥保留用于验签）
 }
 
+// JWTKeyConfig JWT非对称密钥配置
+type JWTKeyConfig struct {
+	ID             string `yaml:"id"`               // 密钥ID（kid）
+	Algorithm      string `yaml:"algorithm"`        // 签名算法：RS256 或 EdDSA
+	PrivateKeyFile string `yaml:"private_key_file"` // 私钥PEM文件（签名密钥必填，仅验签的旧密钥可不配置）
+	PublicKeyFile  string `yaml:"public_key_file"`  // 公钥PEM文件（未配置时从私钥导出）
+}
+
+// LoginGuardConfig 登录防暴力破解配置
+type LoginGuardConfig struct {
+	MaxFailures   int `yaml:"max_failures"`    // 同一用户名连续失败多少次后锁定
+	IPMaxFailures int `yaml:"ip_max_failures"` // 同一IP失败多少次后锁定
+	FailureWindow int `yaml:"failure_window"`  // 失败计数窗口（秒）
+	BaseLockout   int `yaml:"base_lockout"`    // 首次锁定时长（秒），此后每多失败一次翻倍
+	MaxLockout    int `yaml:"max_lockout"`     // 最长锁定时长（秒）
+}
+
+// CaptchaConfig 邮箱验证码配置
+type CaptchaConfig struct {
+	MaxAttempts     int `yaml:"max_attempts"`      // 单个验证码允许的错误次数，超过后作废
+	EmailDailyLimit int `yaml:"email_daily_limit"` // 每个邮箱每日发送上限
+	IPDailyLimit    int `yaml:"ip_daily_limit"`    // 每个IP每日发送上限
+}
+
+// MFAConfig 两步验证配置
+type MFAConfig struct {
+	Issuer       string `yaml:"issuer"`        // 验证器应用中显示的发行方名称
+	RequireAdmin bool   `yaml:"require_admin"` // 管理员必须启用两步验证（未启用时登录需先绑定）
+}
+
+// OIDCConfig 第三方登录（OpenID Connect）配置
+type OIDCConfig struct {
+	Providers []OIDCProviderConfig `yaml:"providers"` // 身份提供方列表
+}
+
+// OIDCProviderConfig OpenID Connect 身份提供方配置
+type OIDCProviderConfig struct {
+	Name         string   `yaml:"name"`          // 提供方名称（用于路由 /auth/oidc/:provider）
+	Issuer       string   `yaml:"issuer"`        // Issuer 地址（从 <issuer>/.well-known/openid-configuration 获取端点）
+	ClientID     string   `yaml:"client_id"`     // 客户端ID
+	ClientSecret string   `yaml:"client_secret"` // 客户端密钥
+	RedirectURL  string   `yaml:"redirect_url"`  // 授权回调地址（前端页面，拿到 code/state 后调用回调接口）
+	Scopes       []string `yaml:"scopes"`        // 授权范围
+}
+
+// ProxyConfig Python 服务透传配置
+type ProxyConfig struct {
+	PythonURL     string             `yaml:"python_url"`     // Python 服务地址
+	CallbackToken string             `yaml:"callback_token"` // Python 服务回调结算积分预留时携带的令牌（为空时拒绝回调）
+	MaxBodyMB     int                `yaml:"max_body_mb"`    // 按请求体计价时暂存请求体的大小上限（MB），超出返回 413
+	Routes        []ProxyRouteConfig `yaml:"routes"`         // 需要鉴权或计费的接口（按顺序匹配，第一条命中的生效，未命中的请求直接透传）
+}
+
+// ProxyRouteConfig 透传接口规则
+type ProxyRouteConfig struct {
+	Path   string          `yaml:"path"`   // 路径模式（不含 /api/py 前缀，支持 path.Match 通配符，如 /process_*）
+	Method string          `yaml:"method"` // 请求方法（为空表示全部方法）
+	Auth   bool            `yaml:"auth"`   // 是否需要登录（计费接口必须为 true）
+	Scope  string          `yaml:"scope"`  // 允许 API Key 调用时要求的授权范围（为空时不接受 API Key）
+	Reason string          `yaml:"reason"` // 积分流水变动原因（需为已登记的原因）
+	Cost   ProxyCostConfig `yaml:"cost"`   // 积分价格
+
+	// Async 异步接口：上游接受任务后保持积分预留，由 Python 服务回调结算（预留ID通过 X-Points-Hold-ID 请求头传给 Python 服务）
+	Async       bool `yaml:"async"`
+	HoldTimeout int  `yaml:"hold_timeout"` // 积分预留超时时间（秒），超时未结算自动释放
+}
+
+// ProxyCostConfig 接口积分价格：fixed + per_mb × 上传文件MB数（向上取整） + field_values[表单字段 field 的值]
+// + 视频分钟数（不足1分钟按1分钟） × (per_minute + 视频分辨率所在档位的 per_minute)
+type ProxyCostConfig struct {
+	Fixed           int                         `yaml:"fixed"`            // 固定价格
+	PerMB           int                         `yaml:"per_mb"`           // 上传文件每MB价格
+	Field           string                      `yaml:"field"`            // 参与计价的表单字段（如 quality）
+	FieldValues     map[string]int              `yaml:"field_values"`     // 表单字段取值对应的加价（未列出的取值不加价）
+	PerMinute       int                         `yaml:"per_minute"`       // 视频每分钟价格（配置后上传文件须为 MP4/MOV）
+	ResolutionTiers []ProxyResolutionTierConfig `yaml:"resolution_tiers"` // 视频分辨率档位加价（取满足条件的最高档）
+}
+
+// ProxyResolutionTierConfig 视频分辨率档位
+type ProxyResolutionTierConfig struct {
+	MinShortSide int `yaml:"min_short_side"` // 视频短边像素不低于该值时适用（如 1080、2160）
+	PerMinute    int `yaml:"per_minute"`     // 每分钟加价
+}
+
+// RewardsConfig 积分奖励配置（积分为 0 的奖励不发放）
+type RewardsConfig struct {
+	Signup   RewardRuleConfig     `yaml:"signup"`   // 注册奖励（每个用户一次）
+	CheckIn  RewardRuleConfig     `yaml:"check_in"` // 每日签到奖励（每天一次）
+	Referral ReferralRewardConfig `yaml:"referral"` // 邀请奖励（被邀请人注册成功后发放）
+}
+
+// RewardRuleConfig 奖励规则（次数上限按用户计算，0 表示不限）
+type RewardRuleConfig struct {
+	Points     int `yaml:"points"`      // 每次奖励积分
+	DailyLimit int `yaml:"daily_limit"` // 每个用户每天最多获得次数
+	TotalLimit int `yaml:"total_limit"` // 每个用户累计最多获得次数
+}
+
+// ReferralRewardConfig 邀请奖励规则（次数上限作用于邀请人）
+type ReferralRewardConfig struct {
+	RewardRuleConfig `yaml:",inline"`
+	InviteePoints    int `yaml:"invitee_points"` // 被邀请人额外获得的积分（每个用户一次）
+}
+
+// PointsConfig 积分有效期配置（每笔获得的积分为一个批次，到期后未使用的部分过期）
+type PointsConfig struct {
+	ExpireDays       int            `yaml:"expire_days"`        // 获得的积分有效天数（0 表示永不过期）
+	ReasonExpireDays map[string]int `yaml:"reason_expire_days"` // 按变动原因覆盖有效天数（如 check_in: 30，0 表示永不过期）
+	ExpiringSoonDays int            `yaml:"expiring_soon_days"` // 个人信息中"即将过期"的统计范围（天）
+}
+
+// ExpireDaysFor 获取指定变动原因获得的积分有效天数
+func (c *PointsConfig) ExpireDaysFor(reason string) int {
+	if days, ok := c.ReasonExpireDays[reason]; ok {
+		return days
+	}
+	return c.ExpireDays
+}
+
+// PaymentConfig 支付配置
+type PaymentConfig struct {
+	Provider         string `yaml:"provider"`          // 支付渠道（fake 为本地模拟支付，仅用于开发测试；为空时不开放购买）
+	WebhookSecret    string `yaml:"webhook_secret"`    // 支付回调签名密钥（HMAC-SHA256）
+	WebhookTolerance int    `yaml:"webhook_tolerance"` // 回调签名时间与服务器时间允许的偏差（秒），超出视为重放
+}
+
+// 旧版示例配置中的占位密钥：配置仍为这些值时拒绝启动
+const (
+	// JWTSecretPlaceholder jwt.secret 的占位值
+	JWTSecretPlaceholder = "your-secret-key-change-in-production"
+	// WebhookSecretPlaceholder payment.webhook_secret 的占位值
+	WebhookSecretPlaceholder = "your-webhook-secret-change-in-production"
+)
+
 // Load 从文件加载配置
 func Load(configPath string) (*Config, error) {
 	data, err := os.ReadFile(configPath) //nolint:gosec // 配置文件路径由调用方控制
@@ -91,6 +240,13 @@ func Load(configPath string) (*Config, error) {
 	cfg.Redis.setDefaults()
 	cfg.Email.setDefaults()
 	cfg.JWT.setDefaults()
+	cfg.LoginGuard.setDefaults()
+	cfg.Captcha.setDefaults()
+	cfg.MFA.setDefaults()
+	cfg.OIDC.setDefaults()
+	cfg.Proxy.setDefaults()
+	cfg.Points.setDefaults()
+	cfg.Payment.setDefaults()
 
 	return &cfg, nil
 }
@@ -133,7 +289,7 @@ func (c *CORSConfig) setDefaults() {
 		c.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
 	}
 	if c.ExposeHeaders == nil {
-		c.ExposeHeaders = []string{"Content-Length"}
+		c.ExposeHeaders = []string{"Content-Length", "X-Request-ID", "X-Points-Cost"}
 	}
 	if c.MaxAge == 0 {
 		c.MaxAge = 12 * 3600
@@ -169,11 +325,109 @@ func (c *EmailConfig) setDefaults() {
 
 // setDefaults 设置JWT配置的默认值
 func (c *JWTConfig) setDefaults() {
-	if c.Secret == "" {
-		c.Secret = "your-secret-key-change-in-production"
+	if c.ExpireTime != 0 {
+		if c.AccessExpireTime == 0 {
+			c.AccessExpireTime = c.ExpireTime * 60
+			log.Printf("警告: jwt.expire_time 已废弃，暂按 %d 分钟作为 Access Token 过期时间，请改用 jwt.access_expire_time（分钟）",
+				c.AccessExpireTime)
+		} else {
+			log.Println("警告: jwt.expire_time 已废弃且已配置 jwt.access_expire_time，expire_time 被忽略，请删除该配置")
+		}
+	}
+	if c.AccessExpireTime == 0 {
+		c.AccessExpireTime = 15 // 默认15分钟
+	}
+	if c.RefreshExpireTime == 0 {
+		c.RefreshExpireTime = 7 * 24 // 默认7天
+	}
+}
+
+// setDefaults 设置登录防暴力破解配置的默认值
+func (c *LoginGuardConfig) setDefaults() {
+	if c.MaxFailures == 0 {
+		c.MaxFailures = 5
+	}
+	if c.IPMaxFailures == 0 {
+		c.IPMaxFailures = 20
+	}
+	if c.FailureWindow == 0 {
+		c.FailureWindow = 15 * 60 // 默认15分钟
 	}
-	if c.ExpireTime == 0 {
-		c.ExpireTime = 24 // 默认24小时
+	if c.BaseLockout == 0 {
+		c.BaseLockout = 60 // 默认1分钟
+	}
+	if c.MaxLockout == 0 {
+		c.MaxLockout = 3600 // 默认1小时
+	}
+}
+
+// setDefaults 设置邮箱验证码配置的默认值
+func (c *CaptchaConfig) setDefaults() {
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 5
+	}
+	if c.EmailDailyLimit == 0 {
+		c.EmailDailyLimit = 10
+	}
+	if c.IPDailyLimit == 0 {
+		c.IPDailyLimit = 30
+	}
+}
+
+// setDefaults 设置两步验证配置的默认值
+func (c *MFAConfig) setDefaults() {
+	if c.Issuer == "" {
+		c.Issuer = "video-backend"
+	}
+}
+
+// setDefaults 设置第三方登录配置的默认值
+func (c *OIDCConfig) setDefaults() {
+	for i := range c.Providers {
+		if len(c.Providers[i].Scopes) == 0 {
+			c.Providers[i].Scopes = []string{"openid", "email", "profile"}
+		}
+	}
+}
+
+// setDefaults 设置 Python 服务透传配置的默认值（未配置接口规则时，图片处理接口收取 1 积分，视频处理接口每分钟 1 积分）
+func (c *ProxyConfig) setDefaults() {
+	if c.PythonURL == "" {
+		c.PythonURL = "http://localhost:6869"
+	}
+	if c.MaxBodyMB == 0 {
+		c.MaxBodyMB = 1024 // 默认1GB
+	}
+	if c.Routes == nil {
+		c.Routes = []ProxyRouteConfig{
+			{
+				Path: "/process_image", Method: "POST", Auth: true,
+				Scope: "py:process_image", Reason: "process_image", Cost: ProxyCostConfig{Fixed: 1},
+			},
+			{
+				Path: "/process_video", Method: "POST", Auth: true,
+				Scope: "py:process_video", Reason: "process_video", Cost: ProxyCostConfig{PerMinute: 1},
+			},
+		}
+	}
+	for i := range c.Routes {
+		if c.Routes[i].HoldTimeout == 0 {
+			c.Routes[i].HoldTimeout = 3600 // 默认1小时
+		}
+	}
+}
+
+// setDefaults 设置积分有效期配置的默认值
+func (c *PointsConfig) setDefaults() {
+	if c.ExpiringSoonDays == 0 {
+		c.ExpiringSoonDays = 7
+	}
+}
+
+// setDefaults 设置支付配置的默认值
+func (c *PaymentConfig) setDefaults() {
+	if c.WebhookTolerance == 0 {
+		c.WebhookTolerance = 300 // 默认5分钟
 	}
 }
 
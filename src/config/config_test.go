@@ -0,0 +1,41 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// loadYAML 将配置内容写入临时文件并加载
+func loadYAML(t *testing.T, content string) *Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("写入配置失败: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return cfg
+}
+
+// TestJWTExpireTimeFallback 旧版 expire_time（小时）在未配置 access_expire_time 时作为 Access Token 过期时间
+func TestJWTExpireTimeFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{name: "默认值", content: "jwt:\n  secret: s\n", want: 15},
+		{name: "旧版配置", content: "jwt:\n  expire_time: 24\n", want: 24 * 60},
+		{name: "新配置优先", content: "jwt:\n  expire_time: 24\n  access_expire_time: 30\n", want: 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loadYAML(t, tt.content).JWT.AccessExpireTime; got != tt.want {
+				t.Fatalf("AccessExpireTime = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
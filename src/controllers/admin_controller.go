@@ -2,9 +2,12 @@
 package controllers
 
 import (
+	"strconv"
+
 	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
 	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
 	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
 	"github.com/Company-Automation-1/video-backend-go/src/query"
 	"github.com/Company-Automation-1/video-backend-go/src/services"
 	"github.com/Company-Automation-1/video-backend-go/src/tools"
@@ -65,10 +68,91 @@ func (c *AdminController) GetProfile(ctx *gin.Context) error {
 
 // Create 创建管理员（管理员权限）
 func (c *AdminController) Create(ctx *gin.Context, req *dto.AdminCreateRequest) error {
-	admin, err := c.adminService.Create(req.Username, req.Password)
+	admin, err := c.adminService.Create(ctx.Request.Context(), req.Username, req.Password, req.RoleID)
 	if err != nil {
 		return err
 	}
 	middleware.Created(ctx, vo.FromAdminModel(admin))
 	return nil
+}
+
+// Update 更新管理员（用户名、密码、角色）
+func (c *AdminController) Update(ctx *gin.Context, req *dto.AdminUpdateRequest) error {
+	id, err := parseAdminID(ctx)
+	if err != nil {
+		return err
+	}
+	admin, err := c.adminService.Update(ctx.Request.Context(), id, req.Username, req.Password, req.RoleID)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromAdminModel(admin))
+	return nil
+}
+
+// Delete 删除管理员
+func (c *AdminController) Delete(ctx *gin.Context) error {
+	operatorID, err := middleware.GetAdminID(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := parseAdminID(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.adminService.Delete(ctx.Request.Context(), operatorID, id); err != nil {
+		return err
+	}
+	middleware.Success(ctx, "删除成功")
+	return nil
+}
+
+// Disable 禁用管理员
+func (c *AdminController) Disable(ctx *gin.Context) error {
+	return c.setStatus(ctx, models.AdminStatusDisabled)
+}
+
+// Enable 启用管理员
+func (c *AdminController) Enable(ctx *gin.Context) error {
+	return c.setStatus(ctx, models.AdminStatusActive)
+}
+
+// setStatus 设置管理员状态
+func (c *AdminController) setStatus(ctx *gin.Context, status int8) error {
+	operatorID, err := middleware.GetAdminID(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := parseAdminID(ctx)
+	if err != nil {
+		return err
+	}
+	admin, err := c.adminService.SetStatus(ctx.Request.Context(), operatorID, id, status)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromAdminModel(admin))
+	return nil
+}
+
+// ChangePassword 修改自己的密码（当前登录管理员）
+func (c *AdminController) ChangePassword(ctx *gin.Context, req *dto.AdminPasswordChangeRequest) error {
+	adminID, err := middleware.GetAdminID(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.adminService.ChangePassword(ctx.Request.Context(), adminID, req.OldPassword, req.NewPassword); err != nil {
+		return err
+	}
+	middleware.Success(ctx, "密码修改成功，请重新登录")
+	return nil
+}
+
+// parseAdminID 解析路径参数中的管理员ID
+func parseAdminID(ctx *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		return 0, tools.ErrBadRequest("无效的管理员ID")
+	}
+	return uint(id), nil
 }
\ No newline at end of file
@@ -0,0 +1,46 @@
+// Package controllers 管理员登录锁定管理控制器
+package controllers
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminLockoutController 管理员登录锁定管理控制器
+type AdminLockoutController struct {
+	loginGuard *services.LoginGuardService
+}
+
+// NewAdminLockoutController 创建管理员登录锁定管理控制器
+func NewAdminLockoutController(loginGuard *services.LoginGuardService) *AdminLockoutController {
+	return &AdminLockoutController{
+		loginGuard: loginGuard,
+	}
+}
+
+// GetList 获取当前登录锁定列表（管理员权限）
+func (c *AdminLockoutController) GetList(ctx *gin.Context) error {
+	lockouts, err := c.loginGuard.ListLockouts(ctx.Request.Context())
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromLoginLockoutModelList(lockouts))
+	return nil
+}
+
+// Clear 解除登录锁定（管理员权限）
+func (c *AdminLockoutController) Clear(ctx *gin.Context) error {
+	var req dto.LoginLockoutClearRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		return tools.ErrBadRequest(err.Error())
+	}
+	if err := c.loginGuard.ClearLockout(ctx.Request.Context(), req.Role, req.Type, req.Value); err != nil {
+		return err
+	}
+	middleware.Success(ctx, "已解除锁定")
+	return nil
+}
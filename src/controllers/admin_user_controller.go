@@ -13,13 +13,18 @@ import (
 
 // AdminUserController 管理员用户管理控制器
 type AdminUserController struct {
-	userService *services.UserService
+	userService   *services.UserService
+	ledgerService *services.PointsLedgerService
 }
 
 // NewAdminUserController 创建管理员用户管理控制器
-func NewAdminUserController(userService *services.UserService) *AdminUserController {
+func NewAdminUserController(
+	userService *services.UserService,
+	ledgerService *services.PointsLedgerService,
+) *AdminUserController {
 	return &AdminUserController{
-		userService: userService,
+		userService:   userService,
+		ledgerService: ledgerService,
 	}
 }
 
@@ -63,14 +68,19 @@ func (c *AdminUserController) GetOne(ctx *gin.Context) error {
 	return nil
 }
 
-// Update 更新用户积分（管理员权限，只允许修改积分）
+// Update 更新用户积分（管理员权限，只允许修改积分，按差额记入积分流水）
 func (c *AdminUserController) Update(ctx *gin.Context, req *dto.AdminUserUpdateRequest) error {
 	id, err := parseID(ctx)
 	if err != nil {
 		return err
 	}
 
-	updatedUser, err := c.userService.UpdatePoints(id, req.Points)
+	if req.Points != nil {
+		if err := c.ledgerService.SetBalance(ctx.Request.Context(), id, req.Points); err != nil {
+			return err
+		}
+	}
+	updatedUser, err := c.userService.GetOne(query.User.ID.Eq(id))
 	if err != nil {
 		return err
 	}
@@ -78,3 +88,19 @@ func (c *AdminUserController) Update(ctx *gin.Context, req *dto.AdminUserUpdateR
 	middleware.Success(ctx, vo.FromModel(updatedUser))
 	return nil
 }
+
+// UpdateStatus 设置用户状态（暂停/封禁/恢复，管理员权限）
+func (c *AdminUserController) UpdateStatus(ctx *gin.Context, req *dto.AdminUserStatusRequest) error {
+	id, err := parseID(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.userService.SetStatus(ctx.Request.Context(), id, req.Status, req.Reason, req.SuspendedUntil)
+	if err != nil {
+		return err
+	}
+
+	middleware.Success(ctx, vo.FromModel(user))
+	return nil
+}
@@ -0,0 +1,73 @@
+// Package controllers API Key 控制器
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyController API Key 控制器
+type APIKeyController struct {
+	apiKeyService *services.APIKeyService
+}
+
+// NewAPIKeyController 创建 API Key 控制器
+func NewAPIKeyController(apiKeyService *services.APIKeyService) *APIKeyController {
+	return &APIKeyController{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// Create 创建 API Key
+func (c *APIKeyController) Create(ctx *gin.Context, req *dto.APIKeyCreateRequest) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	apiKey, key, err := c.apiKeyService.Create(ctx.Request.Context(), userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	middleware.Created(ctx, &vo.APIKeyCreatedVO{
+		APIKeyVO: vo.FromAPIKeyModel(apiKey),
+		Key:      key,
+	})
+	return nil
+}
+
+// GetList 获取当前用户的 API Key 列表
+func (c *APIKeyController) GetList(ctx *gin.Context) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	apiKeys, err := c.apiKeyService.List(userID)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromAPIKeyModelList(apiKeys))
+	return nil
+}
+
+// Revoke 吊销当前用户的 API Key
+func (c *APIKeyController) Revoke(ctx *gin.Context) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		return tools.ErrBadRequest("无效的API Key ID")
+	}
+	if err := c.apiKeyService.Revoke(ctx.Request.Context(), userID, uint(id)); err != nil {
+		return err
+	}
+	middleware.Success(ctx, "API Key已吊销")
+	return nil
+}
@@ -0,0 +1,44 @@
+// Package controllers 审计日志控制器
+package controllers
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogController 审计日志控制器
+type AuditLogController struct {
+	auditService *services.AuditService
+}
+
+// NewAuditLogController 创建审计日志控制器
+func NewAuditLogController(auditService *services.AuditService) *AuditLogController {
+	return &AuditLogController{
+		auditService: auditService,
+	}
+}
+
+// GetList 获取审计日志列表（管理员权限，分页，支持条件查询、范围查询）
+func (c *AuditLogController) GetList(ctx *gin.Context) error {
+	var queryReq dto.AuditLogListQueryRequest
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		return tools.ErrBadRequest(err.Error())
+	}
+
+	logs, total, err := c.auditService.List(&queryReq)
+	if err != nil {
+		return err
+	}
+
+	middleware.Success(ctx, vo.NewPaginatedResponse(
+		vo.FromAuditLogModelList(logs),
+		queryReq.GetPage(),
+		queryReq.GetPageSize(),
+		total,
+	))
+	return nil
+}
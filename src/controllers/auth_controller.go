@@ -3,6 +3,7 @@ package controllers
 
 import (
 	"context"
+	"net/http"
 	"strings"
 
 	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
@@ -28,20 +29,42 @@ func NewAuthController(authService *services.AuthService) *AuthController {
 func (c *AuthController) _login(
 	ctx *gin.Context,
 	username, password string,
-	loginFunc func(context.Context, string, string) (string, int64, error),
+	loginFunc func(context.Context, string, string, services.ClientInfo) (*services.LoginResult, error),
 ) error {
-	accessToken, expiresIn, err := loginFunc(ctx.Request.Context(), username, password)
+	result, err := loginFunc(ctx.Request.Context(), username, password, middleware.ClientInfo(ctx))
 	if err != nil {
 		return err
 	}
 
-	tokenVO := &vo.TokenVO{
-		AccessToken: accessToken,
-		ExpiresIn:   expiresIn,
+	loginSuccess(ctx, result)
+	return nil
+}
+
+// loginSuccess 登录成功响应
+func loginSuccess(ctx *gin.Context, result *services.LoginResult) {
+	// 需要两步验证：返回挑战令牌，客户端需调用 /auth/mfa/verify 完成登录
+	// 强制两步验证但尚未启用时，需先调用 /auth/mfa/enroll/setup 和 /auth/mfa/enroll/enable 绑定
+	if result.Tokens == nil {
+		middleware.Success(ctx, &vo.MFAChallengeVO{
+			MFARequired:           true,
+			MFAEnrollmentRequired: result.MFAEnrollRequired,
+			MFAToken:              result.MFAToken,
+			ExpiresIn:             result.MFAExpiresIn,
+		})
+		return
 	}
 
-	middleware.Success(ctx, tokenVO)
-	return nil
+	middleware.Success(ctx, toTokenVO(result.Tokens))
+}
+
+// toTokenVO 令牌对转换为VO
+func toTokenVO(tokens *services.TokenPair) *vo.TokenVO {
+	return &vo.TokenVO{
+		AccessToken:      tokens.AccessToken,
+		ExpiresIn:        tokens.ExpiresIn,
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresIn: tokens.RefreshExpiresIn,
+	}
 }
 
 // UserLogin 用户登录
@@ -54,6 +77,51 @@ func (c *AuthController) AdminLogin(ctx *gin.Context, req *dto.AdminLoginRequest
 	return c._login(ctx, req.Username, req.Password, c.authService.AdminLogin)
 }
 
+// VerifyMFA 两步验证（登录第二步）
+func (c *AuthController) VerifyMFA(ctx *gin.Context, req *dto.MFAVerifyRequest) error {
+	tokens, err := c.authService.VerifyMFA(ctx.Request.Context(), req.MFAToken, req.Code, middleware.ClientInfo(ctx))
+	if err != nil {
+		return err
+	}
+
+	middleware.Success(ctx, toTokenVO(tokens))
+	return nil
+}
+
+// SetupMFAEnrollment 登录时绑定两步验证：生成 TOTP 密钥
+func (c *AuthController) SetupMFAEnrollment(ctx *gin.Context, req *dto.MFATokenRequest) error {
+	secret, uri, err := c.authService.SetupMFAEnrollment(ctx.Request.Context(), req.MFAToken)
+	if err != nil {
+		return err
+	}
+
+	middleware.Success(ctx, &vo.TOTPSetupVO{Secret: secret, OtpauthURI: uri})
+	return nil
+}
+
+// EnableMFAEnrollment 登录时绑定两步验证：验证口令并启用，完成登录
+func (c *AuthController) EnableMFAEnrollment(ctx *gin.Context, req *dto.MFAVerifyRequest) error {
+	codes, tokens, err := c.authService.EnableMFAEnrollment(
+		ctx.Request.Context(), req.MFAToken, req.Code, middleware.ClientInfo(ctx))
+	if err != nil {
+		return err
+	}
+
+	middleware.Success(ctx, &vo.MFAEnrollVO{RecoveryCodes: codes, Tokens: toTokenVO(tokens)})
+	return nil
+}
+
+// Refresh 刷新Token（Refresh Token 轮换）
+func (c *AuthController) Refresh(ctx *gin.Context, req *dto.RefreshTokenRequest) error {
+	tokens, err := c.authService.Refresh(ctx.Request.Context(), req.RefreshToken, middleware.ClientInfo(ctx))
+	if err != nil {
+		return err
+	}
+
+	middleware.Success(ctx, toTokenVO(tokens))
+	return nil
+}
+
 // Logout 用户登出
 func (c *AuthController) Logout(ctx *gin.Context) error {
 	authHeader := ctx.GetHeader("Authorization")
@@ -68,3 +136,9 @@ func (c *AuthController) Logout(ctx *gin.Context) error {
 	middleware.Success(ctx, "登出成功")
 	return nil
 }
+
+// JWKS 发布验签公钥（标准 JWKS 格式，不使用统一响应包装）
+func (c *AuthController) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, c.authService.JWKS())
+}
@@ -0,0 +1,69 @@
+// Package controllers 两步验证控制器
+package controllers
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// MFAController 两步验证控制器（用户和管理员共用，通过 role 区分账号类型）
+type MFAController struct {
+	mfaService *services.MFAService
+	role       string
+}
+
+// NewMFAController 创建两步验证控制器
+func NewMFAController(mfaService *services.MFAService, role string) *MFAController {
+	return &MFAController{
+		mfaService: mfaService,
+		role:       role,
+	}
+}
+
+// Setup 生成 TOTP 密钥（启用前需调用 Enable 验证）
+func (c *MFAController) Setup(ctx *gin.Context) error {
+	id, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	username, err := middleware.GetUsername(ctx)
+	if err != nil {
+		return err
+	}
+	secret, uri, err := c.mfaService.Setup(ctx.Request.Context(), c.role, id, username)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, &vo.TOTPSetupVO{Secret: secret, OtpauthURI: uri})
+	return nil
+}
+
+// Enable 验证口令并启用两步验证
+func (c *MFAController) Enable(ctx *gin.Context, req *dto.MFACodeRequest) error {
+	id, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	codes, err := c.mfaService.Enable(ctx.Request.Context(), c.role, id, req.Code)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, &vo.RecoveryCodesVO{RecoveryCodes: codes})
+	return nil
+}
+
+// Disable 验证口令并关闭两步验证
+func (c *MFAController) Disable(ctx *gin.Context, req *dto.MFACodeRequest) error {
+	id, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.mfaService.Disable(ctx.Request.Context(), c.role, id, req.Code); err != nil {
+		return err
+	}
+	middleware.Success(ctx, "两步验证已关闭")
+	return nil
+}
@@ -0,0 +1,51 @@
+// Package controllers 第三方登录控制器
+package controllers
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// OIDCController 第三方登录控制器
+type OIDCController struct {
+	oidcService *services.OIDCService
+}
+
+// NewOIDCController 创建第三方登录控制器
+func NewOIDCController(oidcService *services.OIDCService) *OIDCController {
+	return &OIDCController{
+		oidcService: oidcService,
+	}
+}
+
+// Authorize 获取身份提供方授权地址
+func (c *OIDCController) Authorize(ctx *gin.Context) error {
+	authURL, state, err := c.oidcService.AuthorizationURL(ctx.Request.Context(), ctx.Param("provider"))
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, &vo.OIDCAuthorizeVO{
+		AuthorizationURL: authURL,
+		State:            state,
+	})
+	return nil
+}
+
+// Callback 授权回调：使用 code 和 state 完成登录
+func (c *OIDCController) Callback(ctx *gin.Context, req *dto.OIDCCallbackRequest) error {
+	result, err := c.oidcService.Login(
+		ctx.Request.Context(),
+		ctx.Param("provider"),
+		req.Code,
+		req.State,
+		middleware.ClientInfo(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	loginSuccess(ctx, result)
+	return nil
+}
@@ -0,0 +1,203 @@
+// Package controllers 积分套餐购买控制器
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/gin-gonic/gin"
+)
+
+// PaymentController 积分套餐购买控制器
+type PaymentController struct {
+	paymentService *services.PaymentService
+}
+
+// NewPaymentController 创建积分套餐购买控制器
+func NewPaymentController(paymentService *services.PaymentService) *PaymentController {
+	return &PaymentController{
+		paymentService: paymentService,
+	}
+}
+
+// GetProducts 获取可购买的积分套餐
+func (c *PaymentController) GetProducts(ctx *gin.Context) error {
+	products, err := c.paymentService.ListActiveProducts()
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromPointsProductModelList(products))
+	return nil
+}
+
+// CreateOrder 购买积分套餐（创建订单，返回支付地址）
+func (c *PaymentController) CreateOrder(ctx *gin.Context, req *dto.PointsOrderCreateRequest) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	order, session, err := c.paymentService.CreateOrder(ctx.Request.Context(), userID, req.ProductID)
+	if err != nil {
+		return err
+	}
+	middleware.Created(ctx, &vo.PointsOrderCreateVO{
+		Order:  vo.FromPointsOrderModel(order),
+		PayURL: session.PayURL,
+	})
+	return nil
+}
+
+// GetOrderList 获取本人的积分订单（分页）
+func (c *PaymentController) GetOrderList(ctx *gin.Context) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	var queryReq dto.PointsOrderListQueryRequest
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		return tools.ErrBadRequest(err.Error())
+	}
+
+	orders, total, err := c.paymentService.ListUserOrders(userID, &queryReq)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.NewPaginatedResponse(
+		vo.FromPointsOrderModelList(orders),
+		queryReq.GetPage(),
+		queryReq.GetPageSize(),
+		total,
+	))
+	return nil
+}
+
+// Webhook 支付渠道回调（按原始请求体验签，重复回调返回成功）
+func (c *PaymentController) Webhook(ctx *gin.Context) error {
+	body, err := ctx.GetRawData()
+	if err != nil {
+		return tools.ErrBadRequest("读取请求体失败")
+	}
+	if _, err := c.paymentService.HandleWebhook(ctx.Request.Context(), ctx.Request.Header, body); err != nil {
+		return err
+	}
+	middleware.Success(ctx, "ok")
+	return nil
+}
+
+// FakeCheckout 模拟支付本人的订单（仅本地模拟支付渠道开启时可用）
+func (c *PaymentController) FakeCheckout(ctx *gin.Context) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	order, err := c.paymentService.FakeCheckout(ctx.Request.Context(), userID, ctx.Param("order_no"))
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromPointsOrderModel(order))
+	return nil
+}
+
+// GetProductList 获取积分套餐列表（管理员权限，分页）
+func (c *PaymentController) GetProductList(ctx *gin.Context) error {
+	var queryReq dto.PointsProductListQueryRequest
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		return tools.ErrBadRequest(err.Error())
+	}
+
+	products, total, err := c.paymentService.ListProducts(&queryReq)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.NewPaginatedResponse(
+		vo.FromPointsProductModelList(products),
+		queryReq.GetPage(),
+		queryReq.GetPageSize(),
+		total,
+	))
+	return nil
+}
+
+// CreateProduct 创建积分套餐（管理员权限）
+func (c *PaymentController) CreateProduct(ctx *gin.Context, req *dto.PointsProductCreateRequest) error {
+	product, err := c.paymentService.CreateProduct(ctx.Request.Context(), req)
+	if err != nil {
+		return err
+	}
+	middleware.Created(ctx, vo.FromPointsProductModel(product))
+	return nil
+}
+
+// UpdateProduct 修改积分套餐（管理员权限）
+func (c *PaymentController) UpdateProduct(ctx *gin.Context, req *dto.PointsProductUpdateRequest) error {
+	id, err := parseProductID(ctx)
+	if err != nil {
+		return err
+	}
+
+	product, err := c.paymentService.UpdateProduct(ctx.Request.Context(), id, req)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromPointsProductModel(product))
+	return nil
+}
+
+// GetAdminOrderList 获取积分订单列表（管理员权限，分页）
+func (c *PaymentController) GetAdminOrderList(ctx *gin.Context) error {
+	var queryReq dto.PointsOrderAdminListQueryRequest
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		return tools.ErrBadRequest(err.Error())
+	}
+
+	orders, total, err := c.paymentService.ListOrders(&queryReq)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.NewPaginatedResponse(
+		vo.FromAdminPointsOrderModelList(orders),
+		queryReq.GetPage(),
+		queryReq.GetPageSize(),
+		total,
+	))
+	return nil
+}
+
+// Refund 积分订单退款（管理员权限，扣回积分并向支付渠道退款）
+func (c *PaymentController) Refund(ctx *gin.Context, req *dto.PointsOrderRefundRequest) error {
+	id, err := parseOrderID(ctx)
+	if err != nil {
+		return err
+	}
+
+	order, err := c.paymentService.Refund(ctx.Request.Context(), id, req.Reason)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromAdminPointsOrderModel(order))
+	return nil
+}
+
+// parseProductID 从路径参数获取积分套餐ID
+func parseProductID(ctx *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		return 0, tools.ErrBadRequest("无效的积分套餐ID")
+	}
+	return uint(id), nil
+}
+
+// parseOrderID 从路径参数获取订单ID
+func parseOrderID(ctx *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		return 0, tools.ErrBadRequest("无效的订单ID")
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,67 @@
+// Package controllers 积分流水控制器
+package controllers
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/gin-gonic/gin"
+)
+
+// PointsController 积分流水控制器
+type PointsController struct {
+	ledgerService *services.PointsLedgerService
+}
+
+// NewPointsController 创建积分流水控制器
+func NewPointsController(ledgerService *services.PointsLedgerService) *PointsController {
+	return &PointsController{
+		ledgerService: ledgerService,
+	}
+}
+
+// History 获取本人的积分流水（分页）
+func (c *PointsController) History(ctx *gin.Context) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	var queryReq dto.PointsHistoryRequest
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		return tools.ErrBadRequest(err.Error())
+	}
+
+	txns, total, err := c.ledgerService.History(userID, &queryReq)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.NewPaginatedResponse(
+		vo.FromPointsTransactionModelList(txns),
+		queryReq.GetPage(),
+		queryReq.GetPageSize(),
+		total,
+	))
+	return nil
+}
+
+// GetList 获取积分流水列表（管理员权限，分页，支持条件查询、范围查询）
+func (c *PointsController) GetList(ctx *gin.Context) error {
+	var queryReq dto.PointsTransactionListQueryRequest
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		return tools.ErrBadRequest(err.Error())
+	}
+
+	txns, total, err := c.ledgerService.List(&queryReq)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.NewPaginatedResponse(
+		vo.FromAdminPointsTransactionModelList(txns),
+		queryReq.GetPage(),
+		queryReq.GetPageSize(),
+		total,
+	))
+	return nil
+}
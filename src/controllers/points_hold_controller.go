@@ -0,0 +1,44 @@
+// Package controllers 积分预留控制器
+package controllers
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// PointsHoldController 积分预留控制器
+type PointsHoldController struct {
+	holdService *services.PointsHoldService
+}
+
+// NewPointsHoldController 创建积分预留控制器
+func NewPointsHoldController(holdService *services.PointsHoldService) *PointsHoldController {
+	return &PointsHoldController{
+		holdService: holdService,
+	}
+}
+
+// Settle 结算积分预留（Python 服务异步任务完成后回调，重复回调返回相同结果）
+func (c *PointsHoldController) Settle(ctx *gin.Context, req *dto.PointsHoldSettleRequest) error {
+	id, err := parseID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var hold *models.PointsHold
+	if req.Status == "succeeded" {
+		hold, err = c.holdService.Capture(ctx.Request.Context(), id, req.Points)
+	} else {
+		hold, err = c.holdService.Release(id, req.Reason)
+	}
+	if err != nil {
+		return err
+	}
+
+	middleware.Success(ctx, vo.FromPointsHoldModel(hold))
+	return nil
+}
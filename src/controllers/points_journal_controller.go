@@ -0,0 +1,36 @@
+// Package controllers 积分变更日志控制器
+package controllers
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// PointsJournalController 积分变更日志控制器
+type PointsJournalController struct {
+	journalService *services.PointsJournalService
+}
+
+// NewPointsJournalController 创建积分变更日志控制器
+func NewPointsJournalController(journalService *services.PointsJournalService) *PointsJournalController {
+	return &PointsJournalController{
+		journalService: journalService,
+	}
+}
+
+// Checkpoint 导出当前链头的签名检查点
+func (c *PointsJournalController) Checkpoint(ctx *gin.Context) error {
+	checkpoint, err := c.journalService.Checkpoint()
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, &vo.JournalCheckpointVO{
+		Seq:       checkpoint.Seq,
+		Hash:      checkpoint.Hash,
+		CreatedAt: checkpoint.CreatedAt,
+		Signature: checkpoint.Signature,
+	})
+	return nil
+}
@@ -0,0 +1,116 @@
+// Package controllers 兑换码控制器
+package controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/gin-gonic/gin"
+)
+
+// RedeemController 兑换码控制器
+type RedeemController struct {
+	redeemService *services.RedeemService
+}
+
+// NewRedeemController 创建兑换码控制器
+func NewRedeemController(redeemService *services.RedeemService) *RedeemController {
+	return &RedeemController{
+		redeemService: redeemService,
+	}
+}
+
+// Redeem 用户兑换积分（重复兑换同一兑换码返回相同结果）
+func (c *RedeemController) Redeem(ctx *gin.Context, req *dto.RedeemRequest) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	redemption, err := c.redeemService.Redeem(ctx.Request.Context(), userID, req.Code)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromRedeemRedemptionModel(redemption))
+	return nil
+}
+
+// CreateCampaign 创建兑换码活动并生成兑换码（管理员权限）
+func (c *RedeemController) CreateCampaign(ctx *gin.Context, req *dto.RedeemCampaignCreateRequest) error {
+	campaign, err := c.redeemService.CreateCampaign(ctx.Request.Context(), req)
+	if err != nil {
+		return err
+	}
+	middleware.Created(ctx, vo.FromRedeemCampaignModel(campaign, 0))
+	return nil
+}
+
+// GetCampaignList 获取兑换码活动列表（管理员权限，分页）
+func (c *RedeemController) GetCampaignList(ctx *gin.Context) error {
+	var queryReq dto.RedeemCampaignListQueryRequest
+	if err := ctx.ShouldBindQuery(&queryReq); err != nil {
+		return tools.ErrBadRequest(err.Error())
+	}
+
+	campaigns, redeemed, total, err := c.redeemService.ListCampaigns(&queryReq)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.NewPaginatedResponse(
+		vo.FromRedeemCampaignModelList(campaigns, redeemed),
+		queryReq.GetPage(),
+		queryReq.GetPageSize(),
+		total,
+	))
+	return nil
+}
+
+// ExportCodes 导出活动的全部兑换码（CSV 附件）
+func (c *RedeemController) ExportCodes(ctx *gin.Context) error {
+	id, err := parseCampaignID(ctx)
+	if err != nil {
+		return err
+	}
+
+	campaign, codes, err := c.redeemService.ExportCodes(ctx.Request.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	records := make([][]string, 0, len(codes)+1)
+	records = append(records, []string{"code", "used_count", "max_uses", "starts_at", "ends_at"})
+	for _, code := range codes {
+		records = append(records, []string{
+			services.FormatRedeemCode(code.Code),
+			strconv.Itoa(code.UsedCount),
+			strconv.Itoa(campaign.MaxUsesPerCode),
+			strconv.FormatInt(campaign.StartsAt, 10),
+			strconv.FormatInt(campaign.EndsAt, 10),
+		})
+	}
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(records); err != nil {
+		return tools.ErrInternalServer("兑换码导出失败")
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="redeem_codes_%d.csv"`, campaign.ID))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+	return nil
+}
+
+// parseCampaignID 从路径参数获取活动ID
+func parseCampaignID(ctx *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		return 0, tools.ErrBadRequest("无效的活动ID")
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,56 @@
+// Package controllers 积分奖励控制器
+package controllers
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RewardController 积分奖励控制器
+type RewardController struct {
+	rewardService *services.RewardService
+}
+
+// NewRewardController 创建积分奖励控制器
+func NewRewardController(rewardService *services.RewardService) *RewardController {
+	return &RewardController{
+		rewardService: rewardService,
+	}
+}
+
+// CheckIn 每日签到
+func (c *RewardController) CheckIn(ctx *gin.Context) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	grant, err := c.rewardService.CheckIn(ctx.Request.Context(), userID)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromRewardGrantModel(grant))
+	return nil
+}
+
+// Referral 获取本人的邀请码和邀请统计
+func (c *RewardController) Referral(ctx *gin.Context) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	summary, err := c.rewardService.Referral(userID)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, &vo.ReferralVO{
+		InviteCode:   summary.InviteCode,
+		InvitedCount: summary.InvitedCount,
+		RewardCount:  summary.RewardCount,
+		RewardPoints: summary.RewardPoints,
+	})
+	return nil
+}
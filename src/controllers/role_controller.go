@@ -0,0 +1,31 @@
+// Package controllers 角色控制器
+package controllers
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RoleController 角色控制器
+type RoleController struct {
+	rbacService *services.RBACService
+}
+
+// NewRoleController 创建角色控制器
+func NewRoleController(rbacService *services.RBACService) *RoleController {
+	return &RoleController{
+		rbacService: rbacService,
+	}
+}
+
+// GetList 获取角色列表（含各角色的权限）
+func (c *RoleController) GetList(ctx *gin.Context) error {
+	roles, permissions, err := c.rbacService.ListRoles()
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromRoleModelList(roles, permissions))
+	return nil
+}
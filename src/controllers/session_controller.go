@@ -0,0 +1,48 @@
+// Package controllers 登录会话控制器
+package controllers
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/api/vo"
+	"github.com/Company-Automation-1/video-backend-go/src/middleware"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+// SessionController 登录会话控制器
+type SessionController struct {
+	authService *services.AuthService
+}
+
+// NewSessionController 创建登录会话控制器
+func NewSessionController(authService *services.AuthService) *SessionController {
+	return &SessionController{
+		authService: authService,
+	}
+}
+
+// GetList 获取当前用户的登录会话列表
+func (c *SessionController) GetList(ctx *gin.Context) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	sessions, err := c.authService.ListSessions(ctx.Request.Context(), services.RoleUser, userID)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, vo.FromSessionModelList(sessions, middleware.GetSessionID(ctx)))
+	return nil
+}
+
+// Revoke 吊销当前用户的指定会话（该设备下线）
+func (c *SessionController) Revoke(ctx *gin.Context) error {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.authService.RevokeSession(ctx.Request.Context(), services.RoleUser, userID, ctx.Param("sid")); err != nil {
+		return err
+	}
+	middleware.Success(ctx, "会话已注销")
+	return nil
+}
@@ -12,13 +12,15 @@ import (
 
 // UserController 用户控制器
 type UserController struct {
-	service *services.UserService
+	service       *services.UserService
+	ledgerService *services.PointsLedgerService
 }
 
 // NewUserController 创建用户控制器
-func NewUserController(service *services.UserService) *UserController {
+func NewUserController(service *services.UserService, ledgerService *services.PointsLedgerService) *UserController {
 	return &UserController{
-		service: service,
+		service:       service,
+		ledgerService: ledgerService,
 	}
 }
 
@@ -32,13 +34,25 @@ func (c *UserController) GetProfile(ctx *gin.Context) error {
 	if err != nil {
 		return err
 	}
-	middleware.Success(ctx, vo.FromModel(user))
+	expiring, err := c.ledgerService.ExpiringSoon(userID)
+	if err != nil {
+		return err
+	}
+	middleware.Success(ctx, &vo.UserProfileVO{
+		UserVO: vo.FromModel(user),
+		ExpiringSoon: &vo.ExpiringPointsVO{
+			Points:    expiring.Points,
+			ExpiresAt: expiring.ExpiresAt,
+			Days:      expiring.Days,
+		},
+	})
 	return nil
 }
 
 // Register 用户注册
 func (c *UserController) Register(ctx *gin.Context, req *dto.UserRegisterRequest) error {
-	if err := c.service.Register(ctx.Request.Context(), req.Username, req.Email, req.Password, req.Captcha); err != nil {
+	err := c.service.Register(ctx.Request.Context(), req.Username, req.Email, req.Password, req.Captcha, req.InviteCode)
+	if err != nil {
 		return err
 	}
 	middleware.Created(ctx, "注册成功")
@@ -47,13 +61,29 @@ func (c *UserController) Register(ctx *gin.Context, req *dto.UserRegisterRequest
 
 // SendVerificationCode 发送验证码
 func (c *UserController) SendVerificationCode(ctx *gin.Context, req *dto.SendVerificationCodeRequest) error {
-	if err := c.service.SendVerificationCode(ctx.Request.Context(), req.Email); err != nil {
+	if err := c.service.SendVerificationCode(ctx.Request.Context(), req.Email, ctx.ClientIP()); err != nil {
 		return err
 	}
 	middleware.Success(ctx, "验证码已发送")
 	return nil
 }
 
+// ForgotPassword 忘记密码（发送重置验证码）
+func (c *UserController) ForgotPassword(ctx *gin.Context, req *dto.ForgotPasswordRequest) error {
+	c.service.ForgotPassword(ctx.Request.Context(), req.Email, ctx.ClientIP())
+	middleware.Success(ctx, "如果该邮箱已注册，验证码将发送至该邮箱")
+	return nil
+}
+
+// ResetPassword 重置密码
+func (c *UserController) ResetPassword(ctx *gin.Context, req *dto.ResetPasswordRequest) error {
+	if err := c.service.ResetPassword(ctx.Request.Context(), req.Email, req.Captcha, req.Password); err != nil {
+		return err
+	}
+	middleware.Success(ctx, "密码重置成功，请重新登录")
+	return nil
+}
+
 // Update 更新用户（用户自己更新，不允许修改积分）
 func (c *UserController) Update(ctx *gin.Context, req *dto.UserUpdateRequest) error {
 	id, err := parseID(ctx)
@@ -77,7 +107,7 @@ func (c *UserController) Delete(ctx *gin.Context) error {
 	if err != nil {
 		return err
 	}
-	if err := c.service.Delete(id); err != nil {
+	if err := c.service.Delete(ctx.Request.Context(), id); err != nil {
 		return err
 	}
 	middleware.Success(ctx, "删除成功")
@@ -0,0 +1,190 @@
+package infrastructure_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// authorize 生成授权地址并模拟用户授权，返回授权码、code_verifier 和 nonce
+func authorize(
+	t *testing.T,
+	issuer *oidctest.Issuer,
+	provider *infrastructure.OIDCProvider,
+	claims jwt.MapClaims,
+) (code, verifier, nonce string) {
+	t.Helper()
+	verifier = rand.Text()
+	sum := sha256.Sum256([]byte(verifier))
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", rand.Text(),
+		base64.RawURLEncoding.EncodeToString(sum[:]))
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("授权地址解析失败: %v", err)
+	}
+	code, err = issuer.Authorize(authURL, claims)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	return code, verifier, u.Query().Get("nonce")
+}
+
+func TestOIDCProviderLogin(t *testing.T) {
+	issuer := oidctest.NewIssuer(t)
+	cfg := issuer.ProviderConfig("mock")
+	provider := infrastructure.NewOIDCProvider(&cfg)
+	ctx := context.Background()
+
+	code, verifier, nonce := authorize(t, issuer, provider, jwt.MapClaims{
+		"sub":            "u-1",
+		"email":          "alice@example.com",
+		"email_verified": true,
+	})
+	idToken, err := provider.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	claims, err := provider.VerifyIDToken(ctx, idToken, nonce)
+	if err != nil {
+		t.Fatalf("VerifyIDToken: %v", err)
+	}
+	if claims.Subject != "u-1" || claims.Email != "alice@example.com" || !claims.EmailVerified {
+		t.Fatalf("claims = %+v", claims)
+	}
+
+	// 授权码一次性使用
+	if _, err := provider.Exchange(ctx, code, verifier); err == nil {
+		t.Fatal("重复使用授权码应失败")
+	}
+}
+
+func TestOIDCProviderExchangeRejectsWrongVerifier(t *testing.T) {
+	issuer := oidctest.NewIssuer(t)
+	cfg := issuer.ProviderConfig("mock")
+	provider := infrastructure.NewOIDCProvider(&cfg)
+
+	code, _, _ := authorize(t, issuer, provider, jwt.MapClaims{"sub": "u-1"})
+	if _, err := provider.Exchange(context.Background(), code, rand.Text()); err == nil {
+		t.Fatal("code_verifier 不匹配时应换取失败")
+	}
+}
+
+func TestOIDCProviderVerifyIDToken(t *testing.T) {
+	issuer := oidctest.NewIssuer(t)
+	cfg := issuer.ProviderConfig("mock")
+	provider := infrastructure.NewOIDCProvider(&cfg)
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		nonce  string
+		ok     bool
+	}{
+		{name: "有效", ok: true},
+		{name: "nonce 不匹配", nonce: "other-nonce"},
+		{name: "issuer 错误", claims: jwt.MapClaims{"iss": "https://evil.example.com"}},
+		{name: "audience 错误", claims: jwt.MapClaims{"aud": "other-client"}},
+		{name: "已过期", claims: jwt.MapClaims{"exp": now.Add(-time.Hour).Unix()}},
+		{name: "缺少 sub", claims: jwt.MapClaims{"sub": ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := jwt.MapClaims{
+				"sub":   "u-1",
+				"iss":   issuer.URL,
+				"aud":   issuer.ClientID,
+				"iat":   now.Unix(),
+				"exp":   now.Add(5 * time.Minute).Unix(),
+				"nonce": "nonce-1",
+			}
+			for k, v := range tt.claims {
+				claims[k] = v
+			}
+			nonce := tt.nonce
+			if nonce == "" {
+				nonce = "nonce-1"
+			}
+
+			idToken, err := issuer.SignIDToken(claims)
+			if err != nil {
+				t.Fatalf("SignIDToken: %v", err)
+			}
+			_, err = provider.VerifyIDToken(ctx, idToken, nonce)
+			if tt.ok && err != nil {
+				t.Fatalf("VerifyIDToken: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("VerifyIDToken 应失败")
+			}
+		})
+	}
+}
+
+func TestOIDCProviderRejectsForeignSignature(t *testing.T) {
+	issuer := oidctest.NewIssuer(t)
+	other := oidctest.NewIssuer(t)
+	cfg := issuer.ProviderConfig("mock")
+	provider := infrastructure.NewOIDCProvider(&cfg)
+
+	// 声明正确但由其他密钥签名
+	idToken, err := other.SignIDToken(jwt.MapClaims{
+		"sub":   "u-1",
+		"iss":   issuer.URL,
+		"aud":   issuer.ClientID,
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": "nonce-1",
+	})
+	if err != nil {
+		t.Fatalf("SignIDToken: %v", err)
+	}
+	if _, err := provider.VerifyIDToken(context.Background(), idToken, "nonce-1"); err == nil {
+		t.Fatal("其他密钥签名的 ID Token 应验签失败")
+	}
+}
+
+func TestOIDCProviderDiscoveryConcurrent(t *testing.T) {
+	issuer := oidctest.NewIssuer(t)
+	cfg := issuer.ProviderConfig("mock")
+	provider := infrastructure.NewOIDCProvider(&cfg)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("AuthCodeURL: %v", err)
+		}
+	}
+
+	// 缓存后不再请求
+	before := issuer.DiscoveryHits.Load()
+	if _, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "challenge"); err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	if issuer.DiscoveryHits.Load() != before {
+		t.Fatal("Discovery 文档应被缓存")
+	}
+}
@@ -0,0 +1,182 @@
+// Package oidctest 测试用的本地模拟 OpenID Connect 身份提供方
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// keyID 模拟提供方签名密钥ID
+const keyID = "oidctest-1"
+
+// Issuer 模拟身份提供方：提供 Discovery 文档、Token 端点（校验 PKCE）和 JWKS
+type Issuer struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	// DiscoveryHits Discovery 文档被请求的次数
+	DiscoveryHits atomic.Int32
+
+	key    *rsa.PrivateKey
+	mu     sync.Mutex
+	grants map[string]*grant
+}
+
+// grant 已签发的授权码
+type grant struct {
+	challenge string
+	claims    jwt.MapClaims
+}
+
+// NewIssuer 启动模拟身份提供方（测试结束时自动关闭）
+func NewIssuer(t testing.TB) *Issuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成签名密钥失败: %v", err)
+	}
+	i := &Issuer{
+		ClientID:     "oidctest-client",
+		ClientSecret: "oidctest-secret",
+		key:          key,
+		grants:       make(map[string]*grant),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", i.discovery)
+	mux.HandleFunc("/token", i.token)
+	mux.HandleFunc("/jwks", i.jwks)
+	i.Server = httptest.NewServer(mux)
+	t.Cleanup(i.Close)
+	return i
+}
+
+// ProviderConfig 指向模拟身份提供方的客户端配置
+func (i *Issuer) ProviderConfig(name string) config.OIDCProviderConfig {
+	return config.OIDCProviderConfig{
+		Name:         name,
+		Issuer:       i.URL,
+		ClientID:     i.ClientID,
+		ClientSecret: i.ClientSecret,
+		RedirectURL:  "http://localhost/oidc/callback",
+		Scopes:       []string{"openid", "email", "profile"},
+	}
+}
+
+// Authorize 模拟用户在提供方完成授权：记录授权地址中的 code_challenge 和 nonce，返回授权码
+// claims 中未设置的 iss、aud、exp、iat、nonce 使用正确的默认值
+func (i *Issuer) Authorize(authURL string, claims jwt.MapClaims) (string, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return "", err
+	}
+	params := u.Query()
+	if params.Get("code_challenge_method") != "S256" || params.Get("code_challenge") == "" {
+		return "", errors.New("授权地址缺少 PKCE 参数")
+	}
+
+	merged := jwt.MapClaims{
+		"iss":   i.URL,
+		"aud":   i.ClientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+		"nonce": params.Get("nonce"),
+	}
+	for k, v := range claims {
+		merged[k] = v
+	}
+
+	code := rand.Text()
+	i.mu.Lock()
+	i.grants[code] = &grant{challenge: params.Get("code_challenge"), claims: merged}
+	i.mu.Unlock()
+	return code, nil
+}
+
+// SignIDToken 使用提供方密钥签发 ID Token
+func (i *Issuer) SignIDToken(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(i.key)
+}
+
+// discovery Discovery 文档
+func (i *Issuer) discovery(w http.ResponseWriter, _ *http.Request) {
+	i.DiscoveryHits.Add(1)
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 i.URL,
+		"authorization_endpoint": i.URL + "/authorize",
+		"token_endpoint":         i.URL + "/token",
+		"jwks_uri":               i.URL + "/jwks",
+	})
+}
+
+// token Token 端点：校验客户端凭证和 PKCE code_verifier，授权码一次性使用
+func (i *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if r.PostForm.Get("client_id") != i.ClientID || r.PostForm.Get("client_secret") != i.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	i.mu.Lock()
+	g, ok := i.grants[r.PostForm.Get("code")]
+	delete(i.grants, r.PostForm.Get("code"))
+	i.mu.Unlock()
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != g.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error":             "invalid_grant",
+			"error_description": "授权码无效或 code_verifier 不匹配",
+		})
+		return
+	}
+
+	idToken, err := i.SignIDToken(g.claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"id_token": idToken, "token_type": "Bearer"})
+}
+
+// jwks 提供方公钥
+func (i *Issuer) jwks(w http.ResponseWriter, _ *http.Request) {
+	pub := &i.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// writeJSON 写入 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	//nolint:errcheck // 测试服务写入失败由客户端报错
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,121 @@
+// Package infrastructure 基础设施层：支付渠道
+package infrastructure
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+)
+
+const (
+	// PaymentSignatureHeader 支付回调签名请求头（格式：t=<Unix时间戳>,v1=<HMAC-SHA256十六进制>）
+	PaymentSignatureHeader = "X-Payment-Signature"
+	// PaymentEventSucceeded 支付成功事件
+	PaymentEventSucceeded = "payment.succeeded"
+)
+
+// ErrPaymentSignature 支付回调签名无效或已超出允许的时间偏差
+var ErrPaymentSignature = errors.New("支付回调签名无效")
+
+// PaymentProvider 支付渠道（新增渠道时实现本接口并在 NewPaymentProvider 中登记）
+type PaymentProvider interface {
+	// Name 渠道名称（记录在订单上）
+	Name() string
+	// CreatePayment 为订单创建支付，返回渠道支付ID和支付地址
+	CreatePayment(ctx context.Context, req *PaymentRequest) (*PaymentSession, error)
+	// ParseWebhook 校验回调签名并解析事件（签名无效时返回 ErrPaymentSignature）
+	ParseWebhook(header http.Header, body []byte) (*PaymentEvent, error)
+	// Refund 全额退款（订单退款中时会重试，渠道应按支付ID幂等处理）
+	Refund(ctx context.Context, paymentID string, amount int) error
+}
+
+// PaymentRequest 创建支付请求
+type PaymentRequest struct {
+	OrderNo string // 订单号
+	Amount  int    // 支付金额（分）
+	Subject string // 商品描述
+}
+
+// PaymentSession 渠道创建的支付
+type PaymentSession struct {
+	PaymentID string // 渠道支付ID
+	PayURL    string // 用户完成支付的地址
+}
+
+// PaymentEvent 支付回调事件
+type PaymentEvent struct {
+	ID        string `json:"id"`         // 事件ID
+	Type      string `json:"type"`       // 事件类型（如 payment.succeeded）
+	OrderNo   string `json:"order_no"`   // 订单号
+	PaymentID string `json:"payment_id"` // 渠道支付ID
+	Amount    int    `json:"amount"`     // 实付金额（分）
+}
+
+// NewPaymentProvider 按配置创建支付渠道（未配置渠道时返回 nil，不开放购买）
+func NewPaymentProvider(cfg *config.PaymentConfig) (PaymentProvider, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case FakePaymentProviderName:
+		if cfg.WebhookSecret == "" {
+			return nil, errors.New("未配置支付回调签名密钥 webhook_secret")
+		}
+		if cfg.WebhookSecret == config.WebhookSecretPlaceholder {
+			return nil, errors.New("webhook_secret 仍为示例配置中的占位值，请修改为随机密钥")
+		}
+		return NewFakePaymentProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("不支持的支付渠道: %s", cfg.Provider)
+	}
+}
+
+// SignPaymentWebhook 计算回调签名请求头的值：HMAC-SHA256(secret, "<timestamp>.<body>")
+func SignPaymentWebhook(secret string, timestamp int64, body []byte) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, paymentSignature(secret, timestamp, body))
+}
+
+// VerifyPaymentWebhook 校验回调签名：签名须与请求体一致，且签名时间与当前时间的偏差不超过 tolerance（防止重放）
+func VerifyPaymentWebhook(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var timestamp int64
+	var signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return ErrPaymentSignature
+			}
+			timestamp = ts
+		case "v1":
+			signature = value
+		}
+	}
+	if timestamp == 0 || signature == "" {
+		return ErrPaymentSignature
+	}
+	if skew := now.Sub(time.Unix(timestamp, 0)); skew > tolerance || skew < -tolerance {
+		return ErrPaymentSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(paymentSignature(secret, timestamp, body))) {
+		return ErrPaymentSignature
+	}
+	return nil
+}
+
+// paymentSignature 计算签名（十六进制）
+func paymentSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
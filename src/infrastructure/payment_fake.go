@@ -0,0 +1,85 @@
+// Package infrastructure 基础设施层：本地模拟支付渠道
+package infrastructure
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+// FakePaymentProviderName 本地模拟支付渠道名称
+const FakePaymentProviderName = "fake"
+
+// FakePaymentProvider 本地模拟支付渠道（仅用于开发测试）
+// 不请求任何外部服务：支付地址指向本服务的模拟支付接口，模拟支付时生成与真实渠道格式一致的已签名回调，走同一验签流程
+type FakePaymentProvider struct {
+	secret    string
+	tolerance time.Duration
+}
+
+// NewFakePaymentProvider 创建本地模拟支付渠道
+func NewFakePaymentProvider(cfg *config.PaymentConfig) *FakePaymentProvider {
+	return &FakePaymentProvider{
+		secret:    cfg.WebhookSecret,
+		tolerance: time.Duration(cfg.WebhookTolerance) * time.Second,
+	}
+}
+
+// Name 渠道名称
+func (p *FakePaymentProvider) Name() string {
+	return FakePaymentProviderName
+}
+
+// CreatePayment 创建模拟支付（支付ID由订单号派生，支付地址为模拟支付接口）
+func (p *FakePaymentProvider) CreatePayment(_ context.Context, req *PaymentRequest) (*PaymentSession, error) {
+	return &PaymentSession{
+		PaymentID: "fake_" + req.OrderNo,
+		PayURL:    "/api/v1/payments/fake/checkout/" + req.OrderNo,
+	}, nil
+}
+
+// ParseWebhook 校验回调签名并解析事件
+func (p *FakePaymentProvider) ParseWebhook(header http.Header, body []byte) (*PaymentEvent, error) {
+	signature := header.Get(PaymentSignatureHeader)
+	if err := VerifyPaymentWebhook(p.secret, signature, body, p.tolerance, time.Now()); err != nil {
+		return nil, err
+	}
+	var event PaymentEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// Refund 模拟退款（只记录日志）
+func (p *FakePaymentProvider) Refund(_ context.Context, paymentID string, amount int) error {
+	tools.Logf("模拟支付退款 payment=%s amount=%d", paymentID, amount)
+	return nil
+}
+
+// Pay 模拟用户完成支付：生成已签名的支付成功回调（请求头和请求体）
+func (p *FakePaymentProvider) Pay(orderNo, paymentID string, amount int) (http.Header, []byte, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, nil, err
+	}
+	body, err := json.Marshal(&PaymentEvent{
+		ID:        "evt_" + hex.EncodeToString(buf),
+		Type:      PaymentEventSucceeded,
+		OrderNo:   orderNo,
+		PaymentID: paymentID,
+		Amount:    amount,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	header := http.Header{}
+	header.Set(PaymentSignatureHeader, SignPaymentWebhook(p.secret, time.Now().Unix(), body))
+	return header, body, nil
+}
@@ -0,0 +1,103 @@
+package infrastructure_test
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+)
+
+// TestVerifyPaymentWebhook 回调验签：签名与请求体、密钥、时间戳不一致或超出允许偏差时拒绝
+func TestVerifyPaymentWebhook(t *testing.T) {
+	const secret = "webhook-secret"
+	const tolerance = 300 * time.Second
+	now := time.Unix(1767225600, 0)
+	body := []byte(`{"order_no":"20260101080000K7M2Q9XD","amount":1000}`)
+	signed := infrastructure.SignPaymentWebhook(secret, now.Unix(), body)
+	_, signature, _ := strings.Cut(signed, ",")
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		ok     bool
+	}{
+		{name: "有效签名", header: signed, body: body, ok: true},
+		{name: "允许偏差内", header: infrastructure.SignPaymentWebhook(secret, now.Unix()-299, body), body: body, ok: true},
+		{name: "请求体被篡改", header: signed, body: []byte(`{"order_no":"20260101080000K7M2Q9XD","amount":1}`)},
+		{name: "密钥错误", header: infrastructure.SignPaymentWebhook("other-secret", now.Unix(), body), body: body},
+		{name: "签名过期", header: infrastructure.SignPaymentWebhook(secret, now.Unix()-301, body), body: body},
+		{name: "签名时间超前", header: infrastructure.SignPaymentWebhook(secret, now.Unix()+301, body), body: body},
+		{name: "缺少签名", header: "", body: body},
+		{name: "缺少时间戳", header: signature, body: body},
+		{name: "时间戳格式错误", header: "t=abc,v1=00", body: body},
+		{name: "时间戳被替换", header: "t=" + strconv.FormatInt(now.Unix()-1, 10) + "," + signature, body: body},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := infrastructure.VerifyPaymentWebhook(secret, tt.header, tt.body, tolerance, now)
+			if tt.ok && err != nil {
+				t.Fatalf("err = %v, want nil", err)
+			}
+			if !tt.ok && !errors.Is(err, infrastructure.ErrPaymentSignature) {
+				t.Fatalf("err = %v, want ErrPaymentSignature", err)
+			}
+		})
+	}
+}
+
+// TestFakePaymentProviderPay 模拟支付生成的回调可以通过同一渠道验签并解析出订单信息
+func TestFakePaymentProviderPay(t *testing.T) {
+	provider := infrastructure.NewFakePaymentProvider(&config.PaymentConfig{
+		WebhookSecret:    "webhook-secret",
+		WebhookTolerance: 300,
+	})
+	header, body, err := provider.Pay("20260101080000K7M2Q9XD", "fake_20260101080000K7M2Q9XD", 1000)
+	if err != nil {
+		t.Fatalf("Pay: %v", err)
+	}
+	event, err := provider.ParseWebhook(header, body)
+	if err != nil {
+		t.Fatalf("ParseWebhook: %v", err)
+	}
+	if event.Type != infrastructure.PaymentEventSucceeded || event.OrderNo != "20260101080000K7M2Q9XD" ||
+		event.PaymentID != "fake_20260101080000K7M2Q9XD" || event.Amount != 1000 {
+		t.Fatalf("event = %+v", event)
+	}
+
+	other := infrastructure.NewFakePaymentProvider(&config.PaymentConfig{
+		WebhookSecret:    "other-secret",
+		WebhookTolerance: 300,
+	})
+	if _, err := other.ParseWebhook(header, body); !errors.Is(err, infrastructure.ErrPaymentSignature) {
+		t.Fatalf("其他密钥验签 err = %v, want ErrPaymentSignature", err)
+	}
+	if _, err := provider.ParseWebhook(http.Header{}, body); !errors.Is(err, infrastructure.ErrPaymentSignature) {
+		t.Fatalf("缺少签名请求头 err = %v, want ErrPaymentSignature", err)
+	}
+}
+
+// TestNewPaymentProviderSecret 开启支付渠道时回调签名密钥不能为空或为示例配置中的占位值
+func TestNewPaymentProviderSecret(t *testing.T) {
+	for _, secret := range []string{"", config.WebhookSecretPlaceholder} {
+		_, err := infrastructure.NewPaymentProvider(&config.PaymentConfig{
+			Provider:      infrastructure.FakePaymentProviderName,
+			WebhookSecret: secret,
+		})
+		if err == nil {
+			t.Fatalf("webhook_secret = %q 时应拒绝启动", secret)
+		}
+	}
+	provider, err := infrastructure.NewPaymentProvider(&config.PaymentConfig{
+		Provider:      infrastructure.FakePaymentProviderName,
+		WebhookSecret: "webhook-secret",
+	})
+	if err != nil || provider == nil {
+		t.Fatalf("NewPaymentProvider = %v, %v", provider, err)
+	}
+}
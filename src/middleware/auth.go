@@ -13,42 +13,73 @@ import (
 const ctxKeyUserID = "user_id"
 const ctxKeyUsername = "username"
 const ctxKeyRole = "role"
+const ctxKeySessionID = "session_id"
 
 const roleAdmin = "admin"
 
 const bearerPrefix = "Bearer"
 
+const apiKeyPrefix = "ApiKey"
+
+const apiKeyHeader = "X-API-Key"
+
 // const roleUser = "user"
 
 // verifyTokenOnly 纯函数：只验证Token，不设置上下文，不中断请求，返回Claims和error
-func verifyTokenOnly(ctx *gin.Context, authService *services.AuthService) (*services.Claims, error) {
+// scopes 为接口允许的 API Key 授权范围，为空时该接口只接受 JWT
+// 凭证有效后检查账号状态：被禁用、暂停或封禁的账号立即失去访问权限
+func verifyTokenOnly(ctx *gin.Context, authService *services.AuthService, scopes ...string) (*services.Claims, error) {
+	claims, err := verifyCredential(ctx, authService, scopes)
+	if err != nil {
+		return nil, err
+	}
+	if err := authService.CheckAccountStatus(claims.Role, claims.UserID); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// verifyCredential 验证请求携带的凭证（JWT 或 API Key）
+func verifyCredential(ctx *gin.Context, authService *services.AuthService, scopes []string) (*services.Claims, error) {
+	// API Key：X-API-Key 或 Authorization: ApiKey <key>
+	if apiKey := ctx.GetHeader(apiKeyHeader); apiKey != "" {
+		return authService.VerifyAPIKey(apiKey, scopes)
+	}
+
 	authHeader := ctx.GetHeader("Authorization")
 	if authHeader == "" {
 		return nil, tools.ErrUnauthorized("未提供认证Token")
 	}
 
 	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) == 2 && parts[0] == apiKeyPrefix {
+		return authService.VerifyAPIKey(parts[1], scopes)
+	}
 	if len(parts) != 2 || parts[0] != bearerPrefix {
 		return nil, tools.ErrUnauthorized("Token格式错误")
 	}
 
 	tokenString := parts[1]
 
-	if authService.IsTokenBlacklisted(ctx.Request.Context(), tokenString) {
-		return nil, tools.ErrUnauthorized("Token已失效")
-	}
-
 	claims, err := authService.VerifyToken(tokenString)
 	if err != nil {
 		return nil, tools.ErrUnauthorized("Token无效或已过期")
 	}
 
+	if authService.IsTokenBlacklisted(ctx.Request.Context(), claims.ID) {
+		return nil, tools.ErrUnauthorized("Token已失效")
+	}
+
+	if authService.IsTokenRevoked(ctx.Request.Context(), claims) {
+		return nil, tools.ErrUnauthorized("登录已失效，请重新登录")
+	}
+
 	return claims, nil
 }
 
 // verifyToken 验证Token并设置上下文（公共逻辑）
-func verifyToken(ctx *gin.Context, authService *services.AuthService) (*services.Claims, bool) {
-	claims, err := verifyTokenOnly(ctx, authService)
+func verifyToken(ctx *gin.Context, authService *services.AuthService, scopes ...string) (*services.Claims, bool) {
+	claims, err := verifyTokenOnly(ctx, authService, scopes...)
 	if err != nil {
 		setError(ctx, err)
 		ctx.Abort() // 中断请求链
@@ -59,14 +90,33 @@ func verifyToken(ctx *gin.Context, authService *services.AuthService) (*services
 	ctx.Set(ctxKeyUserID, claims.UserID)
 	ctx.Set(ctxKeyUsername, claims.Username)
 	ctx.Set(ctxKeyRole, claims.Role)
+	ctx.Set(ctxKeySessionID, claims.ID)
+	setAuditActor(ctx, claims)
+
+	// 记录会话最近活跃时间（API Key 不关联会话）
+	if claims.ID != "" {
+		authService.TouchSession(ctx.Request.Context(), claims.ID, ClientInfo(ctx))
+	}
 
 	return claims, true
 }
 
+// setAuditActor 将已认证账号作为审计操作者写入请求 context（请求ID和IP由 RequestID 中间件写入）
+func setAuditActor(ctx *gin.Context, claims *services.Claims) {
+	actor := services.AuditActorFromContext(ctx.Request.Context())
+	actor.ID = claims.UserID
+	actor.Role = claims.Role
+	if actor.IP == "" {
+		actor.IP = ctx.ClientIP()
+	}
+	ctx.Request = ctx.Request.WithContext(services.WithAuditActor(ctx.Request.Context(), actor))
+}
+
 // AuthMiddleware JWT认证中间件
-func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
+// 传入 scopes 时同时接受具有其中任一授权范围的 API Key
+func AuthMiddleware(authService *services.AuthService, scopes ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		if _, ok := verifyToken(ctx, authService); !ok {
+		if _, ok := verifyToken(ctx, authService, scopes...); !ok {
 			return
 		}
 		ctx.Next()
@@ -144,6 +194,21 @@ func GetUsername(ctx *gin.Context) (string, error) {
 	return name, nil
 }
 
+// GetSessionID 从上下文获取当前会话ID（Token 中的 jti）
+func GetSessionID(ctx *gin.Context) string {
+	sessionID, _ := ctx.Get(ctxKeySessionID)
+	sid, _ := sessionID.(string) //nolint:errcheck // 未通过认证中间件时返回空字符串
+	return sid
+}
+
+// ClientInfo 从请求中提取客户端信息
+func ClientInfo(ctx *gin.Context) services.ClientInfo {
+	return services.ClientInfo{
+		IP:        ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}
+}
+
 // IsAdmin 检查当前用户是否为管理员
 func IsAdmin(ctx *gin.Context) bool {
 	role, exists := ctx.Get(ctxKeyRole)
@@ -0,0 +1,25 @@
+// Package middleware 内部回调鉴权中间件
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/gin-gonic/gin"
+)
+
+// InternalTokenHeader 内部服务回调令牌请求头
+const InternalTokenHeader = "X-Internal-Token"
+
+// InternalMiddleware 内部服务回调鉴权（令牌与配置一致才放行，未配置令牌时拒绝全部回调）
+func InternalMiddleware(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		provided := ctx.GetHeader(InternalTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			setError(ctx, tools.ErrUnauthorized("内部回调令牌无效"))
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
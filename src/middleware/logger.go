@@ -10,13 +10,15 @@ import (
 // Logger 自定义日志中间件
 func Logger() gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		return fmt.Sprintf("[GIN] %s | %3d | %13v | %15s | %-7s %s\n",
+		requestID, _ := param.Keys[ctxKeyRequestID].(string) //nolint:errcheck // 未设置时为空
+		return fmt.Sprintf("[GIN] %s | %3d | %13v | %15s | %-7s %s | %s\n",
 			param.TimeStamp.Format("2006/01/02 - 15:04:05"),
 			param.StatusCode,
 			param.Latency,
 			param.ClientIP,
 			param.Method,
 			param.Path,
+			requestID,
 		)
 	})
 }
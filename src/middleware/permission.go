@@ -0,0 +1,35 @@
+// Package middleware 权限中间件
+package middleware
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission 权限校验中间件（需在 AdminMiddleware 之后使用）
+// 使用方式：RequirePermission(rbacService, services.PermUsersPointsWrite)
+func RequirePermission(rbacService *services.RBACService, permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		adminID, err := GetAdminID(ctx)
+		if err != nil {
+			setError(ctx, err)
+			ctx.Abort()
+			return
+		}
+
+		ok, err := rbacService.HasPermission(adminID, permission)
+		if err != nil {
+			setError(ctx, err)
+			ctx.Abort()
+			return
+		}
+		if !ok {
+			setError(ctx, tools.ErrForbidden("缺少权限: "+permission))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
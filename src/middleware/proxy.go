@@ -2,75 +2,182 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
 	"github.com/Company-Automation-1/video-backend-go/src/services"
 	"github.com/Company-Automation-1/video-backend-go/src/tools"
 	"github.com/gin-gonic/gin"
 )
 
-// PythonProxy 像nginx一样透传，只加前置逻辑
+const (
+	// PointsCostHeader 响应头：本次请求收取的积分
+	PointsCostHeader = "X-Points-Cost"
+	// PointsHoldHeader 转发给 Python 服务的请求头：异步接口的积分预留ID（任务完成后回调结算）
+	PointsHoldHeader = "X-Points-Hold-ID"
+)
+
+// proxyStateKey 请求 context 中保存 proxyState 的 key
+type proxyStateKey struct{}
+
+// proxyState 单次代理请求的状态（ModifyResponse/ErrorHandler 通过请求 context 读取）
+type proxyState struct {
+	charge *models.ProxyCharge // 计费记录（无需扣积分时为 nil）
+	async  bool                // 异步接口（上游接受任务后保持预留）
+	err    error               // 上游错误（超时、不可达等）
+}
+
+// PythonProxy 像nginx一样透传，只加前置逻辑（按 proxy.routes 规则鉴权和计价）
+// 扣积分的接口先预留积分，上游返回 2xx 时扣除（异步接口保持预留，等待回调结算），返回其他状态码、超时或不可达时释放
 func PythonProxy(
-	pythonURL string,
-	userService *services.UserService,
+	cfg *config.ProxyConfig,
+	chargeService *services.ProxyChargeService,
 	authService *services.AuthService,
 ) gin.HandlerFunc {
-	target, err := url.Parse(pythonURL)
+	target, err := url.Parse(cfg.PythonURL)
 	if err != nil {
 		return func(ctx *gin.Context) {
 			setError(ctx, tools.ErrInternalServer("Python服务URL配置错误"))
 		}
 	}
+	routes, err := newProxyRoutes(cfg.Routes)
+	if err != nil {
+		tools.Logf("Python服务透传规则配置错误: %v", err)
+		return func(ctx *gin.Context) {
+			setError(ctx, tools.ErrInternalServer("Python服务透传规则配置错误"))
+		}
+	}
+	maxBody := int64(cfg.MaxBodyMB) * proxyBytesPerMB
 	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.ModifyResponse = func(resp *http.Response) error {
+		state := proxyStateFrom(resp.Request.Context())
+		if state == nil || state.charge == nil {
+			return nil
+		}
+		// 扣除或释放失败只记录日志，上游响应照常返回
+		switch {
+		case resp.StatusCode < 200 || resp.StatusCode >= 300:
+			_ = chargeService.Release(state.charge, resp.StatusCode, //nolint:errcheck // 失败已记录日志
+				fmt.Sprintf("上游返回状态码 %d", resp.StatusCode))
+		case state.async:
+			chargeService.Accept(state.charge, resp.StatusCode)
+		default:
+			_ = chargeService.Capture(resp.Request.Context(), state.charge, resp.StatusCode) //nolint:errcheck // 失败已记录日志
+		}
+		return nil
+	}
+	proxy.ErrorHandler = func(_ http.ResponseWriter, req *http.Request, err error) {
+		state := proxyStateFrom(req.Context())
+		if state == nil {
+			return
+		}
+		state.err = err
+		if state.charge != nil {
+			_ = chargeService.Release(state.charge, 0, "上游请求失败: "+err.Error()) //nolint:errcheck // 失败已记录日志
+		}
+	}
 
 	return func(ctx *gin.Context) {
-		// 去掉 /api/py 前缀，获取实际路径
-		path := strings.TrimPrefix(ctx.Request.URL.Path, "/api/py")
+		// 去掉 /api/py 前缀并规范化，获取实际路径：规则匹配和转发都使用规范化后的路径，
+		// 避免 //process_video、/process_video/、/./process_image 等写法绕过鉴权和计费
+		path := cleanProxyPath(strings.TrimPrefix(ctx.Request.URL.Path, "/api/py"))
 		// 修改请求路径（用于转发给Python服务）
 		ctx.Request.URL.Path = path
-		method := ctx.Request.Method
-		// 只有 /process_image 和 /process_video 的 POST 请求需要鉴权和扣积分
-		needAuth := (path == "/process_image" || path == "/process_video") && method == "POST"
+		ctx.Request.URL.RawPath = ""
+		// 未命中规则的请求直接透传
+		route := matchProxyRoute(routes, ctx.Request.Method, path)
 
-		if needAuth {
-			// 需要鉴权和扣积分：使用纯验证函数
-			claims, err := verifyTokenOnly(ctx, authService)
+		// 预留ID只能由网关设置
+		ctx.Request.Header.Del(PointsHoldHeader)
+		state := &proxyState{}
+		if route != nil && route.Auth {
+			// 需要鉴权：使用纯验证函数（API Key 需具有规则配置的授权范围，积分从 Key 所属用户扣除）
+			var scopes []string
+			if route.Scope != "" {
+				scopes = append(scopes, route.Scope)
+			}
+			claims, err := verifyTokenOnly(ctx, authService, scopes...)
 			if err != nil {
 				setError(ctx, err)
 				return
 			}
-			if !checkAndDeductPoints(userService, claims.UserID) {
-				setError(ctx, tools.ErrBadRequest("积分不足"))
+			setAuditActor(ctx, claims)
+
+			points, media, cleanup, err := proxyCost(ctx.Writer, ctx.Request, route, maxBody)
+			if err != nil {
+				setError(ctx, err)
 				return
 			}
+			defer cleanup()
+			// 预留积分（条件更新，并发请求不会超扣；可用积分不足返回 402）
+			if points > 0 {
+				ttl := time.Duration(route.HoldTimeout) * time.Second
+				state.charge, err = chargeService.Hold(ctx.Request.Context(), claims.UserID, path, route.Reason, points, media, ttl)
+				if err != nil {
+					setError(ctx, err)
+					return
+				}
+				if route.Async {
+					state.async = true
+					ctx.Request.Header.Set(PointsHoldHeader, strconv.FormatUint(uint64(state.charge.HoldID), 10))
+				} else {
+					// 同步接口在转发完成时结算，转发期间保持预留不过期
+					defer chargeService.KeepAlive(state.charge, ttl)()
+				}
+			}
+			ctx.Header(PointsCostHeader, strconv.Itoa(points))
+		}
+
+		// API Key 不转发给 Python 服务
+		ctx.Request.Header.Del(apiKeyHeader)
+		if strings.HasPrefix(ctx.Request.Header.Get("Authorization"), apiKeyPrefix+" ") {
+			ctx.Request.Header.Del("Authorization")
 		}
 
 		// 透传
+		ctx.Request = ctx.Request.WithContext(context.WithValue(ctx.Request.Context(), proxyStateKey{}, state))
 		proxy.ServeHTTP(ctx.Writer, ctx.Request)
+		if state.err != nil && !ctx.Writer.Written() {
+			tools.Logf("Python服务请求失败 path=%s request_id=%s: %v", path, GetRequestID(ctx), state.err)
+			setError(ctx, tools.ErrBadGateway("Python服务不可用"))
+			return
+		}
 		ctx.Abort()
 	}
 }
 
-// checkAndDeductPoints 检查积分是否足够，如果足够则扣积分
-func checkAndDeductPoints(userService *services.UserService, userID uint) bool {
-	user, err := userService.GetOne(query.User.ID.Eq(userID))
-	if err != nil {
-		return false
+// proxyCost 计算本次请求的积分价格（价格依赖请求体时先暂存请求体，cleanup 在转发完成后调用）
+// 按视频计价时同时返回解析出的视频信息；暂存的请求体超过 maxBody 时返回 413
+func proxyCost(
+	w http.ResponseWriter,
+	req *http.Request,
+	route *proxyRoute,
+	maxBody int64,
+) (int, *tools.MediaInfo, func(), error) {
+	if !route.needsBody() {
+		return route.Cost.Fixed, nil, func() {}, nil
 	}
-
-	points := 0
-	if user.Points != nil {
-		points = *user.Points
+	upload, cleanup, err := inspectProxyRequest(w, req, route.Cost.Field, route.needsMedia(), maxBody)
+	if err != nil {
+		return 0, nil, nil, proxyUploadError(err)
 	}
-
-	if points < 1 {
-		return false
+	if route.needsMedia() && upload.media == nil {
+		cleanup()
+		return 0, nil, nil, tools.ErrBadRequest("未找到上传的视频文件")
 	}
+	return route.cost(upload), upload.media, cleanup, nil
+}
 
-	newPoints := points - 1
-	_, err = userService.UpdatePoints(userID, &newPoints)
-	return err == nil
+// proxyStateFrom 从请求 context 读取代理状态
+func proxyStateFrom(ctx context.Context) *proxyState {
+	state, _ := ctx.Value(proxyStateKey{}).(*proxyState) //nolint:errcheck // 不存在时返回 nil
+	return state
 }
@@ -0,0 +1,287 @@
+// Package middleware 透传接口规则（鉴权与计价）
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+const (
+	// proxyBytesPerMB 按MB计价的单位
+	proxyBytesPerMB = 1 << 20
+	// proxyFieldMaxLen 计价表单字段的最大读取长度
+	proxyFieldMaxLen = 1024
+	// proxyMinute 按分钟计价的单位
+	proxyMinute = int64(time.Minute)
+)
+
+// proxyRoute 透传接口规则（由 config.ProxyRouteConfig 校验后生成）
+type proxyRoute struct {
+	config.ProxyRouteConfig
+}
+
+// proxyUpload 请求体中与计价相关的信息
+type proxyUpload struct {
+	fileBytes  int64            // 上传文件总字节数（非表单请求为整个请求体）
+	fieldValue string           // 计价表单字段的值
+	media      *tools.MediaInfo // 第一个上传文件的视频信息（仅按视频计价时解析）
+}
+
+// newProxyRoutes 校验并生成透传接口规则
+func newProxyRoutes(cfgs []config.ProxyRouteConfig) ([]*proxyRoute, error) {
+	routes := make([]*proxyRoute, 0, len(cfgs))
+	for i := range cfgs {
+		route := &proxyRoute{ProxyRouteConfig: cfgs[i]}
+		route.Method = strings.ToUpper(route.Method)
+		// 请求路径匹配前会被规范化，规则路径也必须是规范形式（如不能以 / 结尾）
+		if _, err := path.Match(route.Path, "/"); err != nil || cleanProxyPath(route.Path) != route.Path {
+			return nil, fmt.Errorf("透传接口规则 %s 路径格式错误", route.Path)
+		}
+		cost := route.Cost
+		if cost.Fixed < 0 || cost.PerMB < 0 || cost.PerMinute < 0 {
+			return nil, fmt.Errorf("透传接口规则 %s 价格不能为负数", route.Path)
+		}
+		for _, tier := range cost.ResolutionTiers {
+			if tier.MinShortSide <= 0 || tier.PerMinute < 0 {
+				return nil, fmt.Errorf("透传接口规则 %s 分辨率档位配置错误", route.Path)
+			}
+		}
+		for value, extra := range cost.FieldValues {
+			if extra < 0 {
+				return nil, fmt.Errorf("透传接口规则 %s 字段取值 %s 价格不能为负数", route.Path, value)
+			}
+		}
+		if len(cost.FieldValues) > 0 && cost.Field == "" {
+			return nil, fmt.Errorf("透传接口规则 %s 配置了 field_values 但未配置 field", route.Path)
+		}
+		if route.HoldTimeout <= 0 {
+			return nil, fmt.Errorf("透传接口规则 %s 的 hold_timeout 必须大于0", route.Path)
+		}
+		if route.priced() && !route.Auth {
+			return nil, fmt.Errorf("透传接口规则 %s 计费时必须开启 auth", route.Path)
+		}
+		if route.priced() && !services.IsPointsReason(route.Reason) {
+			return nil, fmt.Errorf("透传接口规则 %s 的积分变动原因 %q 未登记", route.Path, route.Reason)
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// cleanProxyPath 规范化请求路径（合并重复的 /，处理 . 和 ..，去掉末尾的 /）
+func cleanProxyPath(reqPath string) string {
+	return path.Clean("/" + reqPath)
+}
+
+// matchProxyRoute 按顺序匹配透传接口规则（reqPath 须已规范化，未命中时返回 nil）
+func matchProxyRoute(routes []*proxyRoute, method, reqPath string) *proxyRoute {
+	for _, route := range routes {
+		if route.Method != "" && route.Method != method {
+			continue
+		}
+		if ok, _ := path.Match(route.Path, reqPath); ok { //nolint:errcheck // 模式已在启动时校验
+			return route
+		}
+	}
+	return nil
+}
+
+// priced 是否可能收取积分
+func (r *proxyRoute) priced() bool {
+	if r.Cost.Fixed > 0 || r.Cost.PerMB > 0 || r.Cost.PerMinute > 0 {
+		return true
+	}
+	for _, extra := range r.Cost.FieldValues {
+		if extra > 0 {
+			return true
+		}
+	}
+	for _, tier := range r.Cost.ResolutionTiers {
+		if tier.PerMinute > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// needsMedia 价格是否依赖视频时长和分辨率
+func (r *proxyRoute) needsMedia() bool {
+	return r.Cost.PerMinute > 0 || len(r.Cost.ResolutionTiers) > 0
+}
+
+// needsBody 价格是否依赖请求体（上传文件大小、表单字段或视频信息）
+func (r *proxyRoute) needsBody() bool {
+	return r.Cost.PerMB > 0 || r.Cost.Field != "" || r.needsMedia()
+}
+
+// cost 计算本次请求的积分价格
+func (r *proxyRoute) cost(upload *proxyUpload) int {
+	mb := int((upload.fileBytes + proxyBytesPerMB - 1) / proxyBytesPerMB)
+	points := r.Cost.Fixed + r.Cost.PerMB*mb + r.Cost.FieldValues[upload.fieldValue]
+	if upload.media != nil {
+		// 不足1分钟按1分钟计
+		minutes := max(int((int64(upload.media.Duration)+proxyMinute-1)/proxyMinute), 1)
+		points += minutes * (r.Cost.PerMinute + r.tierPerMinute(upload.media.ShortSide()))
+	}
+	return points
+}
+
+// tierPerMinute 视频分辨率所在档位的每分钟加价（取满足条件的最高档）
+func (r *proxyRoute) tierPerMinute(shortSide int) int {
+	best, extra := 0, 0
+	for _, tier := range r.Cost.ResolutionTiers {
+		if shortSide >= tier.MinShortSide && tier.MinShortSide > best {
+			best, extra = tier.MinShortSide, tier.PerMinute
+		}
+	}
+	return extra
+}
+
+// inspectProxyRequest 将请求体暂存到临时文件并读取计价信息，之后请求体从临时文件重新读取
+// 请求体超过 maxBytes 时返回 *http.MaxBytesError；返回的 cleanup 必须在请求转发完成后调用
+func inspectProxyRequest(
+	w http.ResponseWriter,
+	req *http.Request,
+	field string,
+	parseMedia bool,
+	maxBytes int64,
+) (*proxyUpload, func(), error) {
+	if req.ContentLength > maxBytes {
+		return nil, nil, &http.MaxBytesError{Limit: maxBytes}
+	}
+	req.Body = http.MaxBytesReader(w, req.Body, maxBytes)
+	file, err := os.CreateTemp("", "proxy-body-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		_ = file.Close()           //nolint:errcheck // 临时文件
+		_ = os.Remove(file.Name()) //nolint:errcheck // 临时文件
+	}
+	size, err := io.Copy(file, req.Body)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	_ = req.Body.Close() //nolint:errcheck // 已读取完毕
+
+	upload, err := parseProxyUpload(req, file, size, field, parseMedia)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	req.Body = io.NopCloser(file)
+	req.ContentLength = size
+	req.GetBody = nil
+	return upload, cleanup, nil
+}
+
+// parseProxyUpload 解析暂存的请求体：multipart 表单统计文件部分的大小并读取计价字段，
+// urlencoded 表单只读取字段，其他类型将整个请求体视为上传文件；查询参数中的字段优先
+// parseMedia 为 true 时解析第一个上传文件的视频时长和分辨率
+func parseProxyUpload(req *http.Request, file *os.File, size int64, field string, parseMedia bool) (*proxyUpload, error) {
+	upload := &proxyUpload{}
+	if field != "" {
+		upload.fieldValue = req.URL.Query().Get(field)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type")) //nolint:errcheck // 无法识别时按原始请求体处理
+	switch mediaType {
+	case "multipart/form-data":
+		reader := multipart.NewReader(file, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return upload, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if part.FileName() != "" {
+				counter := &countingReader{reader: part}
+				if parseMedia && upload.media == nil {
+					if upload.media, err = tools.ParseMP4(counter); err != nil {
+						return nil, err
+					}
+				}
+				if _, err := io.Copy(io.Discard, counter); err != nil {
+					return nil, err
+				}
+				upload.fileBytes += counter.n
+				continue
+			}
+			if field != "" && part.FormName() == field && upload.fieldValue == "" {
+				value, err := io.ReadAll(io.LimitReader(part, proxyFieldMaxLen))
+				if err != nil {
+					return nil, err
+				}
+				upload.fieldValue = string(value)
+			}
+		}
+	case "application/x-www-form-urlencoded":
+		body, err := io.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		if field != "" && upload.fieldValue == "" {
+			upload.fieldValue = values.Get(field)
+		}
+	default:
+		upload.fileBytes = size
+		if parseMedia {
+			var err error
+			if upload.media, err = tools.ParseMP4(file); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return upload, nil
+}
+
+// countingReader 统计已读取字节数
+type countingReader struct {
+	reader io.Reader
+	n      int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// proxyUploadError 转换请求体解析错误
+func proxyUploadError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return tools.ErrRequestEntityTooLarge(fmt.Sprintf("请求体不能超过 %dMB", tooLarge.Limit/proxyBytesPerMB))
+	}
+	if errors.Is(err, tools.ErrInvalidMP4) {
+		return tools.ErrBadRequest("无法识别的视频文件（仅支持 MP4/MOV）")
+	}
+	return tools.ErrBadRequest("请求体读取失败")
+}
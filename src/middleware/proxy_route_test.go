@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/gin-gonic/gin"
+)
+
+func TestProxyCostBodyLimit(t *testing.T) {
+	routes, err := newProxyRoutes([]config.ProxyRouteConfig{{
+		Path: "/upload", Auth: true, Reason: "process_image", HoldTimeout: 60,
+		Cost: config.ProxyCostConfig{PerMB: 1},
+	}})
+	if err != nil {
+		t.Fatalf("newProxyRoutes: %v", err)
+	}
+	const limit = 1024
+
+	tests := []struct {
+		name          string
+		size          int
+		contentLength bool // 是否声明 Content-Length（否则为分块传输）
+		wantCode      int
+	}{
+		{name: "未超过上限", size: limit, contentLength: true},
+		{name: "Content-Length 超过上限", size: limit + 1, contentLength: true, wantCode: http.StatusRequestEntityTooLarge},
+		{name: "分块传输超过上限", size: limit + 1, wantCode: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := bytes.Repeat([]byte("a"), tt.size)
+			req := httptest.NewRequest(http.MethodPost, "/upload", io.NopCloser(bytes.NewReader(body)))
+			req.Header.Set("Content-Type", "application/octet-stream")
+			req.ContentLength = -1
+			if tt.contentLength {
+				req.ContentLength = int64(tt.size)
+			}
+
+			points, _, cleanup, err := proxyCost(httptest.NewRecorder(), req, routes[0], limit)
+			if tt.wantCode != 0 {
+				if tools.GetCode(err) != tt.wantCode {
+					t.Fatalf("err = %v, want %d", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("proxyCost: %v", err)
+			}
+			defer cleanup()
+			if points != 1 {
+				t.Fatalf("points = %d, want 1", points)
+			}
+			// 转发时请求体从暂存文件完整读取
+			forwarded, err := io.ReadAll(req.Body)
+			if err != nil || len(forwarded) != tt.size || req.ContentLength != int64(tt.size) {
+				t.Fatalf("转发请求体 %d 字节（Content-Length %d），err = %v", len(forwarded), req.ContentLength, err)
+			}
+		})
+	}
+
+	if !strings.Contains(tools.GetMessage(proxyUploadError(&http.MaxBytesError{Limit: 2 << 20})), "2MB") {
+		t.Fatal("413 错误信息应包含上限")
+	}
+}
+
+func TestMatchProxyRouteCleanPath(t *testing.T) {
+	routes, err := newProxyRoutes([]config.ProxyRouteConfig{
+		{Path: "/process_video", Method: "POST", Auth: true, Reason: "process_video", HoldTimeout: 60,
+			Cost: config.ProxyCostConfig{Fixed: 10}},
+		{Path: "/process_image", Auth: true, Reason: "process_image", HoldTimeout: 60,
+			Cost: config.ProxyCostConfig{Fixed: 1}},
+	})
+	if err != nil {
+		t.Fatalf("newProxyRoutes: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want string // 命中的规则（空表示未命中）
+	}{
+		{path: "/process_video", want: "/process_video"},
+		{path: "//process_video", want: "/process_video"},
+		{path: "/process_video/", want: "/process_video"},
+		{path: "/process_video//", want: "/process_video"},
+		{path: "/./process_video", want: "/process_video"},
+		{path: "/foo/../process_video", want: "/process_video"},
+		{path: "/../process_video", want: "/process_video"},
+		{path: "process_video", want: "/process_video"},
+		{path: "/./process_image", want: "/process_image"},
+		{path: "///process_image/.", want: "/process_image"},
+		{path: "/process_video/x", want: ""},
+		{path: "/health", want: ""},
+		{path: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			route := matchProxyRoute(routes, http.MethodPost, cleanProxyPath(tt.path))
+			got := ""
+			if route != nil {
+				got = route.Path
+			}
+			if got != tt.want {
+				t.Fatalf("%q 命中 %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	// 规则路径必须是规范形式
+	for _, bad := range []string{"/process_video/", "//process_video", "/a/../b", "process_video"} {
+		_, err := newProxyRoutes([]config.ProxyRouteConfig{{Path: bad, HoldTimeout: 60}})
+		if err == nil {
+			t.Fatalf("规则路径 %q 应被拒绝", bad)
+		}
+	}
+}
+
+func TestPythonProxyCleanPath(t *testing.T) {
+	var forwarded []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		forwarded = append(forwarded, r.URL.Path)
+	}))
+	defer upstream.Close()
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ResponseMiddleware())
+	r.Any("/api/py/*path", PythonProxy(&config.ProxyConfig{
+		PythonURL: upstream.URL,
+		Routes: []config.ProxyRouteConfig{{
+			Path: "/process_video", Auth: true, Reason: "process_video", HoldTimeout: 60,
+			Cost: config.ProxyCostConfig{Fixed: 10},
+		}},
+	}, nil, nil))
+
+	// ReverseProxy 需要 CloseNotifier，httptest.ResponseRecorder 不支持，走真实的测试服务
+	server := httptest.NewServer(r)
+	defer server.Close()
+	send := func(method, p string) int {
+		req, err := http.NewRequest(method, server.URL+"/api/py"+p, strings.NewReader("{}"))
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, p, err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// 需要鉴权的接口：各种写法都要求登录，不会未鉴权透传
+	for _, p := range []string{"/process_video", "//process_video", "/process_video/", "/./process_video",
+		"/%2e/process_video", "/x/%2e%2e/process_video"} {
+		if code := send(http.MethodPost, p); code != http.StatusUnauthorized {
+			t.Fatalf("%s 状态码 = %d, want 401", p, code)
+		}
+	}
+	if len(forwarded) != 0 {
+		t.Fatalf("未鉴权的请求被转发: %v", forwarded)
+	}
+
+	// 未命中规则的接口按规范化后的路径转发
+	if code := send(http.MethodGet, "//status/./"); code != http.StatusOK || len(forwarded) != 1 ||
+		forwarded[0] != "/status" {
+		t.Fatalf("状态码 = %d 转发路径 = %v, want 200 [/status]", code, forwarded)
+	}
+}
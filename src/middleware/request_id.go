@@ -0,0 +1,52 @@
+// Package middleware 请求ID中间件
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+	"github.com/gin-gonic/gin"
+)
+
+const ctxKeyRequestID = "request_id"
+
+// RequestIDHeader 请求ID请求头（客户端或网关传入时沿用，否则生成）
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern 允许沿用的请求ID格式（防止日志注入和超长值）
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`) //nolint:gochecknoglobals // 正则为只读常量
+
+// RequestID 请求ID中间件：为每个请求分配ID并写入响应头，同时写入审计 context 供服务层记录
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+		ctx.Set(ctxKeyRequestID, requestID)
+		ctx.Header(RequestIDHeader, requestID)
+		ctx.Request = ctx.Request.WithContext(services.WithAuditActor(ctx.Request.Context(), services.AuditActor{
+			IP:        ctx.ClientIP(),
+			RequestID: requestID,
+		}))
+		ctx.Next()
+	}
+}
+
+// GetRequestID 从上下文获取请求ID
+func GetRequestID(ctx *gin.Context) string {
+	requestID, _ := ctx.Get(ctxKeyRequestID)
+	id, _ := requestID.(string) //nolint:errcheck // 未经过 RequestID 中间件时返回空字符串
+	return id
+}
+
+// newRequestID 生成请求ID（16字节随机数十六进制）
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -52,6 +53,9 @@ func Created(ctx *gin.Context, data interface{}) {
 func setError(ctx *gin.Context, err error) {
 	code := tools.GetCode(err)
 	message := tools.GetMessage(err)
+	if retryAfter := tools.GetRetryAfter(err); retryAfter > 0 {
+		ctx.Header("Retry-After", strconv.Itoa(retryAfter))
+	}
 	ctx.Set(ctxKeyResult, &Result{
 		Code:      code,
 		Success:   false,
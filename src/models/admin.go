@@ -1,17 +1,29 @@
 // Package models 定义数据模型
 package models
 
+// 管理员状态
+const (
+	// AdminStatusActive 正常
+	AdminStatusActive int8 = 1
+	// AdminStatusDisabled 已禁用（禁止登录，已签发的令牌全部失效）
+	AdminStatusDisabled int8 = 2
+)
+
 // Admin 管理员模型
 type Admin struct {
-	ID        uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
-	Username  string `gorm:"type:varchar(100);not null;uniqueIndex:idx_username;comment:用户名" json:"username"`
-	Password  string `gorm:"type:varchar(100);not null;comment:密码" json:"-"`
-	CreatedAt int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
-	UpdatedAt int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+	ID           uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	Username     string `gorm:"type:varchar(100);not null;uniqueIndex:idx_username;comment:用户名" json:"username"`
+	Password     string `gorm:"type:varchar(100);not null;comment:密码" json:"-"`
+	RoleID       *uint  `gorm:"default:null;index:idx_role_id;comment:角色ID" json:"role_id"`
+	Status       int8   `gorm:"type:tinyint;not null;default:1;comment:状态：1正常 2禁用" json:"status"`
+	TOTPSecret   string `gorm:"column:totp_secret;type:varchar(64);not null;default:'';comment:TOTP密钥" json:"-"`
+	TOTPEnabled  bool   `gorm:"column:totp_enabled;default:false;comment:是否启用TOTP两步验证" json:"totp_enabled"`
+	TokenVersion int64  `gorm:"not null;default:0;comment:令牌版本（递增即吊销全部令牌）" json:"-"`
+	CreatedAt    int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt    int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
 }
 
 // TableName 指定表名
 func (Admin) TableName() string {
 	return "a_admins"
 }
-
@@ -0,0 +1,22 @@
+// Package models 定义数据模型
+package models
+
+// APIKey 用户 API Key（供批处理等机器客户端调用，只保存 SHA-256 摘要）
+type APIKey struct {
+	ID         uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	UserID     uint   `gorm:"not null;index:idx_user_id;comment:所属用户ID" json:"user_id"`
+	Name       string `gorm:"type:varchar(50);not null;comment:名称" json:"name"`
+	Prefix     string `gorm:"type:varchar(16);not null;uniqueIndex:idx_prefix;comment:Key前缀（用于查找和展示）" json:"prefix"`
+	KeyHash    string `gorm:"type:char(64);not null;comment:Key摘要" json:"-"`
+	Scopes     string `gorm:"type:varchar(255);not null;comment:授权范围（逗号分隔）" json:"scopes"`
+	ExpiresAt  *int64 `gorm:"default:null;comment:过期时间" json:"expires_at"`
+	LastUsedAt *int64 `gorm:"default:null;comment:最近使用时间" json:"last_used_at"`
+	RevokedAt  *int64 `gorm:"default:null;comment:吊销时间" json:"revoked_at"`
+	CreatedAt  int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt  int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (APIKey) TableName() string {
+	return "a_api_keys"
+}
@@ -0,0 +1,22 @@
+// Package models 定义数据模型
+package models
+
+// AuditLog 审计日志（只追加，不允许修改和删除）
+type AuditLog struct {
+	ID         uint    `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	ActorID    uint    `gorm:"not null;index:idx_actor;comment:操作者ID（0表示未登录）" json:"actor_id"`
+	ActorRole  string  `gorm:"type:varchar(20);not null;index:idx_actor;comment:操作者角色" json:"actor_role"`
+	Action     string  `gorm:"type:varchar(50);not null;index:idx_action;comment:操作" json:"action"`
+	TargetType string  `gorm:"type:varchar(30);not null;index:idx_target;comment:操作对象类型" json:"target_type"`
+	TargetID   string  `gorm:"type:varchar(100);not null;index:idx_target;comment:操作对象ID" json:"target_id"`
+	Before     *string `gorm:"type:json;default:null;comment:变更前（仅变更字段）" json:"before"`
+	After      *string `gorm:"type:json;default:null;comment:变更后（仅变更字段）" json:"after"`
+	IP         string  `gorm:"type:varchar(45);not null;default:'';comment:请求IP" json:"ip"`
+	RequestID  string  `gorm:"type:varchar(64);not null;default:'';index:idx_request_id;comment:请求ID" json:"request_id"`
+	CreatedAt  int64   `gorm:"autoCreateTime;index:idx_created_at;comment:创建时间" json:"created_at"`
+}
+
+// TableName 指定表名
+func (AuditLog) TableName() string {
+	return "a_audit_logs"
+}
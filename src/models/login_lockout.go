@@ -0,0 +1,11 @@
+// Package models 定义数据模型
+package models
+
+// LoginLockout 登录锁定记录（存储于Redis）
+type LoginLockout struct {
+	Role       string `json:"role"`        // 登录角色：user 或 admin
+	Type       string `json:"type"`        // 锁定维度：username 或 ip
+	Value      string `json:"value"`       // 用户名或IP
+	Failures   int64  `json:"failures"`    // 锁定时的失败次数
+	RetryAfter int64  `json:"retry_after"` // 剩余锁定秒数
+}
@@ -0,0 +1,17 @@
+// Package models 定义数据模型
+package models
+
+// MFARecoveryCode 两步验证恢复码（只保存SHA-256摘要，每个恢复码只能使用一次）
+type MFARecoveryCode struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	Role      string `gorm:"type:varchar(20);not null;index:idx_owner,priority:1;comment:账号角色" json:"role"`
+	AccountID uint   `gorm:"not null;index:idx_owner,priority:2;comment:账号ID" json:"account_id"`
+	CodeHash  string `gorm:"type:char(64);not null;comment:恢复码摘要" json:"-"`
+	UsedAt    *int64 `gorm:"default:null;comment:使用时间" json:"used_at"`
+	CreatedAt int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+// TableName 指定表名
+func (MFARecoveryCode) TableName() string {
+	return "a_mfa_recovery_codes"
+}
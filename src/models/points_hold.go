@@ -0,0 +1,36 @@
+// Package models 定义数据模型
+package models
+
+// 积分预留状态
+const (
+	// PointsHoldStatusHeld 预留中（计入用户的已预留积分）
+	PointsHoldStatusHeld int8 = 1
+	// PointsHoldStatusCaptured 已扣除
+	PointsHoldStatusCaptured int8 = 2
+	// PointsHoldStatusReleased 已释放
+	PointsHoldStatusReleased int8 = 3
+	// PointsHoldStatusExpired 已过期释放
+	PointsHoldStatusExpired int8 = 4
+)
+
+// PointsHold 积分预留（预留期间积分仍在余额中但不可用，扣除时才写入积分流水）
+type PointsHold struct {
+	ID             uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	UserID         uint   `gorm:"not null;index:idx_user_id;comment:用户ID" json:"user_id"`
+	Points         int    `gorm:"not null;comment:预留积分" json:"points"`
+	Reason         string `gorm:"type:varchar(50);not null;comment:扣除时使用的积分变动原因" json:"reason"`
+	ReferenceID    string `gorm:"type:varchar(100);not null;default:'';index:idx_reference_id;comment:关联业务ID" json:"reference_id"`
+	Status         int8   `gorm:"type:tinyint;not null;default:1;index:idx_status_expires,priority:1;comment:状态：1预留中 2已扣除 3已释放 4已过期" json:"status"`
+	ExpiresAt      int64  `gorm:"not null;index:idx_status_expires,priority:2;comment:过期时间" json:"expires_at"`
+	CapturedPoints int    `gorm:"not null;default:0;comment:实际扣除积分" json:"captured_points"`
+	ReleaseReason  string `gorm:"type:varchar(255);not null;default:'';comment:释放原因" json:"release_reason"`
+	TransactionID  *uint  `gorm:"default:null;comment:扣除流水ID" json:"transaction_id"`
+	SettledAt      *int64 `gorm:"default:null;comment:结算时间" json:"settled_at"`
+	CreatedAt      int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt      int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (PointsHold) TableName() string {
+	return "a_points_holds"
+}
@@ -0,0 +1,38 @@
+// Package models 定义数据模型
+package models
+
+// PointsJournal 积分变更日志（哈希链，只追加）
+// 每行保存本行内容与上一行哈希共同计算的 SHA-256，任意一行被修改、删除或插入都会使后续链接断开
+type PointsJournal struct {
+	ID           uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	Seq          uint64 `gorm:"not null;uniqueIndex:idx_seq;comment:链上序号（从1开始连续递增）" json:"seq"`
+	UserID       uint   `gorm:"not null;index:idx_user_id;comment:用户ID" json:"user_id"`
+	Delta        int    `gorm:"not null;comment:变动值" json:"delta"`
+	PointsBefore *int   `gorm:"default:null;comment:变动前积分" json:"points_before"`
+	PointsAfter  *int   `gorm:"default:null;comment:变动后积分" json:"points_after"`
+	Reason       string `gorm:"type:varchar(50);not null;comment:变动原因" json:"reason"`
+	ActorID      uint   `gorm:"not null;comment:操作者ID" json:"actor_id"`
+	ActorRole    string `gorm:"type:varchar(20);not null;comment:操作者角色" json:"actor_role"`
+	RequestID    string `gorm:"type:varchar(64);not null;default:'';comment:请求ID" json:"request_id"`
+	PrevHash     string `gorm:"type:char(64);not null;comment:上一行哈希" json:"prev_hash"`
+	Hash         string `gorm:"type:char(64);not null;comment:本行哈希" json:"hash"`
+	CreatedAt    int64  `gorm:"not null;comment:创建时间" json:"created_at"`
+}
+
+// TableName 指定表名
+func (PointsJournal) TableName() string {
+	return "a_points_journal"
+}
+
+// PointsJournalHead 积分变更日志链头（单行，追加时加行锁串行化）
+type PointsJournalHead struct {
+	ID        uint   `gorm:"primaryKey;comment:ID（固定为1）" json:"id"`
+	Seq       uint64 `gorm:"not null;comment:最新序号" json:"seq"`
+	Hash      string `gorm:"type:char(64);not null;comment:最新哈希" json:"hash"`
+	UpdatedAt int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (PointsJournalHead) TableName() string {
+	return "a_points_journal_head"
+}
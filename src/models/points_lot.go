@@ -0,0 +1,22 @@
+// Package models 定义数据模型
+package models
+
+// PointsLot 积分批次（每笔获得的积分为一个批次，扣除时按先进先出消耗，过期后由定时任务扣除剩余积分）
+type PointsLot struct {
+	ID            uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	UserID        uint   `gorm:"not null;index:idx_user_id;comment:用户ID" json:"user_id"`
+	Reason        string `gorm:"type:varchar(32);not null;comment:积分来源（变动原因）" json:"reason"`
+	TransactionID uint   `gorm:"not null;comment:获得积分的流水ID（存量迁移为0）" json:"transaction_id"`
+	Points        int    `gorm:"not null;comment:获得积分" json:"points"`
+	Remaining     int    `gorm:"not null;comment:剩余积分" json:"remaining"`
+	ExpiredPoints int    `gorm:"not null;default:0;comment:已过期积分" json:"expired_points"`
+	ExpiresAt     *int64 `gorm:"default:null;index:idx_expires_at;comment:过期时间（NULL 表示永不过期）" json:"expires_at"`
+	ExpiredAt     *int64 `gorm:"default:null;comment:过期处理时间" json:"expired_at"`
+	CreatedAt     int64  `gorm:"autoCreateTime;comment:获得时间" json:"created_at"`
+	UpdatedAt     int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (PointsLot) TableName() string {
+	return "a_points_lots"
+}
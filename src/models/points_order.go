@@ -0,0 +1,43 @@
+// Package models 定义数据模型
+package models
+
+// 积分订单状态（只能按 待支付 → 已支付 → 已到账 → 退款中 → 已退款 依次流转）
+const (
+	// PointsOrderStatusCreated 待支付
+	PointsOrderStatusCreated int8 = 1
+	// PointsOrderStatusPaid 已支付（支付渠道回调确认，积分尚未到账）
+	PointsOrderStatusPaid int8 = 2
+	// PointsOrderStatusFulfilled 已到账
+	PointsOrderStatusFulfilled int8 = 3
+	// PointsOrderStatusRefunded 已退款（积分已扣回）
+	PointsOrderStatusRefunded int8 = 4
+	// PointsOrderStatusRefunding 退款中（积分已扣回，渠道退款尚未确认）
+	PointsOrderStatusRefunding int8 = 5
+)
+
+// PointsOrder 积分订单（下单时保存套餐名称、积分和价格快照，套餐修改不影响已有订单）
+type PointsOrder struct {
+	ID                  uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	OrderNo             string `gorm:"type:varchar(32);not null;uniqueIndex:uk_order_no;comment:订单号" json:"order_no"`
+	UserID              uint   `gorm:"not null;index:idx_user_id;comment:用户ID" json:"user_id"`
+	ProductID           uint   `gorm:"not null;comment:积分套餐ID" json:"product_id"`
+	ProductName         string `gorm:"type:varchar(100);not null;comment:套餐名称" json:"product_name"`
+	Points              int    `gorm:"not null;comment:获得积分" json:"points"`
+	Amount              int    `gorm:"not null;comment:支付金额（分）" json:"amount"`
+	Provider            string `gorm:"type:varchar(20);not null;comment:支付渠道" json:"provider"`
+	PaymentID           string `gorm:"type:varchar(100);not null;default:'';comment:渠道支付ID" json:"payment_id"`
+	Status              int8   `gorm:"type:tinyint;not null;default:1;index:idx_status;comment:状态：1待支付 2已支付 3已到账 4已退款 5退款中" json:"status"`
+	TransactionID       *uint  `gorm:"default:null;comment:到账流水ID" json:"transaction_id"`
+	RefundTransactionID *uint  `gorm:"default:null;comment:退款扣回流水ID" json:"refund_transaction_id"`
+	RefundReason        string `gorm:"type:varchar(255);not null;default:'';comment:退款原因" json:"refund_reason"`
+	PaidAt              *int64 `gorm:"default:null;comment:支付时间" json:"paid_at"`
+	FulfilledAt         *int64 `gorm:"default:null;comment:到账时间" json:"fulfilled_at"`
+	RefundedAt          *int64 `gorm:"default:null;comment:退款时间" json:"refunded_at"`
+	CreatedAt           int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt           int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (PointsOrder) TableName() string {
+	return "a_points_orders"
+}
@@ -0,0 +1,26 @@
+// Package models 定义数据模型
+package models
+
+// 积分套餐状态
+const (
+	// PointsProductStatusActive 上架（用户可购买）
+	PointsProductStatusActive int8 = 1
+	// PointsProductStatusInactive 下架
+	PointsProductStatusInactive int8 = 2
+)
+
+// PointsProduct 积分套餐（用户以固定价格购买固定积分）
+type PointsProduct struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	Name      string `gorm:"type:varchar(100);not null;comment:套餐名称" json:"name"`
+	Points    int    `gorm:"not null;comment:获得积分" json:"points"`
+	Price     int    `gorm:"not null;comment:价格（分）" json:"price"`
+	Status    int8   `gorm:"type:tinyint;not null;default:1;index:idx_status;comment:状态：1上架 2下架" json:"status"`
+	CreatedAt int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (PointsProduct) TableName() string {
+	return "a_points_products"
+}
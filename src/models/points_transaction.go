@@ -0,0 +1,23 @@
+// Package models 定义数据模型
+package models
+
+// PointsTransaction 积分流水（用户积分的唯一变动记录，余额只能通过积分账本服务变更）
+// 每笔流水的另一方为 CounterAccount 指向的系统账户，用户侧与系统侧变动之和为 0
+type PointsTransaction struct {
+	ID             uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	UserID         uint   `gorm:"not null;index:idx_user_id;comment:用户ID" json:"user_id"`
+	Delta          int    `gorm:"not null;comment:变动值（正数增加，负数扣除）" json:"delta"`
+	BalanceAfter   int    `gorm:"not null;comment:变动后余额" json:"balance_after"`
+	Reason         string `gorm:"type:varchar(50);not null;index:idx_reason;comment:变动原因" json:"reason"`
+	CounterAccount string `gorm:"type:varchar(50);not null;comment:对方系统账户" json:"counter_account"`
+	ReferenceID    string `gorm:"type:varchar(100);not null;default:'';index:idx_reference_id;comment:关联业务ID" json:"reference_id"`
+	ActorID        uint   `gorm:"not null;comment:操作者ID" json:"actor_id"`
+	ActorRole      string `gorm:"type:varchar(20);not null;comment:操作者角色" json:"actor_role"`
+	RequestID      string `gorm:"type:varchar(64);not null;default:'';comment:请求ID" json:"request_id"`
+	CreatedAt      int64  `gorm:"autoCreateTime;index:idx_created_at;comment:创建时间" json:"created_at"`
+}
+
+// TableName 指定表名
+func (PointsTransaction) TableName() string {
+	return "a_points_transactions"
+}
@@ -0,0 +1,23 @@
+// Package models 定义数据模型
+package models
+
+// ProxyCharge Python 处理接口的计费记录（每次收费调用一条，积分通过 HoldID 对应的预留扣除或释放）
+type ProxyCharge struct {
+	ID              uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	UserID          uint   `gorm:"not null;index:idx_user_id;comment:用户ID" json:"user_id"`
+	RequestID       string `gorm:"type:varchar(64);not null;index:idx_request_id;comment:请求ID" json:"request_id"`
+	Path            string `gorm:"type:varchar(100);not null;comment:接口路径" json:"path"`
+	Points          int    `gorm:"not null;comment:价格" json:"points"`
+	MediaDurationMs int64  `gorm:"not null;default:0;comment:视频时长（毫秒，按视频计价时记录）" json:"media_duration_ms"`
+	MediaWidth      int    `gorm:"not null;default:0;comment:视频宽度（像素）" json:"media_width"`
+	MediaHeight     int    `gorm:"not null;default:0;comment:视频高度（像素）" json:"media_height"`
+	HoldID          uint   `gorm:"not null;index:idx_hold_id;comment:积分预留ID" json:"hold_id"`
+	UpstreamStatus  int    `gorm:"not null;default:0;comment:上游响应状态码（0表示未收到响应）" json:"upstream_status"`
+	CreatedAt       int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt       int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (ProxyCharge) TableName() string {
+	return "a_proxy_charges"
+}
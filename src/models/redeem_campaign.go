@@ -0,0 +1,22 @@
+// Package models 定义数据模型
+package models
+
+// RedeemCampaign 兑换码活动（批量生成兑换码，每个兑换码可兑换固定积分）
+type RedeemCampaign struct {
+	ID             uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	Name           string `gorm:"type:varchar(100);not null;comment:活动名称" json:"name"`
+	Points         int    `gorm:"not null;comment:每次兑换获得的积分" json:"points"`
+	CodeCount      int    `gorm:"not null;comment:兑换码数量" json:"code_count"`
+	MaxUsesPerCode int    `gorm:"not null;default:1;comment:每个兑换码可使用次数" json:"max_uses_per_code"`
+	PerUserLimit   int    `gorm:"not null;default:1;comment:每个用户在本活动中可兑换次数" json:"per_user_limit"`
+	StartsAt       int64  `gorm:"not null;comment:生效时间" json:"starts_at"`
+	EndsAt         int64  `gorm:"not null;comment:截止时间" json:"ends_at"`
+	CreatedBy      uint   `gorm:"not null;comment:创建管理员ID" json:"created_by"`
+	CreatedAt      int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt      int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (RedeemCampaign) TableName() string {
+	return "a_redeem_campaigns"
+}
@@ -0,0 +1,17 @@
+// Package models 定义数据模型
+package models
+
+// RedeemCode 兑换码
+type RedeemCode struct {
+	ID         uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	CampaignID uint   `gorm:"not null;index:idx_campaign_id;comment:活动ID" json:"campaign_id"`
+	Code       string `gorm:"type:varchar(32);not null;uniqueIndex:uk_code;comment:兑换码" json:"code"`
+	UsedCount  int    `gorm:"not null;default:0;comment:已使用次数" json:"used_count"`
+	CreatedAt  int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt  int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (RedeemCode) TableName() string {
+	return "a_redeem_codes"
+}
@@ -0,0 +1,18 @@
+// Package models 定义数据模型
+package models
+
+// RedeemRedemption 兑换记录（同一用户对同一兑换码最多一条，重复兑换返回已有记录）
+type RedeemRedemption struct {
+	ID            uint  `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	CampaignID    uint  `gorm:"not null;index:idx_campaign_user,priority:1;comment:活动ID" json:"campaign_id"`
+	CodeID        uint  `gorm:"not null;uniqueIndex:uk_code_user,priority:1;comment:兑换码ID" json:"code_id"`
+	UserID        uint  `gorm:"not null;uniqueIndex:uk_code_user,priority:2;index:idx_campaign_user,priority:2;comment:用户ID" json:"user_id"`
+	Points        int   `gorm:"not null;comment:获得积分" json:"points"`
+	TransactionID uint  `gorm:"not null;comment:积分流水ID" json:"transaction_id"`
+	CreatedAt     int64 `gorm:"autoCreateTime;comment:兑换时间" json:"created_at"`
+}
+
+// TableName 指定表名
+func (RedeemRedemption) TableName() string {
+	return "a_redeem_redemptions"
+}
@@ -0,0 +1,19 @@
+// Package models 定义数据模型
+package models
+
+// RewardGrant 积分奖励发放记录（同一用户、同一奖励类型、同一发放键只发放一次）
+type RewardGrant struct {
+	ID            uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	UserID        uint   `gorm:"not null;uniqueIndex:uk_user_type_key,priority:1;index:idx_user_type_day,priority:1;comment:用户ID" json:"user_id"`
+	Type          string `gorm:"type:varchar(32);not null;uniqueIndex:uk_user_type_key,priority:2;index:idx_user_type_day,priority:2;index:idx_type_key,priority:1;comment:奖励类型（与积分变动原因一致）" json:"type"`
+	GrantKey      string `gorm:"type:varchar(64);not null;uniqueIndex:uk_user_type_key,priority:3;index:idx_type_key,priority:2;comment:发放键（签到为日期，注册和邀请为被邀请人邮箱摘要）" json:"grant_key"`
+	Day           string `gorm:"type:varchar(10);not null;index:idx_user_type_day,priority:3;comment:发放日期" json:"day"`
+	Points        int    `gorm:"not null;comment:奖励积分" json:"points"`
+	TransactionID uint   `gorm:"not null;comment:积分流水ID" json:"transaction_id"`
+	CreatedAt     int64  `gorm:"autoCreateTime;comment:发放时间" json:"created_at"`
+}
+
+// TableName 指定表名
+func (RewardGrant) TableName() string {
+	return "a_reward_grants"
+}
@@ -0,0 +1,41 @@
+// Package models 定义数据模型
+package models
+
+// Role 管理员角色
+type Role struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	Code        string `gorm:"type:varchar(50);not null;uniqueIndex:idx_code;comment:角色编码" json:"code"`
+	Name        string `gorm:"type:varchar(50);not null;comment:角色名称" json:"name"`
+	Description string `gorm:"type:varchar(255);not null;default:'';comment:角色描述" json:"description"`
+	CreatedAt   int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt   int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (Role) TableName() string {
+	return "a_roles"
+}
+
+// Permission 权限（编码格式为 资源:操作，如 users:points:write）
+type Permission struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	Code      string `gorm:"type:varchar(100);not null;uniqueIndex:idx_code;comment:权限编码" json:"code"`
+	Name      string `gorm:"type:varchar(50);not null;comment:权限名称" json:"name"`
+	CreatedAt int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+}
+
+// TableName 指定表名
+func (Permission) TableName() string {
+	return "a_permissions"
+}
+
+// RolePermission 角色权限关联
+type RolePermission struct {
+	RoleID       uint `gorm:"primaryKey;comment:角色ID" json:"role_id"`
+	PermissionID uint `gorm:"primaryKey;index:idx_permission_id;comment:权限ID" json:"permission_id"`
+}
+
+// TableName 指定表名
+func (RolePermission) TableName() string {
+	return "a_role_permissions"
+}
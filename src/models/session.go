@@ -0,0 +1,14 @@
+// Package models 定义数据模型
+package models
+
+// Session 登录会话（存储于Redis，一次登录对应一个会话，刷新链上的令牌共享会话ID）
+type Session struct {
+	ID         string `json:"id"`
+	UserID     uint   `json:"user_id"`
+	Username   string `json:"username"`
+	Role       string `json:"role"`
+	UserAgent  string `json:"user_agent"`
+	IP         string `json:"ip"`
+	CreatedAt  int64  `json:"created_at"`
+	LastSeenAt int64  `json:"last_seen_at"`
+}
@@ -1,19 +1,46 @@
 // Package models 定义数据模型
 package models
 
+// 用户状态
+const (
+	// UserStatusActive 正常
+	UserStatusActive int8 = 1
+	// UserStatusSuspended 暂停（到期后自动恢复正常）
+	UserStatusSuspended int8 = 2
+	// UserStatusBanned 永久封禁
+	UserStatusBanned int8 = 3
+)
+
 // User 用户模型
 type User struct {
-	ID            uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
-	Username      string `gorm:"type:varchar(100);not null;uniqueIndex:idx_username;comment:用户名" json:"username"`
-	Email         string `gorm:"type:varchar(100);not null;uniqueIndex:idx_email;comment:邮箱" json:"email"`
-	Password      string `gorm:"type:varchar(100);not null;comment:密码" json:"-"`
-	EmailVerified bool   `gorm:"default:false;comment:邮箱是否已验证" json:"email_verified"`
-	Points        *int   `gorm:"default:null;comment:积分" json:"points"`
-	CreatedAt     int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
-	UpdatedAt     int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+	ID             uint    `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	Username       string  `gorm:"type:varchar(100);not null;uniqueIndex:idx_username;comment:用户名" json:"username"`
+	Email          string  `gorm:"type:varchar(100);not null;uniqueIndex:idx_email;comment:邮箱" json:"email"`
+	Password       string  `gorm:"type:varchar(100);not null;comment:密码" json:"-"`
+	EmailVerified  bool    `gorm:"default:false;comment:邮箱是否已验证" json:"email_verified"`
+	Points         *int    `gorm:"default:null;comment:积分" json:"points"`
+	HeldPoints     int     `gorm:"not null;default:0;comment:已预留积分（可用积分=积分-已预留积分）" json:"held_points"`
+	Status         int8    `gorm:"type:tinyint;not null;default:1;index:idx_status;comment:状态：1正常 2暂停 3封禁" json:"status"`
+	StatusReason   string  `gorm:"type:varchar(255);not null;default:'';comment:暂停或封禁原因" json:"status_reason"`
+	SuspendedUntil *int64  `gorm:"default:null;comment:暂停截止时间" json:"suspended_until"`
+	TOTPSecret     string  `gorm:"column:totp_secret;type:varchar(64);not null;default:'';comment:TOTP密钥" json:"-"`
+	TOTPEnabled    bool    `gorm:"column:totp_enabled;default:false;comment:是否启用TOTP两步验证" json:"totp_enabled"`
+	InviteCode     *string `gorm:"type:varchar(16);default:null;uniqueIndex:idx_invite_code;comment:邀请码" json:"invite_code"`
+	InvitedBy      *uint   `gorm:"default:null;index:idx_invited_by;comment:邀请人ID" json:"invited_by"`
+	TokenVersion   int64   `gorm:"not null;default:0;comment:令牌版本（递增即吊销全部令牌）" json:"-"`
+	CreatedAt      int64   `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt      int64   `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
 }
 
 // TableName 指定表名
 func (User) TableName() string {
 	return "a_users"
 }
+
+// CurrentStatus 当前生效的状态（暂停到期后视为正常）
+func (u *User) CurrentStatus(now int64) int8 {
+	if u.Status == UserStatusSuspended && u.SuspendedUntil != nil && *u.SuspendedUntil <= now {
+		return UserStatusActive
+	}
+	return u.Status
+}
@@ -0,0 +1,18 @@
+// Package models 定义数据模型
+package models
+
+// UserIdentity 第三方登录身份（身份提供方的 subject 关联到 a_users 中的用户）
+type UserIdentity struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement;comment:ID" json:"id"`
+	UserID    uint   `gorm:"not null;index:idx_user_id;comment:用户ID" json:"user_id"`
+	Provider  string `gorm:"type:varchar(50);not null;uniqueIndex:idx_provider_subject,priority:1;comment:身份提供方" json:"provider"`
+	Subject   string `gorm:"type:varchar(255);not null;uniqueIndex:idx_provider_subject,priority:2;comment:提供方用户标识（sub）" json:"subject"`
+	Email     string `gorm:"type:varchar(100);not null;default:'';comment:提供方邮箱" json:"email"`
+	CreatedAt int64  `gorm:"autoCreateTime;comment:创建时间" json:"created_at"`
+	UpdatedAt int64  `gorm:"autoUpdateTime;comment:更新时间" json:"updated_at"`
+}
+
+// TableName 指定表名
+func (UserIdentity) TableName() string {
+	return "a_user_identities"
+}
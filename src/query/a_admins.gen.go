@@ -31,6 +31,11 @@ func newAdmin(db *gorm.DB, opts ...gen.DOOption) admin {
 	_admin.ID = field.NewUint(tableName, "id")
 	_admin.Username = field.NewString(tableName, "username")
 	_admin.Password = field.NewString(tableName, "password")
+	_admin.RoleID = field.NewUint(tableName, "role_id")
+	_admin.Status = field.NewInt8(tableName, "status")
+	_admin.TOTPSecret = field.NewString(tableName, "totp_secret")
+	_admin.TOTPEnabled = field.NewBool(tableName, "totp_enabled")
+	_admin.TokenVersion = field.NewInt64(tableName, "token_version")
 	_admin.CreatedAt = field.NewInt64(tableName, "created_at")
 	_admin.UpdatedAt = field.NewInt64(tableName, "updated_at")
 
@@ -42,12 +47,17 @@ func newAdmin(db *gorm.DB, opts ...gen.DOOption) admin {
 type admin struct {
 	adminDo
 
-	ALL       field.Asterisk
-	ID        field.Uint   // ID
-	Username  field.String // 用户名
-	Password  field.String // 密码
-	CreatedAt field.Int64  // 创建时间
-	UpdatedAt field.Int64  // 更新时间
+	ALL          field.Asterisk
+	ID           field.Uint   // ID
+	Username     field.String // 用户名
+	Password     field.String // 密码
+	RoleID       field.Uint   // 角色ID
+	Status       field.Int8   // 状态：1正常 2禁用
+	TOTPSecret   field.String // TOTP密钥
+	TOTPEnabled  field.Bool   // 是否启用TOTP两步验证
+	TokenVersion field.Int64  // 令牌版本（递增即吊销全部令牌）
+	CreatedAt    field.Int64  // 创建时间
+	UpdatedAt    field.Int64  // 更新时间
 
 	fieldMap map[string]field.Expr
 }
@@ -67,6 +77,11 @@ func (a *admin) updateTableName(table string) *admin {
 	a.ID = field.NewUint(table, "id")
 	a.Username = field.NewString(table, "username")
 	a.Password = field.NewString(table, "password")
+	a.RoleID = field.NewUint(table, "role_id")
+	a.Status = field.NewInt8(table, "status")
+	a.TOTPSecret = field.NewString(table, "totp_secret")
+	a.TOTPEnabled = field.NewBool(table, "totp_enabled")
+	a.TokenVersion = field.NewInt64(table, "token_version")
 	a.CreatedAt = field.NewInt64(table, "created_at")
 	a.UpdatedAt = field.NewInt64(table, "updated_at")
 
@@ -85,10 +100,15 @@ func (a *admin) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (a *admin) fillFieldMap() {
-	a.fieldMap = make(map[string]field.Expr, 5)
+	a.fieldMap = make(map[string]field.Expr, 10)
 	a.fieldMap["id"] = a.ID
 	a.fieldMap["username"] = a.Username
 	a.fieldMap["password"] = a.Password
+	a.fieldMap["role_id"] = a.RoleID
+	a.fieldMap["status"] = a.Status
+	a.fieldMap["totp_secret"] = a.TOTPSecret
+	a.fieldMap["totp_enabled"] = a.TOTPEnabled
+	a.fieldMap["token_version"] = a.TokenVersion
 	a.fieldMap["created_at"] = a.CreatedAt
 	a.fieldMap["updated_at"] = a.UpdatedAt
 }
@@ -34,6 +34,15 @@ func newUser(db *gorm.DB, opts ...gen.DOOption) user {
 	_user.Password = field.NewString(tableName, "password")
 	_user.EmailVerified = field.NewBool(tableName, "email_verified")
 	_user.Points = field.NewInt(tableName, "points")
+	_user.HeldPoints = field.NewInt(tableName, "held_points")
+	_user.Status = field.NewInt8(tableName, "status")
+	_user.StatusReason = field.NewString(tableName, "status_reason")
+	_user.SuspendedUntil = field.NewInt64(tableName, "suspended_until")
+	_user.TOTPSecret = field.NewString(tableName, "totp_secret")
+	_user.TOTPEnabled = field.NewBool(tableName, "totp_enabled")
+	_user.InviteCode = field.NewString(tableName, "invite_code")
+	_user.InvitedBy = field.NewUint(tableName, "invited_by")
+	_user.TokenVersion = field.NewInt64(tableName, "token_version")
 	_user.CreatedAt = field.NewInt64(tableName, "created_at")
 	_user.UpdatedAt = field.NewInt64(tableName, "updated_at")
 
@@ -45,15 +54,24 @@ func newUser(db *gorm.DB, opts ...gen.DOOption) user {
 type user struct {
 	userDo
 
-	ALL           field.Asterisk
-	ID            field.Uint   // ID
-	Username      field.String // 用户名
-	Email         field.String // 邮箱
-	Password      field.String // 密码
-	EmailVerified field.Bool   // 邮箱是否已验证
-	Points        field.Int    // 积分
-	CreatedAt     field.Int64  // 创建时间
-	UpdatedAt     field.Int64  // 更新时间
+	ALL            field.Asterisk
+	ID             field.Uint   // ID
+	Username       field.String // 用户名
+	Email          field.String // 邮箱
+	Password       field.String // 密码
+	EmailVerified  field.Bool   // 邮箱是否已验证
+	Points         field.Int    // 积分
+	HeldPoints     field.Int    // 已预留积分（可用积分=积分-已预留积分）
+	Status         field.Int8   // 状态：1正常 2暂停 3封禁
+	StatusReason   field.String // 暂停或封禁原因
+	SuspendedUntil field.Int64  // 暂停截止时间
+	TOTPSecret     field.String // TOTP密钥
+	TOTPEnabled    field.Bool   // 是否启用TOTP两步验证
+	InviteCode     field.String // 邀请码
+	InvitedBy      field.Uint   // 邀请人ID
+	TokenVersion   field.Int64  // 令牌版本（递增即吊销全部令牌）
+	CreatedAt      field.Int64  // 创建时间
+	UpdatedAt      field.Int64  // 更新时间
 
 	fieldMap map[string]field.Expr
 }
@@ -76,6 +94,15 @@ func (u *user) updateTableName(table string) *user {
 	u.Password = field.NewString(table, "password")
 	u.EmailVerified = field.NewBool(table, "email_verified")
 	u.Points = field.NewInt(table, "points")
+	u.HeldPoints = field.NewInt(table, "held_points")
+	u.Status = field.NewInt8(table, "status")
+	u.StatusReason = field.NewString(table, "status_reason")
+	u.SuspendedUntil = field.NewInt64(table, "suspended_until")
+	u.TOTPSecret = field.NewString(table, "totp_secret")
+	u.TOTPEnabled = field.NewBool(table, "totp_enabled")
+	u.InviteCode = field.NewString(table, "invite_code")
+	u.InvitedBy = field.NewUint(table, "invited_by")
+	u.TokenVersion = field.NewInt64(table, "token_version")
 	u.CreatedAt = field.NewInt64(table, "created_at")
 	u.UpdatedAt = field.NewInt64(table, "updated_at")
 
@@ -94,13 +121,22 @@ func (u *user) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (u *user) fillFieldMap() {
-	u.fieldMap = make(map[string]field.Expr, 8)
+	u.fieldMap = make(map[string]field.Expr, 17)
 	u.fieldMap["id"] = u.ID
 	u.fieldMap["username"] = u.Username
 	u.fieldMap["email"] = u.Email
 	u.fieldMap["password"] = u.Password
 	u.fieldMap["email_verified"] = u.EmailVerified
 	u.fieldMap["points"] = u.Points
+	u.fieldMap["held_points"] = u.HeldPoints
+	u.fieldMap["status"] = u.Status
+	u.fieldMap["status_reason"] = u.StatusReason
+	u.fieldMap["suspended_until"] = u.SuspendedUntil
+	u.fieldMap["totp_secret"] = u.TOTPSecret
+	u.fieldMap["totp_enabled"] = u.TOTPEnabled
+	u.fieldMap["invite_code"] = u.InviteCode
+	u.fieldMap["invited_by"] = u.InvitedBy
+	u.fieldMap["token_version"] = u.TokenVersion
 	u.fieldMap["created_at"] = u.CreatedAt
 	u.fieldMap["updated_at"] = u.UpdatedAt
 }
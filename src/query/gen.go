@@ -16,39 +16,134 @@ import (
 )
 
 var (
-	Q     = new(Query)
-	Admin *admin
-	User  *user
+	Q                 = new(Query)
+	APIKey            *aPIKey
+	Admin             *admin
+	AuditLog          *auditLog
+	MFARecoveryCode   *mFARecoveryCode
+	Permission        *permission
+	PointsHold        *pointsHold
+	PointsJournal     *pointsJournal
+	PointsJournalHead *pointsJournalHead
+	PointsLot         *pointsLot
+	PointsOrder       *pointsOrder
+	PointsProduct     *pointsProduct
+	PointsTransaction *pointsTransaction
+	ProxyCharge       *proxyCharge
+	RedeemCampaign    *redeemCampaign
+	RedeemCode        *redeemCode
+	RedeemRedemption  *redeemRedemption
+	RewardGrant       *rewardGrant
+	Role              *role
+	RolePermission    *rolePermission
+	User              *user
+	UserIdentity      *userIdentity
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
+	APIKey = &Q.APIKey
 	Admin = &Q.Admin
+	AuditLog = &Q.AuditLog
+	MFARecoveryCode = &Q.MFARecoveryCode
+	Permission = &Q.Permission
+	PointsHold = &Q.PointsHold
+	PointsJournal = &Q.PointsJournal
+	PointsJournalHead = &Q.PointsJournalHead
+	PointsLot = &Q.PointsLot
+	PointsOrder = &Q.PointsOrder
+	PointsProduct = &Q.PointsProduct
+	PointsTransaction = &Q.PointsTransaction
+	ProxyCharge = &Q.ProxyCharge
+	RedeemCampaign = &Q.RedeemCampaign
+	RedeemCode = &Q.RedeemCode
+	RedeemRedemption = &Q.RedeemRedemption
+	RewardGrant = &Q.RewardGrant
+	Role = &Q.Role
+	RolePermission = &Q.RolePermission
 	User = &Q.User
+	UserIdentity = &Q.UserIdentity
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:    db,
-		Admin: newAdmin(db, opts...),
-		User:  newUser(db, opts...),
+		db:                db,
+		APIKey:            newAPIKey(db, opts...),
+		Admin:             newAdmin(db, opts...),
+		AuditLog:          newAuditLog(db, opts...),
+		MFARecoveryCode:   newMFARecoveryCode(db, opts...),
+		Permission:        newPermission(db, opts...),
+		PointsHold:        newPointsHold(db, opts...),
+		PointsJournal:     newPointsJournal(db, opts...),
+		PointsJournalHead: newPointsJournalHead(db, opts...),
+		PointsLot:         newPointsLot(db, opts...),
+		PointsOrder:       newPointsOrder(db, opts...),
+		PointsProduct:     newPointsProduct(db, opts...),
+		PointsTransaction: newPointsTransaction(db, opts...),
+		ProxyCharge:       newProxyCharge(db, opts...),
+		RedeemCampaign:    newRedeemCampaign(db, opts...),
+		RedeemCode:        newRedeemCode(db, opts...),
+		RedeemRedemption:  newRedeemRedemption(db, opts...),
+		RewardGrant:       newRewardGrant(db, opts...),
+		Role:              newRole(db, opts...),
+		RolePermission:    newRolePermission(db, opts...),
+		User:              newUser(db, opts...),
+		UserIdentity:      newUserIdentity(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	Admin admin
-	User  user
+	APIKey            aPIKey
+	Admin             admin
+	AuditLog          auditLog
+	MFARecoveryCode   mFARecoveryCode
+	Permission        permission
+	PointsHold        pointsHold
+	PointsJournal     pointsJournal
+	PointsJournalHead pointsJournalHead
+	PointsLot         pointsLot
+	PointsOrder       pointsOrder
+	PointsProduct     pointsProduct
+	PointsTransaction pointsTransaction
+	ProxyCharge       proxyCharge
+	RedeemCampaign    redeemCampaign
+	RedeemCode        redeemCode
+	RedeemRedemption  redeemRedemption
+	RewardGrant       rewardGrant
+	Role              role
+	RolePermission    rolePermission
+	User              user
+	UserIdentity      userIdentity
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:    db,
-		Admin: q.Admin.clone(db),
-		User:  q.User.clone(db),
+		db:                db,
+		APIKey:            q.APIKey.clone(db),
+		Admin:             q.Admin.clone(db),
+		AuditLog:          q.AuditLog.clone(db),
+		MFARecoveryCode:   q.MFARecoveryCode.clone(db),
+		Permission:        q.Permission.clone(db),
+		PointsHold:        q.PointsHold.clone(db),
+		PointsJournal:     q.PointsJournal.clone(db),
+		PointsJournalHead: q.PointsJournalHead.clone(db),
+		PointsLot:         q.PointsLot.clone(db),
+		PointsOrder:       q.PointsOrder.clone(db),
+		PointsProduct:     q.PointsProduct.clone(db),
+		PointsTransaction: q.PointsTransaction.clone(db),
+		ProxyCharge:       q.ProxyCharge.clone(db),
+		RedeemCampaign:    q.RedeemCampaign.clone(db),
+		RedeemCode:        q.RedeemCode.clone(db),
+		RedeemRedemption:  q.RedeemRedemption.clone(db),
+		RewardGrant:       q.RewardGrant.clone(db),
+		Role:              q.Role.clone(db),
+		RolePermission:    q.RolePermission.clone(db),
+		User:              q.User.clone(db),
+		UserIdentity:      q.UserIdentity.clone(db),
 	}
 }
 
@@ -62,21 +157,78 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:    db,
-		Admin: q.Admin.replaceDB(db),
-		User:  q.User.replaceDB(db),
+		db:                db,
+		APIKey:            q.APIKey.replaceDB(db),
+		Admin:             q.Admin.replaceDB(db),
+		AuditLog:          q.AuditLog.replaceDB(db),
+		MFARecoveryCode:   q.MFARecoveryCode.replaceDB(db),
+		Permission:        q.Permission.replaceDB(db),
+		PointsHold:        q.PointsHold.replaceDB(db),
+		PointsJournal:     q.PointsJournal.replaceDB(db),
+		PointsJournalHead: q.PointsJournalHead.replaceDB(db),
+		PointsLot:         q.PointsLot.replaceDB(db),
+		PointsOrder:       q.PointsOrder.replaceDB(db),
+		PointsProduct:     q.PointsProduct.replaceDB(db),
+		PointsTransaction: q.PointsTransaction.replaceDB(db),
+		ProxyCharge:       q.ProxyCharge.replaceDB(db),
+		RedeemCampaign:    q.RedeemCampaign.replaceDB(db),
+		RedeemCode:        q.RedeemCode.replaceDB(db),
+		RedeemRedemption:  q.RedeemRedemption.replaceDB(db),
+		RewardGrant:       q.RewardGrant.replaceDB(db),
+		Role:              q.Role.replaceDB(db),
+		RolePermission:    q.RolePermission.replaceDB(db),
+		User:              q.User.replaceDB(db),
+		UserIdentity:      q.UserIdentity.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	Admin IAdminDo
-	User  IUserDo
+	APIKey            IAPIKeyDo
+	Admin             IAdminDo
+	AuditLog          IAuditLogDo
+	MFARecoveryCode   IMFARecoveryCodeDo
+	Permission        IPermissionDo
+	PointsHold        IPointsHoldDo
+	PointsJournal     IPointsJournalDo
+	PointsJournalHead IPointsJournalHeadDo
+	PointsLot         IPointsLotDo
+	PointsOrder       IPointsOrderDo
+	PointsProduct     IPointsProductDo
+	PointsTransaction IPointsTransactionDo
+	ProxyCharge       IProxyChargeDo
+	RedeemCampaign    IRedeemCampaignDo
+	RedeemCode        IRedeemCodeDo
+	RedeemRedemption  IRedeemRedemptionDo
+	RewardGrant       IRewardGrantDo
+	Role              IRoleDo
+	RolePermission    IRolePermissionDo
+	User              IUserDo
+	UserIdentity      IUserIdentityDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		Admin: q.Admin.WithContext(ctx),
-		User:  q.User.WithContext(ctx),
+		APIKey:            q.APIKey.WithContext(ctx),
+		Admin:             q.Admin.WithContext(ctx),
+		AuditLog:          q.AuditLog.WithContext(ctx),
+		MFARecoveryCode:   q.MFARecoveryCode.WithContext(ctx),
+		Permission:        q.Permission.WithContext(ctx),
+		PointsHold:        q.PointsHold.WithContext(ctx),
+		PointsJournal:     q.PointsJournal.WithContext(ctx),
+		PointsJournalHead: q.PointsJournalHead.WithContext(ctx),
+		PointsLot:         q.PointsLot.WithContext(ctx),
+		PointsOrder:       q.PointsOrder.WithContext(ctx),
+		PointsProduct:     q.PointsProduct.WithContext(ctx),
+		PointsTransaction: q.PointsTransaction.WithContext(ctx),
+		ProxyCharge:       q.ProxyCharge.WithContext(ctx),
+		RedeemCampaign:    q.RedeemCampaign.WithContext(ctx),
+		RedeemCode:        q.RedeemCode.WithContext(ctx),
+		RedeemRedemption:  q.RedeemRedemption.WithContext(ctx),
+		RewardGrant:       q.RewardGrant.WithContext(ctx),
+		Role:              q.Role.WithContext(ctx),
+		RolePermission:    q.RolePermission.WithContext(ctx),
+		User:              q.User.WithContext(ctx),
+		UserIdentity:      q.UserIdentity.WithContext(ctx),
 	}
 }
 
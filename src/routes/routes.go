@@ -2,6 +2,7 @@
 package routes
 
 import (
+	"github.com/Company-Automation-1/video-backend-go/src/config"
 	"github.com/Company-Automation-1/video-backend-go/src/controllers"
 	"github.com/Company-Automation-1/video-backend-go/src/middleware"
 	"github.com/Company-Automation-1/video-backend-go/src/services"
@@ -13,54 +14,199 @@ func RegisterRoutes(
 	r *gin.Engine,
 	userService *services.UserService,
 	authService *services.AuthService,
-	pythonURL string,
+	loginGuardService *services.LoginGuardService,
+	mfaService *services.MFAService,
+	oidcService *services.OIDCService,
+	apiKeyService *services.APIKeyService,
+	auditService *services.AuditService,
+	journalService *services.PointsJournalService,
+	ledgerService *services.PointsLedgerService,
+	holdService *services.PointsHoldService,
+	proxyChargeService *services.ProxyChargeService,
+	redeemService *services.RedeemService,
+	rewardService *services.RewardService,
+	paymentService *services.PaymentService,
+	proxyCfg *config.ProxyConfig,
 ) {
+	// 验签公钥（供下游服务验证 Access Token）
+	authController := controllers.NewAuthController(authService)
+	r.GET("/.well-known/jwks.json", authController.JWKS)
+
 	// API v1 路由组
 	v1 := r.Group("/api/v1")
 
 	// 认证路由（无需鉴权）
-	authController := controllers.NewAuthController(authService)
 	auth := v1.Group("/auth")
 	auth.POST("/user/login", middleware.Bind(authController.UserLogin))
 	auth.POST("/admin/login", middleware.Bind(authController.AdminLogin))
+	auth.POST("/mfa/verify", middleware.Bind(authController.VerifyMFA))
+	auth.POST("/mfa/enroll/setup", middleware.Bind(authController.SetupMFAEnrollment))
+	auth.POST("/mfa/enroll/enable", middleware.Bind(authController.EnableMFAEnrollment))
+	auth.POST("/refresh", middleware.Bind(authController.Refresh))
 	auth.POST("/logout", middleware.AuthMiddleware(authService), middleware.Handle(authController.Logout))
 
+	// 第三方登录（OpenID Connect 授权码模式 + PKCE）
+	oidcController := controllers.NewOIDCController(oidcService)
+	auth.GET("/oidc/:provider/authorize", middleware.Handle(oidcController.Authorize))
+	auth.POST("/oidc/:provider/callback", middleware.Bind(oidcController.Callback))
+
 	// 用户路由
-	userController := controllers.NewUserController(userService)
+	userController := controllers.NewUserController(userService, ledgerService)
 	users := v1.Group("/users")
 
 	// 公开路由
 	users.POST("/send-verification-code", middleware.Bind(userController.SendVerificationCode))
 	users.POST("/register", middleware.Bind(userController.Register))
+	users.POST("/password/forgot", middleware.Bind(userController.ForgotPassword))
+	users.POST("/password/reset", middleware.Bind(userController.ResetPassword))
 
 	// 需要本人权限的路由
-	users.GET("/profile", middleware.AuthMiddleware(authService), middleware.Handle(userController.GetProfile))
+	users.GET("/profile", middleware.AuthMiddleware(authService, services.ScopeProfileRead), middleware.Handle(userController.GetProfile))
 	users.PUT("/:id", middleware.SelfMiddleware(authService), middleware.Bind(userController.Update))
 	users.DELETE("/:id", middleware.SelfMiddleware(authService), middleware.Handle(userController.Delete))
 
+	// 积分流水（本人）
+	pointsController := controllers.NewPointsController(ledgerService)
+	users.GET("/points/history", middleware.AuthMiddleware(authService), middleware.Handle(pointsController.History))
+
+	// 兑换码兑换积分
+	redeemController := controllers.NewRedeemController(redeemService)
+	users.POST("/points/redeem", middleware.AuthMiddleware(authService), middleware.Bind(redeemController.Redeem))
+
+	// 每日签到和邀请
+	rewardController := controllers.NewRewardController(rewardService)
+	users.POST("/points/check-in", middleware.AuthMiddleware(authService), middleware.Handle(rewardController.CheckIn))
+	users.GET("/referral", middleware.AuthMiddleware(authService), middleware.Handle(rewardController.Referral))
+
+	// 购买积分套餐
+	paymentController := controllers.NewPaymentController(paymentService)
+	users.GET("/points/products", middleware.AuthMiddleware(authService), middleware.Handle(paymentController.GetProducts))
+	users.POST("/points/orders", middleware.AuthMiddleware(authService), middleware.Bind(paymentController.CreateOrder))
+	users.GET("/points/orders", middleware.AuthMiddleware(authService), middleware.Handle(paymentController.GetOrderList))
+
+	// 登录会话管理（本人）
+	sessionController := controllers.NewSessionController(authService)
+	users.GET("/sessions", middleware.AuthMiddleware(authService), middleware.Handle(sessionController.GetList))
+	users.DELETE("/sessions/:sid", middleware.AuthMiddleware(authService), middleware.Handle(sessionController.Revoke))
+
+	// API Key 管理（本人，仅接受 JWT）
+	apiKeyController := controllers.NewAPIKeyController(apiKeyService)
+	apiKeys := users.Group("/api-keys")
+	apiKeys.Use(middleware.AuthMiddleware(authService))
+	apiKeys.POST("", middleware.Bind(apiKeyController.Create))
+	apiKeys.GET("", middleware.Handle(apiKeyController.GetList))
+	apiKeys.DELETE("/:id", middleware.Handle(apiKeyController.Revoke))
+
+	// 两步验证（本人，可选启用）
+	userMFAController := controllers.NewMFAController(mfaService, services.RoleUser)
+	userMFA := users.Group("/mfa/totp")
+	userMFA.Use(middleware.AuthMiddleware(authService))
+	userMFA.POST("/setup", middleware.Handle(userMFAController.Setup))
+	userMFA.POST("/enable", middleware.Bind(userMFAController.Enable))
+	userMFA.POST("/disable", middleware.Bind(userMFAController.Disable))
+
 	// 管理员路由
 	admin := v1.Group("/admin")
 
+	// 角色权限（管理员接口除本人信息和两步验证外，均需对应权限）
+	rbacService := services.NewRBACService()
+	perm := func(permission string) gin.HandlerFunc {
+		return middleware.RequirePermission(rbacService, permission)
+	}
+
 	// 管理员管理路由（需要管理员认证）
-	adminService := services.NewAdminService()
+	adminService := services.NewAdminService(rbacService, authService, auditService)
 	adminController := controllers.NewAdminController(adminService)
 	admin.GET("/profile", middleware.AdminMiddleware(authService), middleware.Handle(adminController.GetProfile))
+	admin.PUT("/password", middleware.AdminMiddleware(authService), middleware.Bind(adminController.ChangePassword))
+
+	// 管理员两步验证
+	adminMFAController := controllers.NewMFAController(mfaService, services.RoleAdmin)
+	adminMFA := admin.Group("/mfa/totp")
+	adminMFA.Use(middleware.AdminMiddleware(authService))
+	adminMFA.POST("/setup", middleware.Handle(adminMFAController.Setup))
+	adminMFA.POST("/enable", middleware.Bind(adminMFAController.Enable))
+	adminMFA.POST("/disable", middleware.Bind(adminMFAController.Disable))
 	admins := admin.Group("/admins")
 	admins.Use(middleware.AdminMiddleware(authService))
-	admins.GET("", middleware.Handle(adminController.GetList))
-	admins.POST("", middleware.Bind(adminController.Create))
+	admins.GET("", perm(services.PermAdminsRead), middleware.Handle(adminController.GetList))
+	admins.POST("", perm(services.PermAdminsWrite), middleware.Bind(adminController.Create))
+	admins.PUT("/:id", perm(services.PermAdminsWrite), middleware.Bind(adminController.Update))
+	admins.DELETE("/:id", perm(services.PermAdminsWrite), middleware.Handle(adminController.Delete))
+	admins.POST("/:id/disable", perm(services.PermAdminsWrite), middleware.Handle(adminController.Disable))
+	admins.POST("/:id/enable", perm(services.PermAdminsWrite), middleware.Handle(adminController.Enable))
+
+	// 角色列表
+	roleController := controllers.NewRoleController(rbacService)
+	admin.GET("/roles", middleware.AdminMiddleware(authService), perm(services.PermRolesRead),
+		middleware.Handle(roleController.GetList))
 
 	// 管理员用户管理路由（需要管理员认证）
-	adminUserController := controllers.NewAdminUserController(userService)
+	adminUserController := controllers.NewAdminUserController(userService, ledgerService)
 	adminUsers := admin.Group("/users")
 	adminUsers.Use(middleware.AdminMiddleware(authService))
-	adminUsers.GET("", middleware.Handle(adminUserController.GetList))
-	adminUsers.GET("/:id", middleware.Handle(adminUserController.GetOne))
-	adminUsers.PUT("/:id", middleware.Bind(adminUserController.Update))
+	adminUsers.GET("", perm(services.PermUsersRead), middleware.Handle(adminUserController.GetList))
+	adminUsers.GET("/:id", perm(services.PermUsersRead), middleware.Handle(adminUserController.GetOne))
+	adminUsers.PUT("/:id", perm(services.PermUsersPointsWrite), middleware.Bind(adminUserController.Update))
+	adminUsers.PUT("/:id/status", perm(services.PermUsersStatusWrite), middleware.Bind(adminUserController.UpdateStatus))
+
+	// 登录锁定管理路由（需要管理员认证）
+	adminLockoutController := controllers.NewAdminLockoutController(loginGuardService)
+	adminLockouts := admin.Group("/login-lockouts")
+	adminLockouts.Use(middleware.AdminMiddleware(authService))
+	adminLockouts.GET("", perm(services.PermLoginLockoutsRead), middleware.Handle(adminLockoutController.GetList))
+	adminLockouts.DELETE("", perm(services.PermLoginLockoutsWrite), middleware.Handle(adminLockoutController.Clear))
+
+	// 审计日志（只读）
+	auditLogController := controllers.NewAuditLogController(auditService)
+	admin.GET("/audit-logs", middleware.AdminMiddleware(authService), perm(services.PermAuditLogsRead),
+		middleware.Handle(auditLogController.GetList))
+
+	// 积分流水（全部用户）
+	admin.GET("/points/transactions", middleware.AdminMiddleware(authService), perm(services.PermPointsRead),
+		middleware.Handle(pointsController.GetList))
+
+	// 兑换码活动
+	redeemCampaigns := admin.Group("/redeem-campaigns")
+	redeemCampaigns.Use(middleware.AdminMiddleware(authService))
+	redeemCampaigns.GET("", perm(services.PermRedeemRead), middleware.Handle(redeemController.GetCampaignList))
+	redeemCampaigns.POST("", perm(services.PermRedeemWrite), middleware.Bind(redeemController.CreateCampaign))
+	redeemCampaigns.GET("/:id/codes/export", perm(services.PermRedeemRead), middleware.Handle(redeemController.ExportCodes))
+
+	// 积分套餐
+	pointsProducts := admin.Group("/points-products")
+	pointsProducts.Use(middleware.AdminMiddleware(authService))
+	pointsProducts.GET("", perm(services.PermProductsRead), middleware.Handle(paymentController.GetProductList))
+	pointsProducts.POST("", perm(services.PermProductsWrite), middleware.Bind(paymentController.CreateProduct))
+	pointsProducts.PUT("/:id", perm(services.PermProductsWrite), middleware.Bind(paymentController.UpdateProduct))
+
+	// 积分订单
+	pointsOrders := admin.Group("/points-orders")
+	pointsOrders.Use(middleware.AdminMiddleware(authService))
+	pointsOrders.GET("", perm(services.PermOrdersRead), middleware.Handle(paymentController.GetAdminOrderList))
+	pointsOrders.POST("/:id/refund", perm(services.PermOrdersRefund), middleware.Bind(paymentController.Refund))
+
+	// 积分变更日志检查点
+	journalController := controllers.NewPointsJournalController(journalService)
+	admin.GET("/points-journal/checkpoint", middleware.AdminMiddleware(authService), perm(services.PermPointsJournalRead),
+		middleware.Handle(journalController.Checkpoint))
+
+	// 内部回调（Python 服务异步任务完成后结算积分预留）
+	holdController := controllers.NewPointsHoldController(holdService)
+	internal := v1.Group("/internal", middleware.InternalMiddleware(proxyCfg.CallbackToken))
+	internal.POST("/points/holds/:id/settle", middleware.Bind(holdController.Settle))
+
+	// 支付渠道回调（按请求头 X-Payment-Signature 验签）和本地模拟支付（仅 payment.provider 为 fake 时注册）
+	payments := v1.Group("/payments")
+	payments.POST("/webhook", middleware.Handle(paymentController.Webhook))
+	if paymentService.FakeCheckoutEnabled() {
+		payments.POST("/fake/checkout/:order_no", middleware.AuthMiddleware(authService),
+			middleware.Handle(paymentController.FakeCheckout))
+	}
 
-	// Python服务透传（部分接口需要认证）
+	// Python服务透传（按 proxy.routes 规则鉴权和计费）
 	apiPy := r.Group("/api/py")
-	apiPy.Any("/*path", middleware.PythonProxy(pythonURL, userService, authService))
+	apiPy.Any("/*path", middleware.PythonProxy(proxyCfg, proxyChargeService, authService))
 
 	// 健康检查
 	r.GET("/health", func(c *gin.Context) {
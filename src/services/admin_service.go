@@ -2,6 +2,8 @@
 package services
 
 import (
+	"context"
+	"slices"
 	"strings"
 
 	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
@@ -10,16 +12,25 @@ import (
 	"github.com/Company-Automation-1/video-backend-go/src/tools"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gen"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // AdminService 管理员服务
 type AdminService struct {
+	rbac  *RBACService
+	auth  *AuthService
+	audit *AuditService
 }
 
 // NewAdminService 创建管理员服务
-func NewAdminService() *AdminService {
-	return &AdminService{}
+func NewAdminService(rbac *RBACService, auth *AuthService, audit *AuditService) *AdminService {
+	return &AdminService{
+		rbac:  rbac,
+		auth:  auth,
+		audit: audit,
+	}
 }
 
 // GetListWithPagination 获取管理员列表（分页）
@@ -71,12 +82,16 @@ func (s *AdminService) GetOne(conditions ...gen.Condition) (*models.Admin, error
 	return admin, err
 }
 
-// Create 创建管理员
-func (s *AdminService) Create(username, password string) (*models.Admin, error) {
+// Create 创建管理员（必须指定角色）
+func (s *AdminService) Create(ctx context.Context, username, password string, roleID uint) (*models.Admin, error) {
 	if err := s.validateUsername(username, nil); err != nil {
 		return nil, err
 	}
 
+	if err := s.rbac.ValidateRole(roleID); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := s.encryptPassword(password)
 	if err != nil {
 		return nil, err
@@ -85,15 +100,237 @@ func (s *AdminService) Create(username, password string) (*models.Admin, error)
 	admin := &models.Admin{
 		Username: username,
 		Password: hashedPassword,
+		RoleID:   &roleID,
+		Status:   models.AdminStatusActive,
 	}
 
 	if err := query.Admin.Create(admin); err != nil {
 		return nil, tools.ErrInternalServer("管理员创建失败")
 	}
+	s.audit.Record(ctx, AuditActionAdminCreate, AuditTargetAdmin, admin.ID, nil, adminAuditFields(admin))
 
 	return admin, nil
 }
 
+// Update 更新管理员（用户名、密码、角色，nil 表示不修改）
+// 修改密码后该管理员的全部令牌失效；不能将最后一个超级管理员降级
+func (s *AdminService) Update(
+	ctx context.Context,
+	id uint,
+	username, password *string,
+	roleID *uint,
+) (*models.Admin, error) {
+	existing, err := s.GetOne(query.Admin.ID.Eq(id))
+	if err != nil {
+		return nil, err
+	}
+
+	var assigns []field.AssignExpr
+	if username != nil {
+		if err := s.validateUsername(*username, &id); err != nil {
+			return nil, err
+		}
+		assigns = append(assigns, query.Admin.Username.Value(*username))
+	}
+	if password != nil {
+		hashedPassword, err := s.encryptPassword(*password)
+		if err != nil {
+			return nil, err
+		}
+		assigns = append(assigns, query.Admin.Password.Value(hashedPassword))
+	}
+	if roleID != nil {
+		if err := s.rbac.ValidateRole(*roleID); err != nil {
+			return nil, err
+		}
+		assigns = append(assigns, query.Admin.RoleID.Value(*roleID))
+	}
+	if len(assigns) == 0 {
+		return s.GetOne(query.Admin.ID.Eq(id))
+	}
+
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if roleID != nil {
+			demoted, err := s.isDemotion(tx, *roleID)
+			if err != nil {
+				return err
+			}
+			if demoted {
+				if err := s.ensureNotLastSuperAdmin(tx, id); err != nil {
+					return err
+				}
+			}
+		}
+		_, err := tx.Admin.Where(tx.Admin.ID.Eq(id)).UpdateSimple(assigns...)
+		return err
+	})
+	if err != nil {
+		return nil, wrapAdminError(err, "管理员更新失败")
+	}
+
+	before := adminAuditFields(existing)
+	after := adminAuditFields(existing)
+	if username != nil {
+		after["username"] = *username
+	}
+	if roleID != nil {
+		after["role_id"] = roleID
+	}
+	before["password_changed"], after["password_changed"] = false, password != nil
+	s.audit.Record(ctx, AuditActionAdminUpdate, AuditTargetAdmin, id, before, after)
+
+	if password != nil {
+		if err := s.auth.RevokeAllTokens(ctx, RoleAdmin, id); err != nil {
+			return nil, tools.ErrInternalServer("登录状态清理失败")
+		}
+	}
+	return s.GetOne(query.Admin.ID.Eq(id))
+}
+
+// Delete 删除管理员（不能删除自己和最后一个超级管理员），同时吊销其全部令牌
+func (s *AdminService) Delete(ctx context.Context, operatorID, id uint) error {
+	if operatorID == id {
+		return tools.ErrBadRequest("不能删除自己")
+	}
+
+	var existing *models.Admin
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		admin, err := tx.Admin.Where(tx.Admin.ID.Eq(id)).First()
+		if err != nil {
+			return err
+		}
+		existing = admin
+		if err := s.ensureNotLastSuperAdmin(tx, id); err != nil {
+			return err
+		}
+		if _, err := tx.Admin.Where(tx.Admin.ID.Eq(id)).Delete(); err != nil {
+			return err
+		}
+		_, err = tx.MFARecoveryCode.
+			Where(tx.MFARecoveryCode.Role.Eq(RoleAdmin), tx.MFARecoveryCode.AccountID.Eq(id)).
+			Delete()
+		return err
+	})
+	if err != nil {
+		return wrapAdminError(err, "管理员删除失败")
+	}
+	s.audit.Record(ctx, AuditActionAdminDelete, AuditTargetAdmin, id, adminAuditFields(existing), nil)
+	return s.auth.RevokeAllTokens(ctx, RoleAdmin, id)
+}
+
+// SetStatus 启用或禁用管理员（不能禁用自己和最后一个超级管理员），禁用后其全部令牌立即失效
+func (s *AdminService) SetStatus(ctx context.Context, operatorID, id uint, status int8) (*models.Admin, error) {
+	if operatorID == id && status == models.AdminStatusDisabled {
+		return nil, tools.ErrBadRequest("不能禁用自己")
+	}
+
+	var previous int8
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		admin, err := tx.Admin.Where(tx.Admin.ID.Eq(id)).First()
+		if err != nil {
+			return err
+		}
+		previous = admin.Status
+		if status == models.AdminStatusDisabled {
+			if err := s.ensureNotLastSuperAdmin(tx, id); err != nil {
+				return err
+			}
+		}
+		_, err = tx.Admin.Where(tx.Admin.ID.Eq(id)).UpdateSimple(tx.Admin.Status.Value(status))
+		return err
+	})
+	if err != nil {
+		return nil, wrapAdminError(err, "管理员状态更新失败")
+	}
+	s.audit.Record(ctx, AuditActionAdminStatusUpdate, AuditTargetAdmin, id,
+		AuditFields{"status": previous}, AuditFields{"status": status})
+
+	if status == models.AdminStatusDisabled {
+		if err := s.auth.RevokeAllTokens(ctx, RoleAdmin, id); err != nil {
+			return nil, tools.ErrInternalServer("登录状态清理失败")
+		}
+	}
+	return s.GetOne(query.Admin.ID.Eq(id))
+}
+
+// ChangePassword 管理员修改自己的密码（需验证原密码），修改后全部令牌失效需重新登录
+func (s *AdminService) ChangePassword(ctx context.Context, id uint, oldPassword, newPassword string) error {
+	admin, err := s.GetOne(query.Admin.ID.Eq(id))
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(admin.Password), []byte(oldPassword)); err != nil {
+		return tools.ErrBadRequest("原密码错误")
+	}
+
+	hashedPassword, err := s.encryptPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	if _, err := query.Admin.Where(query.Admin.ID.Eq(id)).
+		UpdateSimple(query.Admin.Password.Value(hashedPassword)); err != nil {
+		return tools.ErrInternalServer("密码修改失败")
+	}
+	s.audit.Record(ctx, AuditActionAdminPasswordChange, AuditTargetAdmin, id, nil, AuditFields{"username": admin.Username})
+	return s.auth.RevokeAllTokens(ctx, RoleAdmin, id)
+}
+
+// isDemotion 新角色是否不是超级管理员
+func (s *AdminService) isDemotion(tx *query.Query, roleID uint) (bool, error) {
+	role, err := tx.Role.Where(tx.Role.ID.Eq(roleID)).First()
+	if err != nil {
+		return false, err
+	}
+	return role.Code != RoleCodeSuperAdmin, nil
+}
+
+// ensureNotLastSuperAdmin 确保目标管理员不是最后一个有效的超级管理员
+// 在事务中锁定全部有效超级管理员，防止并发操作同时移除最后两个超级管理员
+func (s *AdminService) ensureNotLastSuperAdmin(tx *query.Query, id uint) error {
+	superRole, err := tx.Role.Where(tx.Role.Code.Eq(RoleCodeSuperAdmin)).First()
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	superAdmins, err := tx.Admin.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(tx.Admin.RoleID.Eq(superRole.ID), tx.Admin.Status.Eq(models.AdminStatusActive)).
+		Find()
+	if err != nil {
+		return err
+	}
+	isSuperAdmin := slices.ContainsFunc(superAdmins, func(admin *models.Admin) bool {
+		return admin.ID == id
+	})
+	if isSuperAdmin && len(superAdmins) <= 1 {
+		return tools.ErrForbidden("不能移除最后一个超级管理员")
+	}
+	return nil
+}
+
+// adminAuditFields 管理员审计字段快照
+func adminAuditFields(admin *models.Admin) AuditFields {
+	return AuditFields{
+		"username": admin.Username,
+		"role_id":  admin.RoleID,
+		"status":   admin.Status,
+	}
+}
+
+// wrapAdminError 转换事务中的错误
+func wrapAdminError(err error, message string) error {
+	if err == gorm.ErrRecordNotFound {
+		return tools.ErrNotFound("管理员不存在")
+	}
+	if appErr, ok := err.(*tools.AppError); ok {
+		return appErr
+	}
+	return tools.ErrInternalServer(message)
+}
+
 // validateUsername 校验用户名唯一性
 func (s *AdminService) validateUsername(username string, excludeID *uint) error {
 	if username == "" {
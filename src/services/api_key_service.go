@@ -0,0 +1,206 @@
+// Package services API Key 服务
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"gorm.io/gorm"
+)
+
+const (
+	// apiKeyMarker API Key 固定前缀，便于在日志和代码仓库中识别泄露的 Key
+	apiKeyMarker = "vbk_"
+	// apiKeyPrefixBytes API Key 前缀的随机字节数（十六进制后 16 位，与 prefix 列长度一致）
+	apiKeyPrefixBytes = 8
+	// apiKeyMaxPerUser 每个用户最多持有的有效 API Key 数量
+	apiKeyMaxPerUser = 20
+	// apiKeyTouchInterval 最近使用时间的最小更新间隔，避免每个请求都写数据库
+	apiKeyTouchInterval = time.Minute
+)
+
+const (
+	// ScopeProfileRead 读取个人信息
+	ScopeProfileRead = "profile:read"
+	// ScopeProcessImage 调用图片处理接口（扣除积分）
+	ScopeProcessImage = "py:process_image"
+	// ScopeProcessVideo 调用视频处理接口（扣除积分）
+	ScopeProcessVideo = "py:process_video"
+)
+
+// APIKeyScopes 全部可授予的 API Key 授权范围
+var APIKeyScopes = []string{ScopeProfileRead, ScopeProcessImage, ScopeProcessVideo} //nolint:gochecknoglobals // 只读列表
+
+// APIKeyService API Key 服务
+type APIKeyService struct {
+	audit *AuditService
+}
+
+// NewAPIKeyService 创建 API Key 服务
+func NewAPIKeyService(audit *AuditService) *APIKeyService {
+	return &APIKeyService{
+		audit: audit,
+	}
+}
+
+// Create 创建 API Key，返回记录和 Key 明文（明文仅此一次返回，服务端只保存摘要）
+func (s *APIKeyService) Create(
+	ctx context.Context,
+	userID uint,
+	name string,
+	scopes []string,
+	expiresAt *int64,
+) (*models.APIKey, string, error) {
+	for _, scope := range scopes {
+		if !slices.Contains(APIKeyScopes, scope) {
+			return nil, "", tools.ErrBadRequest("不支持的授权范围: " + scope)
+		}
+	}
+	now := time.Now().Unix()
+	if expiresAt != nil && *expiresAt <= now {
+		return nil, "", tools.ErrBadRequest("过期时间必须晚于当前时间")
+	}
+
+	count, err := query.APIKey.
+		Where(query.APIKey.UserID.Eq(userID), query.APIKey.RevokedAt.IsNull()).
+		Where(query.APIKey.Where(query.APIKey.ExpiresAt.IsNull()).Or(query.APIKey.ExpiresAt.Gt(now))).
+		Count()
+	if err != nil {
+		return nil, "", tools.ErrInternalServer("API Key创建失败")
+	}
+	if count >= apiKeyMaxPerUser {
+		return nil, "", tools.ErrConflict("API Key数量已达上限，请先吊销不再使用的Key")
+	}
+
+	prefix, secret, err := generateAPIKey()
+	if err != nil {
+		return nil, "", tools.ErrInternalServer("API Key创建失败")
+	}
+	apiKey := &models.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hashToken(secret),
+		Scopes:    strings.Join(slices.Compact(slices.Sorted(slices.Values(scopes))), ","),
+		ExpiresAt: expiresAt,
+	}
+	if err := query.APIKey.Create(apiKey); err != nil {
+		return nil, "", tools.ErrInternalServer("API Key创建失败")
+	}
+	s.audit.Record(ctx, AuditActionAPIKeyCreate, AuditTargetAPIKey, apiKey.ID, nil, AuditFields{
+		"user_id":    userID,
+		"name":       name,
+		"prefix":     prefix,
+		"scopes":     apiKey.Scopes,
+		"expires_at": expiresAt,
+	})
+	return apiKey, apiKeyMarker + prefix + "_" + secret, nil
+}
+
+// List 获取用户的全部 API Key（含已吊销和已过期的，按创建时间倒序）
+func (s *APIKeyService) List(userID uint) ([]*models.APIKey, error) {
+	keys, err := query.APIKey.
+		Where(query.APIKey.UserID.Eq(userID)).
+		Order(query.APIKey.ID.Desc()).
+		Find()
+	if err != nil {
+		return nil, tools.ErrInternalServer("API Key查询失败")
+	}
+	return keys, nil
+}
+
+// Revoke 吊销用户的 API Key（只能吊销属于该用户的 Key）
+func (s *APIKeyService) Revoke(ctx context.Context, userID, id uint) error {
+	now := time.Now().Unix()
+	info, err := query.APIKey.
+		Where(query.APIKey.ID.Eq(id), query.APIKey.UserID.Eq(userID), query.APIKey.RevokedAt.IsNull()).
+		Update(query.APIKey.RevokedAt, now)
+	if err != nil {
+		return tools.ErrInternalServer("API Key吊销失败")
+	}
+	if info.RowsAffected == 0 {
+		return tools.ErrNotFound("API Key不存在或已吊销")
+	}
+	s.audit.Record(ctx, AuditActionAPIKeyRevoke, AuditTargetAPIKey, id,
+		AuditFields{"revoked_at": nil}, AuditFields{"revoked_at": now})
+	return nil
+}
+
+// authenticate 校验 API Key 明文，返回 Key 记录和所属用户
+func (s *APIKeyService) authenticate(rawKey string) (*models.APIKey, *models.User, error) {
+	rest, ok := strings.CutPrefix(rawKey, apiKeyMarker)
+	if !ok {
+		return nil, nil, tools.ErrUnauthorized("API Key无效")
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || prefix == "" || secret == "" {
+		return nil, nil, tools.ErrUnauthorized("API Key无效")
+	}
+
+	apiKey, err := query.APIKey.Where(query.APIKey.Prefix.Eq(prefix)).First()
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil, tools.ErrUnauthorized("API Key无效")
+	}
+	if err != nil {
+		return nil, nil, tools.ErrInternalServer("API Key校验失败")
+	}
+	if !hmac.Equal([]byte(apiKey.KeyHash), []byte(hashToken(secret))) {
+		return nil, nil, tools.ErrUnauthorized("API Key无效")
+	}
+
+	now := time.Now().Unix()
+	if apiKey.RevokedAt != nil {
+		return nil, nil, tools.ErrUnauthorized("API Key已吊销")
+	}
+	if apiKey.ExpiresAt != nil && *apiKey.ExpiresAt <= now {
+		return nil, nil, tools.ErrUnauthorized("API Key已过期")
+	}
+
+	user, err := query.User.Where(query.User.ID.Eq(apiKey.UserID)).First()
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil, tools.ErrUnauthorized("API Key无效")
+	}
+	if err != nil {
+		return nil, nil, tools.ErrInternalServer("API Key校验失败")
+	}
+
+	// 记录最近使用时间（按间隔节流）
+	if apiKey.LastUsedAt == nil || now-*apiKey.LastUsedAt >= int64(apiKeyTouchInterval/time.Second) {
+		//nolint:errcheck // 使用时间更新失败不影响请求
+		_, _ = query.APIKey.Where(query.APIKey.ID.Eq(apiKey.ID)).UpdateSimple(query.APIKey.LastUsedAt.Value(now))
+	}
+	return apiKey, user, nil
+}
+
+// apiKeyHasScope API Key 是否具有任一授权范围
+func apiKeyHasScope(apiKey *models.APIKey, scopes []string) bool {
+	granted := strings.Split(apiKey.Scopes, ",")
+	for _, scope := range scopes {
+		if slices.Contains(granted, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateAPIKey 生成 API Key 前缀（8字节十六进制）和密钥部分（32字节十六进制）
+// 前缀有唯一索引且创建时不重试，取 64 位随机数使碰撞概率可以忽略
+func generateAPIKey() (prefix, secret string, err error) {
+	var b [apiKeyPrefixBytes]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", "", err
+	}
+	secret, err = randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(b[:]), secret, nil
+}
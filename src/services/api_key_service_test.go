@@ -0,0 +1,27 @@
+package services
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// TestGenerateAPIKey 前缀为 8 字节十六进制（与 prefix 列长度一致），多次生成不重复
+func TestGenerateAPIKey(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 1000 {
+		prefix, secret, err := generateAPIKey()
+		if err != nil {
+			t.Fatalf("generateAPIKey: %v", err)
+		}
+		if b, err := hex.DecodeString(prefix); err != nil || len(b) != apiKeyPrefixBytes || len(prefix) > 16 {
+			t.Fatalf("prefix = %q, want %d 字节十六进制", prefix, apiKeyPrefixBytes)
+		}
+		if secret == "" {
+			t.Fatal("secret 为空")
+		}
+		if seen[prefix] {
+			t.Fatalf("prefix %q 重复", prefix)
+		}
+		seen[prefix] = true
+	}
+}
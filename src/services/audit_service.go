@@ -0,0 +1,213 @@
+// Package services 审计日志服务
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+// 审计操作
+const (
+	// AuditActionUserUpdate 用户修改个人信息（用户名、邮箱、密码）
+	AuditActionUserUpdate = "user.update"
+	// AuditActionUserDelete 用户注销账号
+	AuditActionUserDelete = "user.delete"
+	// AuditActionUserPasswordReset 用户通过邮箱验证码重置密码
+	AuditActionUserPasswordReset = "user.password.reset"
+	// AuditActionUserPointsUpdate 管理员调整用户积分
+	AuditActionUserPointsUpdate = "user.points.update"
+	// AuditActionUserStatusUpdate 管理员暂停/封禁/恢复用户
+	AuditActionUserStatusUpdate = "user.status.update"
+	// AuditActionUserIdentityLink 第三方身份关联到用户
+	AuditActionUserIdentityLink = "user.identity.link"
+	// AuditActionAdminCreate 创建管理员
+	AuditActionAdminCreate = "admin.create"
+	// AuditActionAdminUpdate 更新管理员
+	AuditActionAdminUpdate = "admin.update"
+	// AuditActionAdminDelete 删除管理员
+	AuditActionAdminDelete = "admin.delete"
+	// AuditActionAdminStatusUpdate 启用/禁用管理员
+	AuditActionAdminStatusUpdate = "admin.status.update"
+	// AuditActionAdminPasswordChange 管理员修改自己的密码
+	AuditActionAdminPasswordChange = "admin.password.change"
+	// AuditActionMFAEnable 启用两步验证
+	AuditActionMFAEnable = "mfa.enable"
+	// AuditActionMFADisable 关闭两步验证
+	AuditActionMFADisable = "mfa.disable"
+	// AuditActionAPIKeyCreate 创建 API Key
+	AuditActionAPIKeyCreate = "api_key.create"
+	// AuditActionAPIKeyRevoke 吊销 API Key
+	AuditActionAPIKeyRevoke = "api_key.revoke"
+	// AuditActionSessionRevoke 注销登录会话
+	AuditActionSessionRevoke = "session.revoke"
+	// AuditActionLoginLockoutClear 解除登录锁定
+	AuditActionLoginLockoutClear = "login_lockout.clear"
+	// AuditActionRedeemCampaignCreate 创建兑换码活动
+	AuditActionRedeemCampaignCreate = "redeem_campaign.create"
+	// AuditActionRedeemCampaignExport 导出兑换码
+	AuditActionRedeemCampaignExport = "redeem_campaign.export"
+	// AuditActionPointsProductCreate 创建积分套餐
+	AuditActionPointsProductCreate = "points_product.create"
+	// AuditActionPointsProductUpdate 修改积分套餐（含上架/下架）
+	AuditActionPointsProductUpdate = "points_product.update"
+	// AuditActionPointsOrderRefund 积分订单退款
+	AuditActionPointsOrderRefund = "points_order.refund"
+)
+
+// 审计对象类型
+const (
+	// AuditTargetUser 用户
+	AuditTargetUser = "user"
+	// AuditTargetAdmin 管理员
+	AuditTargetAdmin = "admin"
+	// AuditTargetAPIKey API Key
+	AuditTargetAPIKey = "api_key"
+	// AuditTargetSession 登录会话
+	AuditTargetSession = "session"
+	// AuditTargetLoginLockout 登录锁定（ID 为 角色:维度:值）
+	AuditTargetLoginLockout = "login_lockout"
+	// AuditTargetRedeemCampaign 兑换码活动
+	AuditTargetRedeemCampaign = "redeem_campaign"
+	// AuditTargetPointsProduct 积分套餐
+	AuditTargetPointsProduct = "points_product"
+	// AuditTargetPointsOrder 积分订单
+	AuditTargetPointsOrder = "points_order"
+)
+
+// AuditFields 审计记录中的字段快照（只记录业务字段，不记录密码等敏感值）
+type AuditFields map[string]interface{}
+
+// AuditActor 审计操作者和请求信息（由中间件写入请求 context）
+type AuditActor struct {
+	ID        uint   // 操作者ID（未登录时为 0）
+	Role      string // 操作者角色（user/admin，未登录时为空）
+	IP        string // 请求IP
+	RequestID string // 请求ID
+}
+
+// auditActorKey context 中保存 AuditActor 的 key
+type auditActorKey struct{}
+
+// WithAuditActor 将审计操作者写入 context
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFromContext 从 context 读取审计操作者（不存在时返回零值）
+func AuditActorFromContext(ctx context.Context) AuditActor {
+	actor, _ := ctx.Value(auditActorKey{}).(AuditActor) //nolint:errcheck // 不存在时返回零值
+	return actor
+}
+
+// AuditService 审计日志服务（只提供写入和查询，不提供修改和删除）
+type AuditService struct{}
+
+// NewAuditService 创建审计日志服务
+func NewAuditService() *AuditService {
+	return &AuditService{}
+}
+
+// Record 记录审计事件（操作者、IP、请求ID 从 context 读取）
+// before/after 只保留发生变化的字段；创建时 before 为 nil，删除时 after 为 nil
+// 写入失败只记录日志，不影响已完成的业务操作
+func (s *AuditService) Record(
+	ctx context.Context,
+	action, targetType string,
+	targetID interface{},
+	before, after AuditFields,
+) {
+	before, after = auditDiff(before, after)
+	actor := AuditActorFromContext(ctx)
+	entry := &models.AuditLog{
+		ActorID:    actor.ID,
+		ActorRole:  actor.Role,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   auditTargetID(targetID),
+		Before:     auditJSON(before),
+		After:      auditJSON(after),
+		IP:         actor.IP,
+		RequestID:  actor.RequestID,
+	}
+	if err := query.AuditLog.Create(entry); err != nil {
+		tools.Logf("审计日志写入失败 action=%s target=%s:%s request_id=%s: %v",
+			action, targetType, entry.TargetID, actor.RequestID, err)
+	}
+}
+
+// List 查询审计日志（条件查询、范围查询，按时间倒序）
+func (s *AuditService) List(queryReq *dto.AuditLogListQueryRequest) ([]*models.AuditLog, int64, error) {
+	a := query.AuditLog
+	conditions := tools.NewConditionBuilder().
+		EqUint(&a.ActorID, queryReq.ActorID).
+		EqString(&a.ActorRole, queryReq.ActorRole).
+		EqString(&a.Action, queryReq.Action).
+		EqString(&a.TargetType, queryReq.TargetType).
+		EqString(&a.TargetID, queryReq.TargetID).
+		EqString(&a.RequestID, queryReq.RequestID).
+		GteInt64(&a.CreatedAt, queryReq.CreatedAtMin).
+		LteInt64(&a.CreatedAt, queryReq.CreatedAtMax).
+		Build()
+
+	logs, count, err := a.Where(conditions...).
+		Order(a.ID.Desc()).
+		FindByPage(queryReq.GetOffset(), queryReq.GetLimit())
+	if err != nil {
+		return nil, 0, tools.ErrInternalServer("审计日志查询失败")
+	}
+	return logs, count, nil
+}
+
+// auditDiff 只保留变更前后不同的字段
+func auditDiff(before, after AuditFields) (AuditFields, AuditFields) {
+	if before == nil || after == nil {
+		return before, after
+	}
+	changedBefore := AuditFields{}
+	changedAfter := AuditFields{}
+	for key, value := range after {
+		if old, ok := before[key]; !ok || !reflect.DeepEqual(old, value) {
+			changedBefore[key] = before[key]
+			changedAfter[key] = value
+		}
+	}
+	for key, value := range before {
+		if _, ok := after[key]; !ok {
+			changedBefore[key] = value
+		}
+	}
+	return changedBefore, changedAfter
+}
+
+// auditJSON 序列化字段快照（nil 时返回 nil）
+func auditJSON(fields AuditFields) *string {
+	if fields == nil {
+		return nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil
+	}
+	value := string(data)
+	return &value
+}
+
+// auditTargetID 统一转换操作对象ID
+func auditTargetID(id interface{}) string {
+	switch v := id.(type) {
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case string:
+		return v
+	default:
+		data, _ := json.Marshal(v) //nolint:errcheck // 仅用于展示
+		return string(data)
+	}
+}
@@ -0,0 +1,28 @@
+// Package services API Key 认证
+package services
+
+import (
+	"strings"
+
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+// VerifyAPIKey 校验 API Key 并检查授权范围（需具有 scopes 中任一范围），返回所属用户的 Claims
+// API Key 不关联登录会话，返回的 Claims 中 jti 为空
+func (s *AuthService) VerifyAPIKey(rawKey string, scopes []string) (*Claims, error) {
+	if len(scopes) == 0 {
+		return nil, tools.ErrForbidden("该接口不支持使用API Key访问")
+	}
+	apiKey, user, err := s.apiKeys.authenticate(rawKey)
+	if err != nil {
+		return nil, err
+	}
+	if !apiKeyHasScope(apiKey, scopes) {
+		return nil, tools.ErrForbidden("API Key缺少授权范围: " + strings.Join(scopes, " 或 "))
+	}
+	return &Claims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     RoleUser,
+	}, nil
+}
@@ -0,0 +1,196 @@
+// Package services 两步验证登录
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+const (
+	// mfaChallengePrefix 两步验证挑战 key 前缀（key 中使用挑战令牌的 SHA-256 摘要）
+	mfaChallengePrefix = "mfa_challenge:"
+	// mfaAttemptsPrefix 挑战令牌口令尝试次数 key 前缀（独立计数，INCR 保证并发下不丢失）
+	mfaAttemptsPrefix = "mfa_challenge_attempts:"
+	// mfaChallengeExpire 挑战令牌有效期
+	mfaChallengeExpire = 5 * time.Minute
+	// mfaChallengeMaxAttempts 单个挑战令牌允许的口令错误次数
+	mfaChallengeMaxAttempts = 5
+)
+
+// mfaChallenge Redis 中保存的两步验证挑战
+type mfaChallenge struct {
+	UserID   uint   `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	Enroll   bool   `json:"enroll"` // 强制两步验证但账号尚未启用：需先绑定 TOTP 才能完成登录
+}
+
+// VerifyMFA 校验两步验证口令（TOTP 或恢复码），通过后签发令牌对
+func (s *AuthService) VerifyMFA(ctx context.Context, mfaToken, code string, client ClientInfo) (*TokenPair, error) {
+	hash, challenge, err := s.loadMFAChallenge(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.Enroll {
+		return nil, tools.ErrForbidden("请先绑定两步验证")
+	}
+
+	attempts, err := s.recordMFAAttempt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mfa.Verify(ctx, challenge.Role, challenge.UserID, code); err != nil {
+		if tools.GetCode(err) >= 500 {
+			return nil, err
+		}
+		return nil, s.mfaFailed(ctx, hash, challenge, client.IP, attempts, err)
+	}
+
+	s.deleteMFAChallenge(ctx, hash)
+	return s.completeLogin(ctx, challenge.UserID, challenge.Username, challenge.Role, client)
+}
+
+// SetupMFAEnrollment 登录时强制绑定两步验证：生成待启用的 TOTP 密钥
+func (s *AuthService) SetupMFAEnrollment(ctx context.Context, mfaToken string) (secret, uri string, err error) {
+	_, challenge, err := s.loadEnrollChallenge(ctx, mfaToken)
+	if err != nil {
+		return "", "", err
+	}
+	return s.mfa.Setup(ctx, challenge.Role, challenge.UserID, challenge.Username)
+}
+
+// EnableMFAEnrollment 登录时强制绑定两步验证：校验口令并启用，返回恢复码和令牌对
+func (s *AuthService) EnableMFAEnrollment(
+	ctx context.Context,
+	mfaToken, code string,
+	client ClientInfo,
+) ([]string, *TokenPair, error) {
+	hash, challenge, err := s.loadEnrollChallenge(ctx, mfaToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	attempts, err := s.recordMFAAttempt(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 未登录请求：以挑战令牌对应的账号作为操作者
+	actor := AuditActorFromContext(ctx)
+	actor.ID, actor.Role = challenge.UserID, challenge.Role
+	codes, err := s.mfa.Enable(WithAuditActor(ctx, actor), challenge.Role, challenge.UserID, code)
+	if err != nil {
+		if tools.GetCode(err) >= 500 {
+			return nil, nil, err
+		}
+		return nil, nil, s.mfaFailed(ctx, hash, challenge, client.IP, attempts, err)
+	}
+
+	s.deleteMFAChallenge(ctx, hash)
+	tokens, err := s.completeLogin(ctx, challenge.UserID, challenge.Username, challenge.Role, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return codes, tokens, nil
+}
+
+// createMFAChallenge 创建两步验证挑战
+func (s *AuthService) createMFAChallenge(ctx context.Context, challenge *mfaChallenge) (*LoginResult, error) {
+	mfaToken, err := randomToken()
+	if err != nil {
+		return nil, tools.ErrInternalServer("登录失败")
+	}
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return nil, tools.ErrInternalServer("登录失败")
+	}
+	if err := s.redis.Set(ctx, mfaChallengePrefix+hashToken(mfaToken), string(data), mfaChallengeExpire); err != nil {
+		return nil, tools.ErrInternalServer("登录失败")
+	}
+	return &LoginResult{
+		MFAToken:          mfaToken,
+		MFAExpiresIn:      time.Now().Add(mfaChallengeExpire).Unix(),
+		MFAEnrollRequired: challenge.Enroll,
+	}, nil
+}
+
+// loadMFAChallenge 读取挑战令牌并重新检查账号状态，返回令牌摘要和挑战内容
+func (s *AuthService) loadMFAChallenge(ctx context.Context, mfaToken string) (string, *mfaChallenge, error) {
+	hash := hashToken(mfaToken)
+	data, err := s.redis.Get(ctx, mfaChallengePrefix+hash)
+	if err != nil {
+		return "", nil, tools.ErrUnauthorized("两步验证已过期，请重新登录")
+	}
+	var challenge mfaChallenge
+	if err := json.Unmarshal([]byte(data), &challenge); err != nil {
+		return "", nil, tools.ErrUnauthorized("两步验证已过期，请重新登录")
+	}
+	// 密码校验通过后账号可能已被禁用、暂停或封禁：重新检查状态，不可用时作废挑战令牌
+	if err := s.CheckAccountStatus(challenge.Role, challenge.UserID); err != nil {
+		if tools.GetCode(err) < 500 {
+			s.deleteMFAChallenge(ctx, hash)
+		}
+		return "", nil, err
+	}
+	return hash, &challenge, nil
+}
+
+// loadEnrollChallenge 读取待绑定两步验证的挑战令牌
+func (s *AuthService) loadEnrollChallenge(ctx context.Context, mfaToken string) (string, *mfaChallenge, error) {
+	hash, challenge, err := s.loadMFAChallenge(ctx, mfaToken)
+	if err != nil {
+		return "", nil, err
+	}
+	if !challenge.Enroll {
+		return "", nil, tools.ErrBadRequest("账号已启用两步验证，请直接验证")
+	}
+	return hash, challenge, nil
+}
+
+// recordMFAAttempt 校验口令前先计入一次尝试（INCR 原子计数），超过允许次数时作废挑战令牌
+func (s *AuthService) recordMFAAttempt(ctx context.Context, hash string) (int64, error) {
+	key := mfaAttemptsPrefix + hash
+	attempts, err := s.redis.Incr(ctx, key)
+	if err != nil {
+		return 0, tools.ErrInternalServer("两步验证失败")
+	}
+	if attempts == 1 {
+		//nolint:errcheck // 设置过期失败时计数随挑战令牌作废一起删除
+		_ = s.redis.Expire(ctx, key, mfaChallengeExpire)
+	}
+	if attempts > mfaChallengeMaxAttempts {
+		s.deleteMFAChallenge(ctx, hash)
+		return 0, tools.ErrUnauthorized("两步验证错误次数过多，请重新登录")
+	}
+	return attempts, nil
+}
+
+// mfaFailed 记录一次口令错误：计入登录失败次数，用尽挑战允许次数后作废挑战令牌
+func (s *AuthService) mfaFailed(
+	ctx context.Context,
+	hash string,
+	challenge *mfaChallenge,
+	ip string,
+	attempts int64,
+	verifyErr error,
+) error {
+	if err := s.loginGuard.RecordFailure(ctx, challenge.Role, challenge.Username, ip); err != nil {
+		s.deleteMFAChallenge(ctx, hash)
+		return err
+	}
+	if attempts >= mfaChallengeMaxAttempts {
+		s.deleteMFAChallenge(ctx, hash)
+		return tools.ErrUnauthorized("两步验证错误次数过多，请重新登录")
+	}
+	return verifyErr
+}
+
+// deleteMFAChallenge 作废挑战令牌及其尝试计数（挑战令牌一次性使用）
+func (s *AuthService) deleteMFAChallenge(ctx context.Context, hash string) {
+	//nolint:errcheck // 删除失败时挑战令牌会自然过期
+	_ = s.redis.Del(ctx, mfaChallengePrefix+hash)
+	//nolint:errcheck // 删除失败时计数会自然过期
+	_ = s.redis.Del(ctx, mfaAttemptsPrefix+hash)
+}
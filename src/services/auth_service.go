@@ -3,132 +3,378 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/Company-Automation-1/video-backend-go/src/config"
 	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
 	"github.com/Company-Automation-1/video-backend-go/src/query"
 	"github.com/Company-Automation-1/video-backend-go/src/tools"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+const (
+	// blacklistTokenPrefix Access Token 黑名单 key 前缀（blacklist_token:<jti>）
+	blacklistTokenPrefix = "blacklist_token:"
+	// refreshTokenPrefix Refresh Token 信息 key 前缀（key 中使用 token 的 SHA-256 摘要）
+	refreshTokenPrefix = "refresh_token:"
+	// refreshUsedPrefix 已轮换（已使用）的 Refresh Token 标记 key 前缀，值为所属会话ID
+	refreshUsedPrefix = "refresh_used:"
+	// tokenVersionPrefix 账号令牌版本缓存 key 前缀（token_version:<role>:<id>），版本以用户/管理员表中的为准
+	tokenVersionPrefix = "token_version:"
+)
+
+// tokenVersionCacheTTL 账号令牌版本在 Redis 中的缓存时间
+const tokenVersionCacheTTL = 24 * time.Hour
+
+const (
+	// RoleUser 普通用户角色
+	RoleUser = "user"
+	// RoleAdmin 管理员角色
+	RoleAdmin = "admin"
+)
+
+// TokenTypeAccess 访问令牌的 typ（积分日志检查点等同一密钥签发的其他令牌使用不同的 typ）
+const TokenTypeAccess = "access"
+
 // AuthService 认证服务
 type AuthService struct {
-	jwtConfig *config.JWTConfig
-	redis     *infrastructure.Redis
+	jwtConfig  *config.JWTConfig
+	signer     *JWTSigner
+	redis      *infrastructure.Redis
+	loginGuard *LoginGuardService
+	mfa        *MFAService
+	apiKeys    *APIKeyService
+	audit      *AuditService
 }
 
 // NewAuthService 创建认证服务
-func NewAuthService(jwtConfig *config.JWTConfig, redis *infrastructure.Redis) *AuthService {
+func NewAuthService(
+	jwtConfig *config.JWTConfig,
+	signer *JWTSigner,
+	redis *infrastructure.Redis,
+	loginGuard *LoginGuardService,
+	mfa *MFAService,
+	apiKeys *APIKeyService,
+	audit *AuditService,
+) *AuthService {
 	return &AuthService{
-		jwtConfig: jwtConfig,
-		redis:     redis,
+		jwtConfig:  jwtConfig,
+		signer:     signer,
+		redis:      redis,
+		loginGuard: loginGuard,
+		mfa:        mfa,
+		apiKeys:    apiKeys,
+		audit:      audit,
 	}
 }
 
-// Claims JWT Claims
+// Claims JWT Claims（RegisteredClaims.ID 即 jti，取值为会话ID）
 type Claims struct {
-	UserID   uint   `json:"user_id"`
-	Username string `json:"username"`
-	Role     string `json:"role"` // "user" 或 "admin"
+	Type         string `json:"typ"` // 令牌类型，访问令牌为 access
+	UserID       uint   `json:"user_id"`
+	Username     string `json:"username"`
+	Role         string `json:"role"` // "user" 或 "admin"
+	TokenVersion int64  `json:"ver"`  // 签发时的账号令牌版本
 	jwt.RegisteredClaims
 }
 
+// ClientInfo 客户端信息（用于记录登录会话）
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// TokenPair 登录或刷新后签发的令牌对
+type TokenPair struct {
+	AccessToken      string
+	ExpiresIn        int64 // Access Token 过期时间戳
+	RefreshToken     string
+	RefreshExpiresIn int64 // Refresh Token 过期时间戳
+}
+
+// LoginResult 登录结果：未启用两步验证时直接返回令牌对，否则返回两步验证挑战令牌
+type LoginResult struct {
+	Tokens            *TokenPair
+	MFAToken          string // 两步验证挑战令牌（需通过 VerifyMFA 换取令牌对）
+	MFAExpiresIn      int64  // 挑战令牌过期时间戳
+	MFAEnrollRequired bool   // 强制两步验证但账号尚未启用：需通过 EnableMFAEnrollment 绑定后换取令牌对
+}
+
+// refreshRecord Redis 中保存的 Refresh Token 信息
+type refreshRecord struct {
+	SessionID    string `json:"session_id"`
+	TokenVersion int64  `json:"token_version"`
+}
+
 // loginInfo 登录信息
 type loginInfo struct {
-	ID       uint
-	Username string
-	Password string
+	ID          uint
+	Username    string
+	Password    string
+	TOTPEnabled bool
+	StatusErr   error // 账号状态不允许登录时的错误（禁用、暂停、封禁）
 }
 
 // _login 公共登录逻辑
 func (s *AuthService) _login(
+	ctx context.Context,
 	username, password string,
+	client ClientInfo,
 	getLoginInfo func(string) (*loginInfo, error),
 	role string,
-) (accessToken string, expiresIn int64, err error) {
+) (*LoginResult, error) {
+	// 用户名或IP处于锁定中时直接拒绝，不再执行密码校验
+	if err := s.loginGuard.Check(ctx, role, username, client.IP); err != nil {
+		return nil, err
+	}
+
 	// 查询登录信息
 	info, err := getLoginInfo(username)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return "", 0, tools.ErrBadRequest("用户名或密码错误")
+			return nil, s.loginFailed(ctx, role, username, client.IP)
 		}
-		return "", 0, tools.ErrInternalServer("登录失败")
+		return nil, tools.ErrInternalServer("登录失败")
 	}
 
 	// 验证密码
 	if err = bcrypt.CompareHashAndPassword([]byte(info.Password), []byte(password)); err != nil {
-		return "", 0, tools.ErrBadRequest("用户名或密码错误")
+		return nil, s.loginFailed(ctx, role, username, client.IP)
+	}
+
+	// 密码校验通过后再检查账号状态，避免通过错误信息探测账号
+	if info.StatusErr != nil {
+		return nil, info.StatusErr
+	}
+
+	return s.loginAuthenticated(ctx, info, role, client)
+}
+
+// loginAuthenticated 第一步认证（密码或第三方登录）通过后：
+// 已启用两步验证时只返回挑战令牌，通过口令校验后才签发令牌
+func (s *AuthService) loginAuthenticated(
+	ctx context.Context,
+	info *loginInfo,
+	role string,
+	client ClientInfo,
+) (*LoginResult, error) {
+	if info.TOTPEnabled || s.mfa.Required(role) {
+		return s.createMFAChallenge(ctx, &mfaChallenge{
+			UserID:   info.ID,
+			Username: info.Username,
+			Role:     role,
+			Enroll:   !info.TOTPEnabled,
+		})
+	}
+
+	tokens, err := s.completeLogin(ctx, info.ID, info.Username, role, client)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{Tokens: tokens}, nil
+}
+
+// completeLogin 登录校验全部通过后：清除失败计数、开启新会话并签发令牌对
+func (s *AuthService) completeLogin(
+	ctx context.Context,
+	id uint, username, role string,
+	client ClientInfo,
+) (*TokenPair, error) {
+	s.loginGuard.RecordSuccess(ctx, role, username)
+
+	version, err := s.tokenVersion(ctx, role, id)
+	if err != nil {
+		return nil, tools.ErrInternalServer("登录失败")
 	}
 
-	// 生成Token
-	accessToken, expiresIn, err = s.generateToken(info.ID, info.Username, role)
+	// 每次登录开启一个新会话
+	session, err := s.createSession(ctx, id, username, role, client)
 	if err != nil {
-		return "", 0, tools.ErrInternalServer("Token生成失败")
+		return nil, tools.ErrInternalServer("登录失败")
 	}
 
-	return accessToken, expiresIn, nil
+	return s.issueTokenPair(ctx, session, version)
+}
+
+// loginFailed 记录登录失败，达到阈值时返回锁定错误
+func (s *AuthService) loginFailed(ctx context.Context, role, username, ip string) error {
+	if err := s.loginGuard.RecordFailure(ctx, role, username, ip); err != nil {
+		return err
+	}
+	return tools.ErrBadRequest("用户名或密码错误")
 }
 
 // UserLogin 用户登录
 func (s *AuthService) UserLogin(
 	ctx context.Context,
 	username, password string,
-) (accessToken string, expiresIn int64, err error) {
-	return s._login(username, password, func(u string) (*loginInfo, error) {
+	client ClientInfo,
+) (*LoginResult, error) {
+	return s._login(ctx, username, password, client, func(u string) (*loginInfo, error) {
 		user, err := query.User.Where(query.User.Username.Eq(u)).First()
 		if err != nil {
 			return nil, err
 		}
 		return &loginInfo{
-			ID:       user.ID,
-			Username: user.Username,
-			Password: user.Password,
+			ID:          user.ID,
+			Username:    user.Username,
+			Password:    user.Password,
+			TOTPEnabled: user.TOTPEnabled,
+			StatusErr:   userStatusError(user),
 		}, nil
-	}, "user")
+	}, RoleUser)
 }
 
 // AdminLogin 管理员登录
 func (s *AuthService) AdminLogin(
 	ctx context.Context,
 	username, password string,
-) (accessToken string, expiresIn int64, err error) {
-	return s._login(username, password, func(u string) (*loginInfo, error) {
+	client ClientInfo,
+) (*LoginResult, error) {
+	return s._login(ctx, username, password, client, func(u string) (*loginInfo, error) {
 		admin, err := query.Admin.Where(query.Admin.Username.Eq(u)).First()
 		if err != nil {
 			return nil, err
 		}
 		return &loginInfo{
-			ID:       admin.ID,
-			Username: admin.Username,
-			Password: admin.Password,
+			ID:          admin.ID,
+			Username:    admin.Username,
+			Password:    admin.Password,
+			TOTPEnabled: admin.TOTPEnabled,
+			StatusErr:   adminStatusError(admin),
 		}, nil
-	}, "admin")
+	}, RoleAdmin)
+}
+
+// Refresh 使用 Refresh Token 换取新的令牌对（轮换：旧 Refresh Token 立即失效）
+// 已轮换过的 Refresh Token 再次出现视为泄露，所属会话随之吊销
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string, client ClientInfo) (*TokenPair, error) {
+	digest := hashToken(refreshToken)
+
+	data, err := s.redis.Get(ctx, refreshTokenPrefix+digest)
+	if err != nil {
+		// token 不存在：若曾被轮换过则属于重放，吊销所属会话
+		if sessionID, usedErr := s.redis.Get(ctx, refreshUsedPrefix+digest); usedErr == nil {
+			s.revokeSessionByID(ctx, sessionID)
+			return nil, tools.ErrUnauthorized("Refresh Token已被使用，请重新登录")
+		}
+		return nil, tools.ErrUnauthorized("Refresh Token无效或已过期")
+	}
+
+	var record refreshRecord
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return nil, tools.ErrUnauthorized("Refresh Token无效或已过期")
+	}
+
+	// 原子地打上"已使用"标记，抢不到标记说明并发重放，同样吊销会话
+	firstUse, err := s.redis.SetNX(ctx, refreshUsedPrefix+digest, record.SessionID, s.refreshTTL())
+	if err != nil {
+		return nil, tools.ErrInternalServer("Token刷新失败")
+	}
+	if !firstUse {
+		s.revokeSessionByID(ctx, record.SessionID)
+		return nil, tools.ErrUnauthorized("Refresh Token已被使用，请重新登录")
+	}
+	//nolint:errcheck // 删除失败不影响轮换，已使用标记已生效
+	_ = s.redis.Del(ctx, refreshTokenPrefix+digest)
+
+	// 会话已被吊销（登出、用户主动下线或检测到重放）
+	session, err := s.getSession(ctx, record.SessionID)
+	if err != nil {
+		return nil, tools.ErrUnauthorized("登录已失效，请重新登录")
+	}
+
+	// 账号令牌版本已变更（修改密码、删除账号等），会话随之失效
+	version, err := s.tokenVersion(ctx, session.Role, session.UserID)
+	if err != nil {
+		return nil, tools.ErrInternalServer("Token刷新失败")
+	}
+	if version != record.TokenVersion {
+		s.revokeSession(ctx, session)
+		return nil, tools.ErrUnauthorized("登录已失效，请重新登录")
+	}
+
+	session.IP = client.IP
+	session.UserAgent = client.UserAgent
+	session.LastSeenAt = time.Now().Unix()
+	return s.issueTokenPair(ctx, session, version)
+}
+
+// issueTokenPair 签发 Access Token 与 Refresh Token，并续期会话
+func (s *AuthService) issueTokenPair(ctx context.Context, session *models.Session, version int64) (*TokenPair, error) {
+	accessToken, expiresIn, err := s.generateToken(session, version)
+	if err != nil {
+		return nil, tools.ErrInternalServer("Token生成失败")
+	}
+
+	refreshToken, err := randomToken()
+	if err != nil {
+		return nil, tools.ErrInternalServer("Token生成失败")
+	}
+	data, err := json.Marshal(&refreshRecord{SessionID: session.ID, TokenVersion: version})
+	if err != nil {
+		return nil, tools.ErrInternalServer("Token生成失败")
+	}
+
+	ttl := s.refreshTTL()
+	if err := s.saveSession(ctx, session); err != nil {
+		return nil, tools.ErrInternalServer("Token生成失败")
+	}
+	if err := s.redis.Set(ctx, refreshTokenPrefix+hashToken(refreshToken), string(data), ttl); err != nil {
+		return nil, tools.ErrInternalServer("Token生成失败")
+	}
+
+	return &TokenPair{
+		AccessToken:      accessToken,
+		ExpiresIn:        expiresIn,
+		RefreshToken:     refreshToken,
+		RefreshExpiresIn: time.Now().Add(ttl).Unix(),
+	}, nil
+}
+
+// refreshTTL Refresh Token 有效期
+func (s *AuthService) refreshTTL() time.Duration {
+	return time.Duration(s.jwtConfig.RefreshExpireTime) * time.Hour
+}
+
+// accessTTL Access Token 有效期
+func (s *AuthService) accessTTL() time.Duration {
+	return time.Duration(s.jwtConfig.AccessExpireTime) * time.Minute
 }
 
-// generateToken 生成Access Token
+// generateToken 生成Access Token（jti 为会话ID）
 func (s *AuthService) generateToken(
-	userID uint, username, role string,
+	session *models.Session, version int64,
 ) (tokenString string, expiresIn int64, err error) {
 	now := time.Now()
-	expiresIn = now.Add(time.Duration(s.jwtConfig.ExpireTime) * time.Hour).Unix()
+	expiresIn = now.Add(s.accessTTL()).Unix()
 
 	claims := &Claims{
-		UserID:   userID,
-		Username: username,
-		Role:     role,
+		Type:         TokenTypeAccess,
+		UserID:       session.UserID,
+		Username:     session.Username,
+		Role:         session.Role,
+		TokenVersion: version,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        session.ID,
 			ExpiresAt: jwt.NewNumericDate(time.Unix(expiresIn, 0)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err = token.SignedString([]byte(s.jwtConfig.Secret))
+	tokenString, err = s.signer.Sign(claims)
 	if err != nil {
 		return "", 0, err
 	}
@@ -138,46 +384,229 @@ func (s *AuthService) generateToken(
 
 // VerifyToken 验证Token并返回Claims
 func (s *AuthService) VerifyToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("无效的签名方法")
-		}
-		return []byte(s.jwtConfig.Secret), nil
-	})
-
+	// 访问令牌必须带 exp；同一密钥签发的其他令牌（如积分日志检查点）按 typ 拒绝
+	token, err := s.signer.Parse(tokenString, &Claims{}, jwt.WithExpirationRequired())
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+	claims, ok := token.Claims.(*Claims)
+	// typ 为空的是升级前签发的访问令牌，在 access_expire_time 内自然过期
+	if ok && token.Valid && (claims.Type == TokenTypeAccess || claims.Type == "") {
 		return claims, nil
 	}
 
 	return nil, errors.New("无效的Token")
 }
 
-// Logout 用户登出（将token加入黑名单）
+// Logout 用户登出（吊销当前会话：会话 jti 加入黑名单，刷新链随之失效）
 func (s *AuthService) Logout(ctx context.Context, tokenString string) error {
 	claims, err := s.VerifyToken(tokenString)
 	if err != nil {
 		return nil // token无效，无需处理
 	}
 
-	// 将token加入黑名单（存储到Redis，有效期与token过期时间一致）
-	blacklistKey := "blacklist_token:" + tokenString
-	expiresAt := claims.ExpiresAt.Time
-	ttl := time.Until(expiresAt)
-	if ttl > 0 {
-		//nolint:errcheck // Redis设置失败不影响登出流程，token会在过期时间后自然失效
-		_ = s.redis.Set(ctx, blacklistKey, "1", ttl)
+	if claims.ID == "" {
+		return nil
+	}
+	s.revokeSessionByID(ctx, claims.ID)
+	return nil
+}
+
+// JWKS 获取验签公钥集合
+func (s *AuthService) JWKS() *JWKSet {
+	return s.signer.JWKS()
+}
+
+// CheckAccountStatus 检查账号当前是否可用（账号不存在、已被禁用、暂停或封禁时返回错误）
+func (s *AuthService) CheckAccountStatus(role string, id uint) error {
+	if role == RoleAdmin {
+		admin, err := query.Admin.Select(query.Admin.Status).Where(query.Admin.ID.Eq(id)).First()
+		if err == gorm.ErrRecordNotFound {
+			return tools.ErrUnauthorized("账号不存在")
+		}
+		if err != nil {
+			return tools.ErrInternalServer("账号状态查询失败")
+		}
+		return adminStatusError(admin)
+	}
+
+	user, err := query.User.
+		Select(query.User.Status, query.User.StatusReason, query.User.SuspendedUntil).
+		Where(query.User.ID.Eq(id)).
+		First()
+	if err == gorm.ErrRecordNotFound {
+		return tools.ErrUnauthorized("账号不存在")
+	}
+	if err != nil {
+		return tools.ErrInternalServer("账号状态查询失败")
 	}
+	return userStatusError(user)
+}
 
+// adminStatusError 管理员账号状态错误（正常时返回 nil）
+func adminStatusError(admin *models.Admin) error {
+	if admin.Status == models.AdminStatusDisabled {
+		return tools.ErrForbidden("账号已被禁用")
+	}
 	return nil
 }
 
-// IsTokenBlacklisted 检查token是否在黑名单中
-func (s *AuthService) IsTokenBlacklisted(ctx context.Context, tokenString string) bool {
-	blacklistKey := "blacklist_token:" + tokenString
+// userStatusError 用户账号状态错误（正常时返回 nil），错误信息中包含暂停或封禁原因
+func userStatusError(user *models.User) error {
+	var message string
+	switch user.CurrentStatus(time.Now().Unix()) {
+	case models.UserStatusSuspended:
+		message = "账号已被暂停使用"
+		if user.SuspendedUntil != nil {
+			message = "账号已被暂停使用至 " + time.Unix(*user.SuspendedUntil, 0).Format(time.DateTime)
+		}
+	case models.UserStatusBanned:
+		message = "账号已被封禁"
+	default:
+		return nil
+	}
+	if user.StatusReason != "" {
+		message += "，原因：" + user.StatusReason
+	}
+	return tools.ErrForbidden(message)
+}
+
+// IsTokenBlacklisted 检查token（按 jti）是否在黑名单中（Redis 不可用时视为已拉黑）
+func (s *AuthService) IsTokenBlacklisted(ctx context.Context, jti string) bool {
+	blacklistKey := blacklistTokenPrefix + jti
 	_, err := s.redis.Get(ctx, blacklistKey)
-	return err == nil
+	if err != nil && err != redis.Nil {
+		tools.Logf("读取令牌黑名单失败 jti=%s: %v", jti, err)
+	}
+	return err != redis.Nil
+}
+
+// RevokeAllTokens 吊销账号的全部令牌（所有设备下线）
+// 递增账号令牌版本，此前签发的 Access Token 与 Refresh Token 全部失效，并清除该账号的会话记录。
+// 版本保存在用户/管理员表中，Redis 只作缓存：缓存更新失败时删除缓存，两者都失败时返回错误
+func (s *AuthService) RevokeAllTokens(ctx context.Context, role string, id uint) error {
+	// 兼容此前只保存在 Redis 中的版本：新版本不小于缓存中的版本
+	cached, err := s.cachedTokenVersion(ctx, role, id)
+	if err != nil && err != redis.Nil {
+		cached = 0
+	}
+	version, err := incrTokenVersion(role, id, cached)
+	key := tokenVersionKey(role, id)
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		// 账号已删除：清除缓存，其令牌在校验时按账号不存在失效
+		if err := s.redis.Del(ctx, key); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if err := s.redis.Set(ctx, key, strconv.FormatInt(version, 10), tokenVersionCacheTTL); err != nil {
+			if err := s.redis.Del(ctx, key); err != nil {
+				return err
+			}
+		}
+	}
+	s.clearSessions(ctx, role, id)
+	return nil
+}
+
+// IsTokenRevoked 检查token是否已因账号令牌版本变更而失效（版本读取失败或账号不存在时视为已失效）
+func (s *AuthService) IsTokenRevoked(ctx context.Context, claims *Claims) bool {
+	version, err := s.tokenVersion(ctx, claims.Role, claims.UserID)
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			tools.Logf("读取令牌版本失败 role=%s id=%d: %v", claims.Role, claims.UserID, err)
+		}
+		return true
+	}
+	return claims.TokenVersion < version
+}
+
+// tokenVersion 获取账号当前令牌版本：优先读取 Redis 缓存，未命中或 Redis 不可用时读取数据库
+func (s *AuthService) tokenVersion(ctx context.Context, role string, id uint) (int64, error) {
+	version, err := s.cachedTokenVersion(ctx, role, id)
+	if err == nil {
+		return version, nil
+	}
+	cacheMiss := err == redis.Nil
+
+	version, err = loadTokenVersion(role, id)
+	if err != nil {
+		return 0, err
+	}
+	if cacheMiss {
+		// 使用 SetNX 回填，避免覆盖并发吊销写入的新版本
+		//nolint:errcheck // 回填失败下次继续读取数据库
+		_, _ = s.redis.SetNX(ctx, tokenVersionKey(role, id), strconv.FormatInt(version, 10), tokenVersionCacheTTL)
+	}
+	return version, nil
+}
+
+// cachedTokenVersion 读取 Redis 中缓存的令牌版本（未缓存时返回 redis.Nil）
+func (s *AuthService) cachedTokenVersion(ctx context.Context, role string, id uint) (int64, error) {
+	value, err := s.redis.Get(ctx, tokenVersionKey(role, id))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// loadTokenVersion 从数据库读取账号令牌版本（账号不存在时返回 gorm.ErrRecordNotFound）
+func loadTokenVersion(role string, id uint) (int64, error) {
+	switch role {
+	case RoleUser:
+		user, err := query.User.Select(query.User.TokenVersion).Where(query.User.ID.Eq(id)).First()
+		if err != nil {
+			return 0, err
+		}
+		return user.TokenVersion, nil
+	case RoleAdmin:
+		admin, err := query.Admin.Select(query.Admin.TokenVersion).Where(query.Admin.ID.Eq(id)).First()
+		if err != nil {
+			return 0, err
+		}
+		return admin.TokenVersion, nil
+	default:
+		return 0, fmt.Errorf("未知角色: %s", role)
+	}
+}
+
+// incrTokenVersion 递增数据库中的账号令牌版本（新版本至少为 floor+1），返回新版本（账号不存在时返回 gorm.ErrRecordNotFound）
+func incrTokenVersion(role string, id uint, floor int64) (int64, error) {
+	var err error
+	expr := gorm.Expr("GREATEST(token_version, ?) + 1", floor)
+	switch role {
+	case RoleUser:
+		_, err = query.User.Where(query.User.ID.Eq(id)).UpdateColumn(query.User.TokenVersion, expr)
+	case RoleAdmin:
+		_, err = query.Admin.Where(query.Admin.ID.Eq(id)).UpdateColumn(query.Admin.TokenVersion, expr)
+	default:
+		err = fmt.Errorf("未知角色: %s", role)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return loadTokenVersion(role, id)
+}
+
+// tokenVersionKey 账号令牌版本 key
+func tokenVersionKey(role string, id uint) string {
+	return tokenVersionPrefix + role + ":" + strconv.FormatUint(uint64(id), 10)
+}
+
+// randomToken 生成随机令牌（32字节，十六进制编码）
+func randomToken() (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// hashToken 计算令牌的 SHA-256 摘要（Redis 中不保存令牌原文）
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+// TestRevokeAllTokensPersisted 令牌版本保存在数据库中：Redis 缓存丢失后已吊销的令牌仍然失效，账号删除后令牌全部失效
+func TestRevokeAllTokensPersisted(t *testing.T) {
+	setupTestDB(t)
+	redis := setupTestRedis(t)
+	auth := newTestAuthService(t, loadTestConfig(t), redis)
+	user := createTestUser(t, nil, "revoke@example.com", 0)
+	ctx := context.Background()
+	key := tokenVersionKey(RoleUser, user.ID)
+	if err := redis.Del(ctx, key); err != nil { // 清除之前测试运行留下的缓存
+		t.Fatalf("清除缓存失败: %v", err)
+	}
+
+	old := &Claims{Role: RoleUser, UserID: user.ID, TokenVersion: 0}
+	if auth.IsTokenRevoked(ctx, old) {
+		t.Fatal("吊销前 IsTokenRevoked = true, want false")
+	}
+	if err := auth.RevokeAllTokens(ctx, RoleUser, user.ID); err != nil {
+		t.Fatalf("RevokeAllTokens: %v", err)
+	}
+	if version := reloadTestUser(t, user.ID).TokenVersion; version != 1 {
+		t.Fatalf("token_version = %d, want 1", version)
+	}
+
+	// Redis 数据丢失：从数据库读取版本
+	if err := redis.Del(ctx, key); err != nil {
+		t.Fatalf("清除缓存失败: %v", err)
+	}
+	if !auth.IsTokenRevoked(ctx, old) {
+		t.Fatal("缓存丢失后旧令牌 IsTokenRevoked = false, want true")
+	}
+	current := &Claims{Role: RoleUser, UserID: user.ID, TokenVersion: 1}
+	if auth.IsTokenRevoked(ctx, current) {
+		t.Fatal("新令牌 IsTokenRevoked = true, want false")
+	}
+
+	// 此前只保存在 Redis 中的版本：新版本不小于缓存中的版本
+	if err := redis.Set(ctx, key, "5", tokenVersionCacheTTL); err != nil {
+		t.Fatalf("写入缓存失败: %v", err)
+	}
+	if err := auth.RevokeAllTokens(ctx, RoleUser, user.ID); err != nil {
+		t.Fatalf("RevokeAllTokens: %v", err)
+	}
+	if version := reloadTestUser(t, user.ID).TokenVersion; version != 6 {
+		t.Fatalf("token_version = %d, want 6", version)
+	}
+
+	// 账号删除后令牌全部失效
+	if _, err := query.User.Where(query.User.ID.Eq(user.ID)).Delete(); err != nil {
+		t.Fatalf("删除用户失败: %v", err)
+	}
+	if err := auth.RevokeAllTokens(ctx, RoleUser, user.ID); err != nil {
+		t.Fatalf("删除后 RevokeAllTokens: %v", err)
+	}
+	latest := &Claims{Role: RoleUser, UserID: user.ID, TokenVersion: 6}
+	if !auth.IsTokenRevoked(ctx, latest) {
+		t.Fatal("账号删除后 IsTokenRevoked = false, want true")
+	}
+}
+
+// TestVerifyMFARechecksStatus 密码校验通过后账号被封禁：两步验证返回 403 并作废挑战令牌
+func TestVerifyMFARechecksStatus(t *testing.T) {
+	setupTestDB(t)
+	redis := setupTestRedis(t)
+	auth := newTestAuthService(t, loadTestConfig(t), redis)
+	user := createTestUser(t, nil, "mfa-status@example.com", 0)
+	ctx := context.Background()
+
+	challenge := &mfaChallenge{UserID: user.ID, Username: user.Username, Role: RoleUser}
+	result, err := auth.createMFAChallenge(ctx, challenge)
+	if err != nil {
+		t.Fatalf("createMFAChallenge: %v", err)
+	}
+	ban := query.User.Where(query.User.ID.Eq(user.ID))
+	if _, err := ban.Update(query.User.Status, models.UserStatusBanned); err != nil {
+		t.Fatalf("封禁用户失败: %v", err)
+	}
+
+	_, err = auth.VerifyMFA(ctx, result.MFAToken, "000000", ClientInfo{IP: "127.0.0.1"})
+	if code := tools.GetCode(err); code != 403 {
+		t.Fatalf("VerifyMFA code = %d (%v), want 403", code, err)
+	}
+	exists, err := redis.Exists(ctx, mfaChallengePrefix+hashToken(result.MFAToken))
+	if err != nil {
+		t.Fatalf("Exists: %v", err)
+	}
+	if exists {
+		t.Fatal("账号封禁后挑战令牌未作废")
+	}
+}
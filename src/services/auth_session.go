@@ -0,0 +1,179 @@
+// Package services 登录会话管理
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+const (
+	// sessionPrefix 会话 key 前缀（session:<sid>），会话被删除即整条刷新链失效
+	sessionPrefix = "session:"
+	// accountSessionsPrefix 账号会话集合 key 前缀（account_sessions:<role>:<id>）
+	accountSessionsPrefix = "account_sessions:"
+	// sessionTouchInterval 最近活跃时间的最小更新间隔，避免每个请求都写 Redis
+	sessionTouchInterval = time.Minute
+)
+
+// ListSessions 获取账号的全部有效会话（按最近活跃时间倒序）
+func (s *AuthService) ListSessions(ctx context.Context, role string, id uint) ([]*models.Session, error) {
+	setKey := accountSessionsKey(role, id)
+	ids, err := s.redis.SMembers(ctx, setKey)
+	if err != nil {
+		return nil, tools.ErrInternalServer("会话查询失败")
+	}
+
+	sessions := make([]*models.Session, 0, len(ids))
+	for _, sid := range ids {
+		session, err := s.getSession(ctx, sid)
+		if err != nil {
+			// 会话已过期，顺带清理集合
+			//nolint:errcheck // 清理失败不影响查询结果
+			_ = s.redis.SRem(ctx, setKey, sid)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt > sessions[j].LastSeenAt
+	})
+	return sessions, nil
+}
+
+// RevokeSession 吊销账号的指定会话（只能吊销属于该账号的会话）
+func (s *AuthService) RevokeSession(ctx context.Context, role string, id uint, sessionID string) error {
+	session, err := s.getSession(ctx, sessionID)
+	if err != nil || session.Role != role || session.UserID != id {
+		return tools.ErrNotFound("会话不存在")
+	}
+	s.revokeSession(ctx, session)
+	s.audit.Record(ctx, AuditActionSessionRevoke, AuditTargetSession, sessionID, nil, nil)
+	return nil
+}
+
+// TouchSession 更新会话的最近活跃时间和客户端信息（按间隔节流）
+func (s *AuthService) TouchSession(ctx context.Context, sessionID string, client ClientInfo) {
+	session, err := s.getSession(ctx, sessionID)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	if now.Sub(time.Unix(session.LastSeenAt, 0)) < sessionTouchInterval {
+		return
+	}
+	session.IP = client.IP
+	session.UserAgent = client.UserAgent
+	session.LastSeenAt = now.Unix()
+	//nolint:errcheck // 活跃时间更新失败不影响请求
+	_ = s.updateSession(ctx, session)
+}
+
+// createSession 创建新会话并加入账号会话集合
+func (s *AuthService) createSession(
+	ctx context.Context,
+	id uint, username, role string,
+	client ClientInfo,
+) (*models.Session, error) {
+	sessionID, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	session := &models.Session{
+		ID:         sessionID,
+		UserID:     id,
+		Username:   username,
+		Role:       role,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+
+	setKey := accountSessionsKey(role, id)
+	if err := s.redis.SAdd(ctx, setKey, sessionID); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// getSession 读取会话
+func (s *AuthService) getSession(ctx context.Context, sessionID string) (*models.Session, error) {
+	data, err := s.redis.Get(ctx, sessionPrefix+sessionID)
+	if err != nil {
+		return nil, err
+	}
+	var session models.Session
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// saveSession 保存会话并续期（有效期与 Refresh Token 一致）
+func (s *AuthService) saveSession(ctx context.Context, session *models.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	ttl := s.refreshTTL()
+	if err := s.redis.Set(ctx, sessionPrefix+session.ID, string(data), ttl); err != nil {
+		return err
+	}
+	return s.redis.Expire(ctx, accountSessionsKey(session.Role, session.UserID), ttl)
+}
+
+// updateSession 更新会话内容，保留原有过期时间
+func (s *AuthService) updateSession(ctx context.Context, session *models.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	ttl, err := s.redis.TTL(ctx, sessionPrefix+session.ID)
+	if err != nil || ttl <= 0 {
+		return err
+	}
+	return s.redis.Set(ctx, sessionPrefix+session.ID, string(data), ttl)
+}
+
+// revokeSession 吊销会话：删除会话记录，并将 jti 加入黑名单使已签发的 Access Token 立即失效
+func (s *AuthService) revokeSession(ctx context.Context, session *models.Session) {
+	//nolint:errcheck // 移出集合失败不影响吊销，查询会话列表时会自动清理
+	_ = s.redis.SRem(ctx, accountSessionsKey(session.Role, session.UserID), session.ID)
+	s.revokeSessionByID(ctx, session.ID)
+}
+
+// revokeSessionByID 按会话ID吊销会话
+func (s *AuthService) revokeSessionByID(ctx context.Context, sessionID string) {
+	//nolint:errcheck // 删除失败时会话会在过期时间后自然失效
+	_ = s.redis.Del(ctx, sessionPrefix+sessionID)
+	//nolint:errcheck // 黑名单写入失败时 Access Token 会在过期时间后自然失效
+	_ = s.redis.Set(ctx, blacklistTokenPrefix+sessionID, "1", s.accessTTL())
+}
+
+// clearSessions 清除账号的全部会话记录
+func (s *AuthService) clearSessions(ctx context.Context, role string, id uint) {
+	setKey := accountSessionsKey(role, id)
+	ids, err := s.redis.SMembers(ctx, setKey)
+	if err != nil {
+		return
+	}
+	for _, sid := range ids {
+		//nolint:errcheck // 删除失败时会话会在过期时间后自然失效
+		_ = s.redis.Del(ctx, sessionPrefix+sid)
+	}
+	//nolint:errcheck // 删除失败时集合会在过期时间后自然失效
+	_ = s.redis.Del(ctx, setKey)
+}
+
+// accountSessionsKey 账号会话集合 key
+func accountSessionsKey(role string, id uint) string {
+	return accountSessionsPrefix + role + ":" + strconv.FormatUint(uint64(id), 10)
+}
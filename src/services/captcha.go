@@ -5,10 +5,10 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"time"
 
+	"github.com/Company-Automation-1/video-backend-go/src/config"
 	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
 	"github.com/Company-Automation-1/video-backend-go/src/tools"
 	"github.com/redis/go-redis/v9"
@@ -19,6 +19,10 @@ const (
 	CaptchaExpire = 5 * time.Minute
 	// CaptchaPrefix Redis key 前缀
 	CaptchaPrefix = "captcha:"
+	// captchaAttemptsPrefix 验证码错误次数 key 前缀（captcha_attempts:<type>:<email>）
+	captchaAttemptsPrefix = "captcha_attempts:"
+	// captchaQuotaPrefix 每日发送次数 key 前缀（captcha_quota:<email|ip>:<value>:<日期>）
+	captchaQuotaPrefix = "captcha_quota:"
 )
 
 // CaptchaType 验证码类型
@@ -33,13 +37,19 @@ const (
 
 // CaptchaService 验证码服务
 type CaptchaService struct {
+	cfg         *config.CaptchaConfig
 	redisClient *infrastructure.Redis
 	email       *infrastructure.Email
 }
 
 // NewCaptchaService 创建验证码服务
-func NewCaptchaService(redisClient *infrastructure.Redis, email *infrastructure.Email) *CaptchaService {
+func NewCaptchaService(
+	cfg *config.CaptchaConfig,
+	redisClient *infrastructure.Redis,
+	email *infrastructure.Email,
+) *CaptchaService {
 	return &CaptchaService{
+		cfg:         cfg,
 		redisClient: redisClient,
 		email:       email,
 	}
@@ -72,49 +82,153 @@ func (s *CaptchaService) SetCaptcha(ctx context.Context, email, code string, cap
 }
 
 // VerifyCaptcha 验证验证码
+// 错误次数达到上限后验证码作废（即使之后输入正确也不再通过），需等原验证码过期后重新获取
+// 每次验证先递增尝试次数再比较验证码，并发提交的猜测也都会计入，总尝试次数不会超过上限
 func (s *CaptchaService) VerifyCaptcha(ctx context.Context, email, code string, captchaType CaptchaType) (bool, error) {
 	key := CaptchaPrefix + string(captchaType) + ":" + email
+	attemptsKey := captchaAttemptsPrefix + string(captchaType) + ":" + email
 
 	storedCode, err := s.redisClient.Get(ctx, key)
 	if err != nil {
 		if err == redis.Nil {
-			return false, errors.New("验证码已过期或不存在")
+			return false, tools.ErrBadRequest("验证码已过期或不存在")
 		}
 		return false, err
 	}
 
+	attempts, err := s.recordAttempt(ctx, attemptsKey)
+	if err != nil {
+		return false, err
+	}
+	if attempts > int64(s.cfg.MaxAttempts) {
+		return false, s.tooManyAttempts(ctx, key)
+	}
+
 	if storedCode != code {
-		return false, errors.New("验证码错误")
+		if attempts >= int64(s.cfg.MaxAttempts) {
+			return false, s.tooManyAttempts(ctx, key)
+		}
+		return false, tools.ErrBadRequest("验证码错误")
 	}
 
 	// 验证成功后删除验证码（一次性使用）
-	_ = s.redisClient.Del(ctx, key) //nolint:errcheck // 验证成功后删除验证码，失败不影响结果
+	_ = s.redisClient.Del(ctx, key)         //nolint:errcheck // 验证成功后删除验证码，失败不影响结果
+	_ = s.redisClient.Del(ctx, attemptsKey) //nolint:errcheck // 计数会随验证码过期自然失效
 	return true, nil
 }
 
-// SendCode 发送验证码
-func (s *CaptchaService) SendCode(ctx context.Context, email string, captchaType CaptchaType) (string, error) {
+// recordAttempt 验证码尝试次数加一并返回递增后的次数（比较验证码之前调用）
+func (s *CaptchaService) recordAttempt(ctx context.Context, attemptsKey string) (int64, error) {
+	attempts, err := s.redisClient.Incr(ctx, attemptsKey)
+	if err != nil {
+		return 0, fmt.Errorf("记录验证码尝试次数失败: %w", err)
+	}
+	if attempts == 1 {
+		//nolint:errcheck // 过期时间设置失败时计数最长保留一个验证码有效期
+		_ = s.redisClient.Expire(ctx, attemptsKey, CaptchaExpire)
+	}
+	return attempts, nil
+}
+
+// tooManyAttempts 验证码错误次数过多（429，原验证码过期后才能重新获取）
+func (s *CaptchaService) tooManyAttempts(ctx context.Context, key string) error {
+	ttl, err := s.redisClient.TTL(ctx, key)
+	if err != nil || ttl < 0 {
+		ttl = CaptchaExpire
+	}
+	return tools.ErrTooManyRequests("验证码错误次数过多，验证码已作废，请稍后重新获取", ttl)
+}
+
+// SendCode 发送验证码（ip 为请求方IP，用于每日发送配额）
+func (s *CaptchaService) SendCode(ctx context.Context, email, ip string, captchaType CaptchaType) (string, error) {
 	// 检查是否已存在验证码
+	key := CaptchaPrefix + string(captchaType) + ":" + email
 	exists, err := s.checkCaptchaExists(ctx, email, captchaType)
 	if err != nil {
 		return "", err
 	}
 	if exists {
-		return "", tools.ErrBadRequest("验证码已发送，请稍后再试") // 返回AppError，状态码400
+		ttl, err := s.redisClient.TTL(ctx, key)
+		if err != nil || ttl < 0 {
+			ttl = CaptchaExpire
+		}
+		return "", tools.ErrTooManyRequests("验证码已发送，请稍后再试", ttl)
+	}
+
+	// 预占每日发送配额（先递增再比较，并发请求不会超出上限）
+	quotaKeys := s.quotaKeys(email, ip)
+	if err := s.reserveQuota(ctx, quotaKeys); err != nil {
+		return "", err
 	}
 
 	code := s.GenerateCode() // 生成验证码
 
 	if err := s.SetCaptcha(ctx, email, code, captchaType); err != nil {
+		s.releaseQuota(ctx, quotaKeys)
 		return "", err
 	}
 
 	if err := s.email.SendCaptchaEmail(email, code); err != nil {
-		// 邮件发送失败，清理已存储的验证码，避免数据不一致
-		key := CaptchaPrefix + string(captchaType) + ":" + email
+		// 邮件发送失败，清理已存储的验证码并退还配额，避免数据不一致
 		_ = s.redisClient.Del(ctx, key) //nolint:errcheck // 清理失败不影响错误返回
+		s.releaseQuota(ctx, quotaKeys)
 		return "", tools.ErrInternalServer("验证码发送失败")
 	}
 
+	// 发送成功后清除上一个验证码遗留的错误次数
+	_ = s.redisClient.Del(ctx, captchaAttemptsPrefix+string(captchaType)+":"+email) //nolint:errcheck // 计数会自然过期
+
 	return code, nil
 }
+
+// captchaQuota 每日发送配额
+type captchaQuota struct {
+	key   string
+	limit int
+}
+
+// quotaKeys 本次发送需要检查的配额（邮箱、IP）
+func (s *CaptchaService) quotaKeys(email, ip string) []captchaQuota {
+	day := time.Now().Format("20060102")
+	quotas := []captchaQuota{{key: captchaQuotaPrefix + "email:" + email + ":" + day, limit: s.cfg.EmailDailyLimit}}
+	if ip != "" {
+		quotas = append(quotas, captchaQuota{key: captchaQuotaPrefix + "ip:" + ip + ":" + day, limit: s.cfg.IPDailyLimit})
+	}
+	return quotas
+}
+
+// reserveQuota 预占配额：逐个计数加一（INCR 原子递增，计数保留到次日零点），
+// 任一配额超出上限时退还本次已预占的全部配额并返回 429（次日零点可重试）
+func (s *CaptchaService) reserveQuota(ctx context.Context, quotas []captchaQuota) error {
+	for i, quota := range quotas {
+		count, err := s.redisClient.Incr(ctx, quota.key)
+		if err != nil {
+			s.releaseQuota(ctx, quotas[:i])
+			return tools.ErrInternalServer("验证码发送失败")
+		}
+		if count == 1 {
+			//nolint:errcheck // 过期时间设置失败仅影响配额计数
+			_ = s.redisClient.Expire(ctx, quota.key, untilTomorrow())
+		}
+		if count > int64(quota.limit) {
+			s.releaseQuota(ctx, quotas[:i+1])
+			return tools.ErrTooManyRequests("今日验证码发送次数已达上限，请明天再试", untilTomorrow())
+		}
+	}
+	return nil
+}
+
+// releaseQuota 退还预占的配额（超出上限或发送失败时调用）
+func (s *CaptchaService) releaseQuota(ctx context.Context, quotas []captchaQuota) {
+	for _, quota := range quotas {
+		//nolint:errcheck // 退还失败时计数多算一次，次日零点自然清零
+		_, _ = s.redisClient.Decr(ctx, quota.key)
+	}
+}
+
+// untilTomorrow 距离次日零点的时长
+func untilTomorrow() time.Duration {
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	return tomorrow.Sub(now)
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+// TestCaptchaQuotaConcurrent 并发预占配额不超过上限，超限请求退还计数且不占用其他配额
+func TestCaptchaQuotaConcurrent(t *testing.T) {
+	redis := setupTestRedis(t)
+	const limit = 3
+	captcha := NewCaptchaService(&config.CaptchaConfig{EmailDailyLimit: 100, IPDailyLimit: limit}, redis, nil)
+	ctx := context.Background()
+	ip := "203.0.113." + rand.Text()[:8] // 每次运行使用不同的 key
+	quotas := captcha.quotaKeys("quota@example.com", ip)
+	t.Cleanup(func() {
+		for _, quota := range quotas {
+			_ = redis.Del(ctx, quota.key) //nolint:errcheck // 测试清理
+		}
+	})
+
+	var granted atomic.Int64
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := captcha.reserveQuota(ctx, quotas)
+			if err == nil {
+				granted.Add(1)
+				return
+			}
+			if code := tools.GetCode(err); code != 429 {
+				t.Errorf("reserveQuota code = %d (%v), want 429", code, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := granted.Load(); got != limit {
+		t.Fatalf("预占成功 %d 次, want %d", got, limit)
+	}
+	for _, quota := range quotas {
+		value, err := redis.Get(ctx, quota.key)
+		if err != nil {
+			t.Fatalf("读取配额计数失败: %v", err)
+		}
+		if value != strconv.Itoa(limit) {
+			t.Errorf("%s = %s, want %d（超限请求应退还计数）", quota.key, value, limit)
+		}
+	}
+
+	// 发送失败退还配额后可再次预占
+	captcha.releaseQuota(ctx, quotas)
+	if err := captcha.reserveQuota(ctx, quotas); err != nil {
+		t.Fatalf("退还后 reserveQuota: %v", err)
+	}
+}
@@ -0,0 +1,310 @@
+// Package services JWT 签名与验签
+package services
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// JWTAlgorithmRS256 RSA-SHA256 签名算法
+	JWTAlgorithmRS256 = "RS256"
+	// JWTAlgorithmEdDSA Ed25519 签名算法
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+// jwtKey 已加载的签名密钥
+type jwtKey struct {
+	id         string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer // 仅验签的旧密钥为 nil
+	publicKey  crypto.PublicKey
+}
+
+// JWTSigner JWT 签名器
+// 配置了 signing_key_id 时使用对应的非对称密钥签名，并在 Header 中写入 kid；
+// 验签时按 kid 选择密钥，轮换期间旧密钥仍可验签。未配置时沿用 HS256 共享密钥。
+// 从 HS256 切换到非对称签名时，可通过 hs256_grace_period 在启动后的一段时间内继续接受切换前签发的 HS256 令牌。
+// 积分日志检查点可使用 checkpoint_key_id 指定的独立密钥签名，该密钥签发的令牌不能作为访问令牌通过验签。
+type JWTSigner struct {
+	secret        []byte
+	hs256Until    time.Time // 启用非对称签名后接受 HS256 令牌的截止时间
+	signingKey    *jwtKey
+	checkpointKey *jwtKey
+	keys          map[string]*jwtKey
+	keyOrder      []string
+}
+
+// JWK JSON Web Key（仅公钥部分）
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`   // RSA 模数
+	E   string `json:"e,omitempty"`   // RSA 指数
+	Crv string `json:"crv,omitempty"` // OKP 曲线
+	X   string `json:"x,omitempty"`   // OKP 公钥
+}
+
+// JWKSet JSON Web Key Set
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWTSigner 创建 JWT 签名器（从配置的 PEM 文件加载密钥）
+func NewJWTSigner(cfg *config.JWTConfig) (*JWTSigner, error) {
+	if cfg.Secret == config.JWTSecretPlaceholder {
+		return nil, errors.New("jwt.secret 仍为示例配置中的占位值，请修改为随机密钥")
+	}
+	if cfg.Secret == "" && cfg.SigningKeyID == "" {
+		return nil, errors.New("未配置 jwt.secret（或 signing_key_id 指定的非对称签名密钥）")
+	}
+	s := &JWTSigner{
+		secret: []byte(cfg.Secret),
+		keys:   make(map[string]*jwtKey, len(cfg.Keys)),
+	}
+
+	for i := range cfg.Keys {
+		key, err := loadJWTKey(&cfg.Keys[i])
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := s.keys[key.id]; exists {
+			return nil, fmt.Errorf("JWT密钥ID重复: %s", key.id)
+		}
+		s.keys[key.id] = key
+		s.keyOrder = append(s.keyOrder, key.id)
+	}
+
+	var err error
+	if cfg.SigningKeyID != "" {
+		if s.signingKey, err = s.privateKey(cfg.SigningKeyID); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.CheckpointKeyID != "" {
+		if s.checkpointKey, err = s.privateKey(cfg.CheckpointKeyID); err != nil {
+			return nil, err
+		}
+	}
+	if s.signingKey != nil && cfg.HS256GracePeriod > 0 {
+		if cfg.Secret == "" {
+			return nil, errors.New("配置了 hs256_grace_period 但未配置 jwt.secret")
+		}
+		s.hs256Until = time.Now().Add(time.Duration(cfg.HS256GracePeriod) * time.Minute)
+	}
+	return s, nil
+}
+
+// privateKey 查找用于签名的密钥（必须配置私钥）
+func (s *JWTSigner) privateKey(id string) (*jwtKey, error) {
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("JWT签名密钥不存在: %s", id)
+	}
+	if key.privateKey == nil {
+		return nil, fmt.Errorf("JWT签名密钥缺少私钥: %s", id)
+	}
+	return key, nil
+}
+
+// Sign 签发访问令牌
+func (s *JWTSigner) Sign(claims jwt.Claims) (string, error) {
+	if s.signingKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	}
+	return signWithKey(s.signingKey, claims)
+}
+
+// SignCheckpoint 签发积分日志检查点（只使用非对称密钥：优先 checkpoint_key_id，未配置时使用 signing_key_id）
+func (s *JWTSigner) SignCheckpoint(claims jwt.Claims) (string, error) {
+	key := s.checkpointSigningKey()
+	if key == nil {
+		return "", errors.New("未配置非对称签名密钥")
+	}
+	return signWithKey(key, claims)
+}
+
+// CanSignCheckpoint 是否可以签发检查点（HS256 共享密钥签发的检查点可被持有密钥的任何一方伪造，不具备证明作用）
+func (s *JWTSigner) CanSignCheckpoint() bool {
+	return s.checkpointSigningKey() != nil
+}
+
+// checkpointSigningKey 检查点签名密钥
+func (s *JWTSigner) checkpointSigningKey() *jwtKey {
+	if s.checkpointKey != nil {
+		return s.checkpointKey
+	}
+	return s.signingKey
+}
+
+// signWithKey 使用非对称密钥签名，并在 Header 中写入 kid
+func signWithKey(key *jwtKey, claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.privateKey)
+}
+
+// Parse 验证访问令牌并解析 Claims（独立的检查点密钥签发的令牌一律拒绝）
+func (s *JWTSigner) Parse(tokenString string, claims jwt.Claims, opts ...jwt.ParserOption) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, s.keyFunc, opts...)
+}
+
+// keyFunc 按 kid 选择验签密钥，并校验签名算法与密钥一致
+func (s *JWTSigner) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		// 无 kid 的 HS256 Token 仅在未启用非对称签名或切换后的宽限期内接受
+		if s.signingKey != nil && !time.Now().Before(s.hs256Until) {
+			return nil, errors.New("缺少密钥ID")
+		}
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("无效的签名方法")
+		}
+		return s.secret, nil
+	}
+
+	if s.checkpointKey != nil && s.checkpointKey != s.signingKey && kid == s.checkpointKey.id {
+		return nil, errors.New("检查点密钥不能用于访问令牌")
+	}
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, errors.New("未知的密钥ID")
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New("无效的签名方法")
+	}
+	return key.publicKey, nil
+}
+
+// JWKS 导出全部公钥（供下游服务验签）
+func (s *JWTSigner) JWKS() *JWKSet {
+	set := &JWKSet{Keys: make([]JWK, 0, len(s.keyOrder))}
+	for _, id := range s.keyOrder {
+		key := s.keys[id]
+		jwk := JWK{Use: "sig", Alg: key.method.Alg(), Kid: key.id}
+		switch pub := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// loadJWTKey 加载单个密钥（公钥未配置时从私钥导出）
+func loadJWTKey(cfg *config.JWTKeyConfig) (*jwtKey, error) {
+	if cfg.ID == "" {
+		return nil, errors.New("JWT密钥ID不能为空")
+	}
+	if cfg.PrivateKeyFile == "" && cfg.PublicKeyFile == "" {
+		return nil, fmt.Errorf("JWT密钥 %s 未配置密钥文件", cfg.ID)
+	}
+
+	key := &jwtKey{id: cfg.ID}
+	var err error
+	switch cfg.Algorithm {
+	case JWTAlgorithmRS256:
+		key.method = jwt.SigningMethodRS256
+		err = loadRSAKey(cfg, key)
+	case JWTAlgorithmEdDSA:
+		key.method = jwt.SigningMethodEdDSA
+		err = loadEdDSAKey(cfg, key)
+	default:
+		return nil, fmt.Errorf("JWT密钥 %s 的签名算法不支持: %s", cfg.ID, cfg.Algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("JWT密钥 %s 加载失败: %w", cfg.ID, err)
+	}
+	// 同时配置了私钥和公钥时两者必须匹配，否则签发的令牌无法通过验签，JWKS 发布的公钥也是错的
+	if key.privateKey != nil && cfg.PublicKeyFile != "" {
+		public, ok := key.privateKey.Public().(interface{ Equal(x crypto.PublicKey) bool })
+		if !ok || !public.Equal(key.publicKey) {
+			return nil, fmt.Errorf("JWT密钥 %s 的公钥与私钥不匹配", cfg.ID)
+		}
+	}
+	return key, nil
+}
+
+// loadRSAKey 加载 RSA 密钥
+func loadRSAKey(cfg *config.JWTKeyConfig, key *jwtKey) error {
+	if cfg.PrivateKeyFile != "" {
+		data, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return err
+		}
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+		if err != nil {
+			return err
+		}
+		key.privateKey = privateKey
+		key.publicKey = &privateKey.PublicKey
+	}
+	if cfg.PublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return err
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return err
+		}
+		key.publicKey = publicKey
+	}
+	return nil
+}
+
+// loadEdDSAKey 加载 Ed25519 密钥
+func loadEdDSAKey(cfg *config.JWTKeyConfig, key *jwtKey) error {
+	if cfg.PrivateKeyFile != "" {
+		data, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return err
+		}
+		parsed, err := jwt.ParseEdPrivateKeyFromPEM(data)
+		if err != nil {
+			return err
+		}
+		privateKey, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return errors.New("不是 Ed25519 私钥")
+		}
+		key.privateKey = privateKey
+		key.publicKey = privateKey.Public()
+	}
+	if cfg.PublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return err
+		}
+		parsed, err := jwt.ParseEdPublicKeyFromPEM(data)
+		if err != nil {
+			return err
+		}
+		publicKey, ok := parsed.(ed25519.PublicKey)
+		if !ok {
+			return errors.New("不是 Ed25519 公钥")
+		}
+		key.publicKey = publicKey
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TestNewJWTSignerSecret 未配置非对称签名密钥时 jwt.secret 必填，且不能为示例配置中的占位值
+func TestNewJWTSignerSecret(t *testing.T) {
+	for _, secret := range []string{"", config.JWTSecretPlaceholder} {
+		if _, err := NewJWTSigner(&config.JWTConfig{Secret: secret}); err == nil {
+			t.Fatalf("secret = %q 时应拒绝启动", secret)
+		}
+	}
+	if _, err := NewJWTSigner(&config.JWTConfig{Secret: "shared-secret"}); err != nil {
+		t.Fatalf("NewJWTSigner: %v", err)
+	}
+}
+
+// TestJWTSignerSignVerify RS256 与 EdDSA 签名的令牌带 kid，并能通过验签
+func TestJWTSignerSignVerify(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		algorithm string
+		key       crypto.Signer
+	}{
+		{algorithm: JWTAlgorithmRS256, key: testRSAKey(t)},
+		{algorithm: JWTAlgorithmEdDSA, key: testEdDSAKey(t)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			signer := newTestSigner(t, "k1", testJWTKeyConfig(t, dir, "k1", tt.algorithm, tt.key, nil))
+			tokenString, err := signer.Sign(testJWTClaims())
+			if err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			token, err := signer.Parse(tokenString, &jwt.RegisteredClaims{})
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if token.Header["kid"] != "k1" || token.Method.Alg() != tt.algorithm {
+				t.Fatalf("header = %v, want kid k1 alg %s", token.Header, tt.algorithm)
+			}
+		})
+	}
+}
+
+// TestJWTSignerRotation 轮换签名密钥后旧密钥签发的令牌仍可验签，移除旧密钥后不能再验签
+func TestJWTSignerRotation(t *testing.T) {
+	dir := t.TempDir()
+	oldKey := testJWTKeyConfig(t, dir, "old", JWTAlgorithmEdDSA, testEdDSAKey(t), nil)
+	newKey := testJWTKeyConfig(t, dir, "new", JWTAlgorithmRS256, testRSAKey(t), nil)
+
+	before := newTestSigner(t, "old", oldKey)
+	oldToken, err := before.Sign(testJWTClaims())
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	rotated := newTestSigner(t, "new", newKey, oldKey)
+	if _, err := rotated.Parse(oldToken, &jwt.RegisteredClaims{}); err != nil {
+		t.Fatalf("轮换后旧令牌验签失败: %v", err)
+	}
+	newToken, err := rotated.Sign(testJWTClaims())
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	token, _, err := jwt.NewParser().ParseUnverified(newToken, &jwt.RegisteredClaims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified: %v", err)
+	}
+	if token.Header["kid"] != "new" {
+		t.Fatalf("轮换后签名 kid = %v, want new", token.Header["kid"])
+	}
+
+	retired := newTestSigner(t, "new", newKey)
+	if _, err := retired.Parse(oldToken, &jwt.RegisteredClaims{}); err == nil {
+		t.Fatal("移除旧密钥后旧令牌验签成功, want 拒绝")
+	}
+}
+
+// TestJWTSignerRejects 拒绝未知 kid、kid 与算法不一致（含用公钥作为 HS256 密钥的算法混淆）和缺少 kid 的令牌
+func TestJWTSignerRejects(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey := testRSAKey(t)
+	signer := newTestSigner(t, "rsa", testJWTKeyConfig(t, dir, "rsa", JWTAlgorithmRS256, rsaKey, nil))
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: testPublicKeyDER(t, rsaKey)})
+
+	sign := func(method jwt.SigningMethod, kid string, key any) string {
+		token := jwt.NewWithClaims(method, testJWTClaims())
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		s, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString: %v", err)
+		}
+		return s
+	}
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "未知kid", token: sign(jwt.SigningMethodRS256, "unknown", rsaKey)},
+		{name: "其他私钥", token: sign(jwt.SigningMethodRS256, "rsa", testRSAKey(t))},
+		{name: "kid与算法不一致", token: sign(jwt.SigningMethodRS512, "rsa", rsaKey)},
+		{name: "公钥作为HS256密钥", token: sign(jwt.SigningMethodHS256, "rsa", publicPEM)},
+		{name: "缺少kid的HS256", token: sign(jwt.SigningMethodHS256, "", []byte("shared-secret"))},
+		{name: "none算法", token: sign(jwt.SigningMethodNone, "rsa", jwt.UnsafeAllowNoneSignatureType)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signer.Parse(tt.token, &jwt.RegisteredClaims{}); err == nil {
+				t.Fatal("验签成功, want 拒绝")
+			}
+		})
+	}
+}
+
+// TestJWTSignerHS256Grace 切换到非对称签名后，宽限期内仍接受切换前签发的 HS256 令牌
+func TestJWTSignerHS256Grace(t *testing.T) {
+	dir := t.TempDir()
+	key := testJWTKeyConfig(t, dir, "k1", JWTAlgorithmEdDSA, testEdDSAKey(t), nil)
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, testJWTClaims()).SignedString([]byte("shared-secret"))
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+
+	grace, err := NewJWTSigner(&config.JWTConfig{
+		Secret: "shared-secret", SigningKeyID: "k1", HS256GracePeriod: 15, Keys: []config.JWTKeyConfig{*key},
+	})
+	if err != nil {
+		t.Fatalf("NewJWTSigner: %v", err)
+	}
+	if _, err := grace.Parse(legacy, &jwt.RegisteredClaims{}); err != nil {
+		t.Fatalf("宽限期内 HS256 令牌验签失败: %v", err)
+	}
+
+	grace.hs256Until = time.Now().Add(-time.Second)
+	if _, err := grace.Parse(legacy, &jwt.RegisteredClaims{}); err == nil {
+		t.Fatal("宽限期后 HS256 令牌验签成功, want 拒绝")
+	}
+
+	// 宽限期需要共享密钥
+	_, err = NewJWTSigner(&config.JWTConfig{SigningKeyID: "k1", HS256GracePeriod: 15, Keys: []config.JWTKeyConfig{*key}})
+	if err == nil {
+		t.Fatal("未配置 secret 时开启宽限期应拒绝启动")
+	}
+}
+
+// TestJWTSignerJWKS JWKS 按配置顺序发布全部公钥（含仅用于验签的旧公钥）
+func TestJWTSignerJWKS(t *testing.T) {
+	dir := t.TempDir()
+	edKey := testEdDSAKey(t)
+	rsaKey := testRSAKey(t)
+	signer := newTestSigner(t, "ed",
+		testJWTKeyConfig(t, dir, "ed", JWTAlgorithmEdDSA, edKey, nil),
+		testJWTKeyConfig(t, dir, "rsa", JWTAlgorithmRS256, nil, rsaKey.Public()),
+	)
+
+	keys := signer.JWKS().Keys
+	if len(keys) != 2 {
+		t.Fatalf("JWKS 公钥 %d 个, want 2", len(keys))
+	}
+	ed := keys[0]
+	wantX := base64.RawURLEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey))
+	if ed.Kty != "OKP" || ed.Crv != "Ed25519" || ed.Alg != JWTAlgorithmEdDSA || ed.Kid != "ed" || ed.Use != "sig" ||
+		ed.X != wantX {
+		t.Fatalf("Ed25519 JWK = %+v", ed)
+	}
+	rs := keys[1]
+	n, err := base64.RawURLEncoding.DecodeString(rs.N)
+	if err != nil {
+		t.Fatalf("解码 n 失败: %v", err)
+	}
+	if rs.Kty != "RSA" || rs.Alg != JWTAlgorithmRS256 || rs.Kid != "rsa" || rs.E != "AQAB" ||
+		new(big.Int).SetBytes(n).Cmp(rsaKey.N) != 0 {
+		t.Fatalf("RSA JWK = %+v", rs)
+	}
+}
+
+// TestJWTKeyPairMismatch 同时配置的公钥与私钥不匹配时拒绝加载
+func TestJWTKeyPairMismatch(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		algorithm string
+		key       crypto.Signer
+		other     crypto.Signer
+	}{
+		{algorithm: JWTAlgorithmRS256, key: testRSAKey(t), other: testRSAKey(t)},
+		{algorithm: JWTAlgorithmEdDSA, key: testEdDSAKey(t), other: testEdDSAKey(t)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			matched := testJWTKeyConfig(t, dir, tt.algorithm+"-ok", tt.algorithm, tt.key, tt.key.Public())
+			if _, err := loadJWTKey(matched); err != nil {
+				t.Fatalf("匹配的密钥对加载失败: %v", err)
+			}
+			mismatched := testJWTKeyConfig(t, dir, tt.algorithm+"-bad", tt.algorithm, tt.key, tt.other.Public())
+			if _, err := loadJWTKey(mismatched); err == nil {
+				t.Fatal("不匹配的密钥对加载成功, want 拒绝")
+			}
+		})
+	}
+}
+
+// newTestSigner 使用给定密钥创建签名器（signingKeyID 为当前签名密钥）
+func newTestSigner(t *testing.T, signingKeyID string, keys ...*config.JWTKeyConfig) *JWTSigner {
+	t.Helper()
+	cfg := &config.JWTConfig{SigningKeyID: signingKeyID}
+	for _, key := range keys {
+		cfg.Keys = append(cfg.Keys, *key)
+	}
+	signer, err := NewJWTSigner(cfg)
+	if err != nil {
+		t.Fatalf("NewJWTSigner: %v", err)
+	}
+	return signer
+}
+
+// testJWTKeyConfig 将密钥写入 PEM 文件并返回密钥配置（private 或 public 为 nil 时不配置对应文件）
+func testJWTKeyConfig(
+	t *testing.T,
+	dir, id, algorithm string,
+	private crypto.Signer,
+	public crypto.PublicKey,
+) *config.JWTKeyConfig {
+	t.Helper()
+	cfg := &config.JWTKeyConfig{ID: id, Algorithm: algorithm}
+	if private != nil {
+		der, err := x509.MarshalPKCS8PrivateKey(private)
+		if err != nil {
+			t.Fatalf("编码私钥失败: %v", err)
+		}
+		cfg.PrivateKeyFile = writeTestPEM(t, dir, id+".key", "PRIVATE KEY", der)
+	}
+	if public != nil {
+		der, err := x509.MarshalPKIXPublicKey(public)
+		if err != nil {
+			t.Fatalf("编码公钥失败: %v", err)
+		}
+		cfg.PublicKeyFile = writeTestPEM(t, dir, id+".pub", "PUBLIC KEY", der)
+	}
+	return cfg
+}
+
+// writeTestPEM 写入 PEM 文件并返回路径
+func writeTestPEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("写入 %s 失败: %v", name, err)
+	}
+	return path
+}
+
+// testPublicKeyDER 公钥的 PKIX DER 编码
+func testPublicKeyDER(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("编码公钥失败: %v", err)
+	}
+	return der
+}
+
+// testRSAKey 生成测试用 RSA 私钥
+func testRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成 RSA 密钥失败: %v", err)
+	}
+	return key
+}
+
+// testEdDSAKey 生成测试用 Ed25519 私钥
+func testEdDSAKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("生成 Ed25519 密钥失败: %v", err)
+	}
+	return key
+}
+
+// testJWTClaims 测试用 Claims（1 分钟后过期）
+func testJWTClaims() *jwt.RegisteredClaims {
+	return &jwt.RegisteredClaims{Subject: "1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}
+}
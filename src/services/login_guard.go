@@ -0,0 +1,188 @@
+// Package services 登录防暴力破解服务
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+const (
+	// loginFailPrefix 登录失败计数 key 前缀（login_fail:<role>:<type>:<value>）
+	loginFailPrefix = "login_fail:"
+	// loginLockPrefix 登录锁定 key 前缀（login_lock:<role>:<type>:<value>），值为锁定时的失败次数
+	loginLockPrefix = "login_lock:"
+)
+
+const (
+	// LockoutTypeUsername 按用户名锁定
+	LockoutTypeUsername = "username"
+	// LockoutTypeIP 按客户端IP锁定
+	LockoutTypeIP = "ip"
+)
+
+// LoginGuardService 登录防暴力破解服务
+// 按用户名和客户端IP分别统计失败次数，超过阈值后按指数退避锁定
+type LoginGuardService struct {
+	cfg   *config.LoginGuardConfig
+	redis *infrastructure.Redis
+	audit *AuditService
+}
+
+// NewLoginGuardService 创建登录防暴力破解服务
+func NewLoginGuardService(
+	cfg *config.LoginGuardConfig,
+	redis *infrastructure.Redis,
+	audit *AuditService,
+) *LoginGuardService {
+	return &LoginGuardService{
+		cfg:   cfg,
+		redis: redis,
+		audit: audit,
+	}
+}
+
+// Check 检查用户名或IP是否处于锁定中
+func (s *LoginGuardService) Check(ctx context.Context, role, username, ip string) error {
+	for _, target := range s.targets(username, ip) {
+		ttl, err := s.redis.TTL(ctx, lockoutKey(loginLockPrefix, role, target.kind, target.value))
+		if err == nil && ttl > 0 {
+			return lockedError(ttl)
+		}
+	}
+	return nil
+}
+
+// RecordFailure 记录一次登录失败，达到阈值时锁定并返回 429 错误
+func (s *LoginGuardService) RecordFailure(ctx context.Context, role, username, ip string) error {
+	var lockErr error
+	for _, target := range s.targets(username, ip) {
+		failKey := lockoutKey(loginFailPrefix, role, target.kind, target.value)
+		failures, err := s.redis.Incr(ctx, failKey)
+		if err != nil {
+			continue
+		}
+		if failures == 1 {
+			//nolint:errcheck // 过期时间设置失败仅影响计数窗口
+			_ = s.redis.Expire(ctx, failKey, time.Duration(s.cfg.FailureWindow)*time.Second)
+		}
+		if failures < int64(target.limit) {
+			continue
+		}
+
+		// 指数退避：达到阈值锁定 base 秒，此后每多失败一次翻倍，不超过 max
+		lockout := s.lockoutDuration(failures - int64(target.limit))
+		lockKey := lockoutKey(loginLockPrefix, role, target.kind, target.value)
+		if err := s.redis.Set(ctx, lockKey, strconv.FormatInt(failures, 10), lockout); err == nil {
+			lockErr = lockedError(lockout)
+		}
+		// 计数保留到锁定结束之后，以便下一次失败继续退避
+		//nolint:errcheck // 过期时间设置失败仅影响计数窗口
+		_ = s.redis.Expire(ctx, failKey, lockout+time.Duration(s.cfg.FailureWindow)*time.Second)
+	}
+	return lockErr
+}
+
+// RecordSuccess 登录成功后清除该用户名的失败计数（IP计数保留，防止撞库）
+func (s *LoginGuardService) RecordSuccess(ctx context.Context, role, username string) {
+	//nolint:errcheck // 清除失败仅影响后续计数
+	_ = s.redis.Del(ctx, lockoutKey(loginFailPrefix, role, LockoutTypeUsername, normalizeUsername(username)))
+}
+
+// ListLockouts 获取当前全部锁定记录
+func (s *LoginGuardService) ListLockouts(ctx context.Context) ([]*models.LoginLockout, error) {
+	keys, err := s.redis.Scan(ctx, loginLockPrefix+"*")
+	if err != nil {
+		return nil, tools.ErrInternalServer("锁定记录查询失败")
+	}
+
+	lockouts := make([]*models.LoginLockout, 0, len(keys))
+	for _, key := range keys {
+		parts := strings.SplitN(strings.TrimPrefix(key, loginLockPrefix), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		ttl, err := s.redis.TTL(ctx, key)
+		if err != nil || ttl <= 0 {
+			continue
+		}
+		value, err := s.redis.Get(ctx, key)
+		if err != nil {
+			continue
+		}
+		failures, _ := strconv.ParseInt(value, 10, 64) //nolint:errcheck // 解析失败显示为0
+		lockouts = append(lockouts, &models.LoginLockout{
+			Role:       parts[0],
+			Type:       parts[1],
+			Value:      parts[2],
+			Failures:   failures,
+			RetryAfter: int64(ttl.Seconds()),
+		})
+	}
+	return lockouts, nil
+}
+
+// ClearLockout 解除锁定并清零失败计数
+func (s *LoginGuardService) ClearLockout(ctx context.Context, role, lockoutType, value string) error {
+	if lockoutType == LockoutTypeUsername {
+		value = normalizeUsername(value)
+	}
+	if err := s.redis.Del(ctx, lockoutKey(loginLockPrefix, role, lockoutType, value)); err != nil {
+		return tools.ErrInternalServer("解除锁定失败")
+	}
+	if err := s.redis.Del(ctx, lockoutKey(loginFailPrefix, role, lockoutType, value)); err != nil {
+		return tools.ErrInternalServer("解除锁定失败")
+	}
+	s.audit.Record(ctx, AuditActionLoginLockoutClear, AuditTargetLoginLockout, role+":"+lockoutType+":"+value, nil, nil)
+	return nil
+}
+
+// lockoutTarget 计数维度
+type lockoutTarget struct {
+	kind  string
+	value string
+	limit int
+}
+
+// targets 本次登录需要计数的维度（用户名、IP）
+func (s *LoginGuardService) targets(username, ip string) []lockoutTarget {
+	targets := []lockoutTarget{{kind: LockoutTypeUsername, value: normalizeUsername(username), limit: s.cfg.MaxFailures}}
+	if ip != "" {
+		targets = append(targets, lockoutTarget{kind: LockoutTypeIP, value: ip, limit: s.cfg.IPMaxFailures})
+	}
+	return targets
+}
+
+// lockoutDuration 计算第 n 次超限时的锁定时长
+func (s *LoginGuardService) lockoutDuration(n int64) time.Duration {
+	lockout := time.Duration(s.cfg.BaseLockout) * time.Second
+	maxLockout := time.Duration(s.cfg.MaxLockout) * time.Second
+	for i := int64(0); i < n && lockout < maxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > maxLockout {
+		lockout = maxLockout
+	}
+	return lockout
+}
+
+// lockedError 锁定错误（429，附带 Retry-After）
+func lockedError(retryAfter time.Duration) error {
+	return tools.ErrTooManyRequests("登录失败次数过多，请稍后再试", retryAfter)
+}
+
+// lockoutKey 计数/锁定 key
+func lockoutKey(prefix, role, lockoutType, value string) string {
+	return prefix + role + ":" + lockoutType + ":" + value
+}
+
+// normalizeUsername 用户名统一小写（数据库按不区分大小写比较）
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
@@ -0,0 +1,259 @@
+// Package services 两步验证服务
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"gorm.io/gorm"
+)
+
+const (
+	// totpPendingPrefix 待启用的 TOTP 密钥 key 前缀（totp_pending:<role>:<id>）
+	totpPendingPrefix = "totp_pending:"
+	// totpUsedPrefix 已使用的口令时间步 key 前缀（totp_used:<role>:<id>:<step>），防止口令重放
+	totpUsedPrefix = "totp_used:"
+	// totpPendingExpire 待启用密钥有效期
+	totpPendingExpire = 10 * time.Minute
+	// recoveryCodeCount 每次生成的恢复码数量
+	recoveryCodeCount = 10
+)
+
+// MFAService 两步验证服务（TOTP + 一次性恢复码）
+type MFAService struct {
+	cfg   *config.MFAConfig
+	redis *infrastructure.Redis
+	audit *AuditService
+}
+
+// NewMFAService 创建两步验证服务
+func NewMFAService(cfg *config.MFAConfig, redis *infrastructure.Redis, audit *AuditService) *MFAService {
+	return &MFAService{
+		cfg:   cfg,
+		redis: redis,
+		audit: audit,
+	}
+}
+
+// Setup 生成待启用的 TOTP 密钥，返回密钥和 otpauth URI（需调用 Enable 验证后才生效）
+func (s *MFAService) Setup(ctx context.Context, role string, id uint, account string) (secret, uri string, err error) {
+	_, enabled, err := s.getTOTP(role, id)
+	if err != nil {
+		return "", "", err
+	}
+	if enabled {
+		return "", "", tools.ErrConflict("已启用两步验证")
+	}
+
+	secret, err = tools.GenerateTOTPSecret()
+	if err != nil {
+		return "", "", tools.ErrInternalServer("密钥生成失败")
+	}
+	if err := s.redis.Set(ctx, mfaKey(totpPendingPrefix, role, id), secret, totpPendingExpire); err != nil {
+		return "", "", tools.ErrInternalServer("密钥生成失败")
+	}
+	return secret, tools.TOTPURI(s.cfg.Issuer, account, secret), nil
+}
+
+// Enable 校验口令并启用两步验证，返回一次性恢复码（仅此一次展示明文）
+func (s *MFAService) Enable(ctx context.Context, role string, id uint, code string) ([]string, error) {
+	secret, err := s.redis.Get(ctx, mfaKey(totpPendingPrefix, role, id))
+	if err != nil {
+		return nil, tools.ErrBadRequest("请先获取两步验证密钥")
+	}
+	if err := s.verifyTOTP(ctx, role, id, secret, code); err != nil {
+		return nil, err
+	}
+
+	if err := s.setTOTP(role, id, secret, true); err != nil {
+		return nil, err
+	}
+	//nolint:errcheck // 待启用密钥会自然过期
+	_ = s.redis.Del(ctx, mfaKey(totpPendingPrefix, role, id))
+	// 角色与审计对象类型一致（user/admin）
+	s.audit.Record(ctx, AuditActionMFAEnable, role, id,
+		AuditFields{"totp_enabled": false}, AuditFields{"totp_enabled": true})
+
+	return s.regenerateRecoveryCodes(role, id)
+}
+
+// Required 该类账号是否必须启用两步验证
+func (s *MFAService) Required(role string) bool {
+	return role == RoleAdmin && s.cfg.RequireAdmin
+}
+
+// Disable 校验口令（或恢复码）并关闭两步验证（强制两步验证的账号不允许关闭）
+func (s *MFAService) Disable(ctx context.Context, role string, id uint, code string) error {
+	if s.Required(role) {
+		return tools.ErrForbidden("管理员必须启用两步验证")
+	}
+	if err := s.Verify(ctx, role, id, code); err != nil {
+		return err
+	}
+	if err := s.setTOTP(role, id, "", false); err != nil {
+		return err
+	}
+	_, err := query.MFARecoveryCode.
+		Where(query.MFARecoveryCode.Role.Eq(role), query.MFARecoveryCode.AccountID.Eq(id)).
+		Delete()
+	if err != nil {
+		return tools.ErrInternalServer("恢复码清理失败")
+	}
+	s.audit.Record(ctx, AuditActionMFADisable, role, id,
+		AuditFields{"totp_enabled": true}, AuditFields{"totp_enabled": false})
+	return nil
+}
+
+// IsEnabled 账号是否已启用两步验证
+func (s *MFAService) IsEnabled(role string, id uint) (bool, error) {
+	_, enabled, err := s.getTOTP(role, id)
+	return enabled, err
+}
+
+// Verify 校验两步验证口令：6位数字按 TOTP 校验，其余按恢复码校验
+func (s *MFAService) Verify(ctx context.Context, role string, id uint, code string) error {
+	secret, enabled, err := s.getTOTP(role, id)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return tools.ErrBadRequest("未启用两步验证")
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == tools.TOTPDigits {
+		return s.verifyTOTP(ctx, role, id, secret, code)
+	}
+	return s.useRecoveryCode(role, id, code)
+}
+
+// verifyTOTP 校验 TOTP 口令，同一时间步的口令只能使用一次
+func (s *MFAService) verifyTOTP(ctx context.Context, role string, id uint, secret, code string) error {
+	step, ok := tools.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return tools.ErrBadRequest("两步验证码错误")
+	}
+	usedKey := mfaKey(totpUsedPrefix, role, id) + ":" + strconv.FormatInt(step, 10)
+	firstUse, err := s.redis.SetNX(ctx, usedKey, "1", 3*tools.TOTPPeriod*time.Second)
+	if err != nil {
+		return tools.ErrInternalServer("两步验证失败")
+	}
+	if !firstUse {
+		return tools.ErrBadRequest("该验证码已使用，请等待下一个验证码")
+	}
+	return nil
+}
+
+// useRecoveryCode 使用恢复码（原子地标记为已使用）
+func (s *MFAService) useRecoveryCode(role string, id uint, code string) error {
+	info, err := query.MFARecoveryCode.
+		Where(
+			query.MFARecoveryCode.Role.Eq(role),
+			query.MFARecoveryCode.AccountID.Eq(id),
+			query.MFARecoveryCode.CodeHash.Eq(hashRecoveryCode(code)),
+			query.MFARecoveryCode.UsedAt.IsNull(),
+		).
+		Update(query.MFARecoveryCode.UsedAt, time.Now().Unix())
+	if err != nil {
+		return tools.ErrInternalServer("两步验证失败")
+	}
+	if info.RowsAffected == 0 {
+		return tools.ErrBadRequest("两步验证码错误")
+	}
+	return nil
+}
+
+// regenerateRecoveryCodes 生成新的恢复码（旧恢复码全部作废）
+func (s *MFAService) regenerateRecoveryCodes(role string, id uint) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	records := make([]*models.MFARecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		var b [5]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return nil, tools.ErrInternalServer("恢复码生成失败")
+		}
+		raw := hex.EncodeToString(b[:])
+		codes[i] = raw[:5] + "-" + raw[5:]
+		records[i] = &models.MFARecoveryCode{
+			Role:      role,
+			AccountID: id,
+			CodeHash:  hashRecoveryCode(codes[i]),
+		}
+	}
+
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		if _, err := tx.MFARecoveryCode.
+			Where(tx.MFARecoveryCode.Role.Eq(role), tx.MFARecoveryCode.AccountID.Eq(id)).
+			Delete(); err != nil {
+			return err
+		}
+		return tx.MFARecoveryCode.Create(records...)
+	})
+	if err != nil {
+		return nil, tools.ErrInternalServer("恢复码生成失败")
+	}
+	return codes, nil
+}
+
+// getTOTP 读取账号的 TOTP 密钥和启用状态
+func (s *MFAService) getTOTP(role string, id uint) (secret string, enabled bool, err error) {
+	switch role {
+	case RoleAdmin:
+		admin, err := query.Admin.Where(query.Admin.ID.Eq(id)).First()
+		if err == gorm.ErrRecordNotFound {
+			return "", false, tools.ErrNotFound("管理员不存在")
+		}
+		if err != nil {
+			return "", false, tools.ErrInternalServer("两步验证状态查询失败")
+		}
+		return admin.TOTPSecret, admin.TOTPEnabled, nil
+	default:
+		user, err := query.User.Where(query.User.ID.Eq(id)).First()
+		if err == gorm.ErrRecordNotFound {
+			return "", false, tools.ErrNotFound("用户不存在")
+		}
+		if err != nil {
+			return "", false, tools.ErrInternalServer("两步验证状态查询失败")
+		}
+		return user.TOTPSecret, user.TOTPEnabled, nil
+	}
+}
+
+// setTOTP 保存账号的 TOTP 密钥和启用状态
+func (s *MFAService) setTOTP(role string, id uint, secret string, enabled bool) error {
+	var err error
+	switch role {
+	case RoleAdmin:
+		_, err = query.Admin.Where(query.Admin.ID.Eq(id)).
+			UpdateSimple(query.Admin.TOTPSecret.Value(secret), query.Admin.TOTPEnabled.Value(enabled))
+	default:
+		_, err = query.User.Where(query.User.ID.Eq(id)).
+			UpdateSimple(query.User.TOTPSecret.Value(secret), query.User.TOTPEnabled.Value(enabled))
+	}
+	if err != nil {
+		return tools.ErrInternalServer("两步验证设置失败")
+	}
+	return nil
+}
+
+// hashRecoveryCode 计算恢复码摘要（忽略大小写、空格和连字符）
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// mfaKey 两步验证相关 key
+func mfaKey(prefix, role string, id uint) string {
+	return prefix + role + ":" + strconv.FormatUint(uint64(id), 10)
+}
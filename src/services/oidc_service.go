@@ -0,0 +1,302 @@
+// Package services 第三方登录服务（OpenID Connect）
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// oidcStatePrefix 授权请求 state key 前缀（保存 PKCE code_verifier 和 nonce，一次性使用）
+	oidcStatePrefix = "oidc_state:"
+	// oidcStateExpire 授权请求有效期
+	oidcStateExpire = 10 * time.Minute
+	// oidcUsernameMaxLen 自动生成用户名的最大长度（不含随机后缀）
+	oidcUsernameMaxLen = 32
+)
+
+// oidcUsernameInvalidChars 自动生成用户名时需要剔除的字符
+var oidcUsernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`) //nolint:gochecknoglobals // 正则为只读常量
+
+// oidcState Redis 中保存的授权请求
+type oidcState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+// OIDCService 第三方登录服务
+type OIDCService struct {
+	providers map[string]*infrastructure.OIDCProvider
+	redis     *infrastructure.Redis
+	auth      *AuthService
+	audit     *AuditService
+	rewards   *RewardService
+}
+
+// NewOIDCService 创建第三方登录服务
+func NewOIDCService(
+	cfg *config.OIDCConfig,
+	redis *infrastructure.Redis,
+	auth *AuthService,
+	audit *AuditService,
+	rewards *RewardService,
+) *OIDCService {
+	providers := make(map[string]*infrastructure.OIDCProvider, len(cfg.Providers))
+	for i := range cfg.Providers {
+		providers[cfg.Providers[i].Name] = infrastructure.NewOIDCProvider(&cfg.Providers[i])
+	}
+	return &OIDCService{
+		providers: providers,
+		redis:     redis,
+		auth:      auth,
+		audit:     audit,
+		rewards:   rewards,
+	}
+}
+
+// AuthorizationURL 生成身份提供方授权地址（授权码模式 + PKCE）
+func (s *OIDCService) AuthorizationURL(ctx context.Context, providerName string) (authURL, state string, err error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err = randomToken()
+	if err != nil {
+		return "", "", tools.ErrInternalServer("授权请求生成失败")
+	}
+	verifier, err := randomToken()
+	if err != nil {
+		return "", "", tools.ErrInternalServer("授权请求生成失败")
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return "", "", tools.ErrInternalServer("授权请求生成失败")
+	}
+
+	authURL, err = provider.AuthCodeURL(ctx, state, nonce, pkceChallenge(verifier))
+	if err != nil {
+		tools.Logf("OIDC 获取授权地址失败 provider=%s: %v", providerName, err)
+		return "", "", tools.ErrInternalServer("第三方登录暂不可用")
+	}
+
+	data, err := json.Marshal(&oidcState{Provider: providerName, CodeVerifier: verifier, Nonce: nonce})
+	if err != nil {
+		return "", "", tools.ErrInternalServer("授权请求生成失败")
+	}
+	if err := s.redis.Set(ctx, oidcStatePrefix+hashToken(state), string(data), oidcStateExpire); err != nil {
+		return "", "", tools.ErrInternalServer("授权请求生成失败")
+	}
+	return authURL, state, nil
+}
+
+// Login 使用授权回调的 code 和 state 登录
+// 已关联的第三方身份直接登录；否则按已验证邮箱关联已有用户，邮箱未注册时自动创建用户
+func (s *OIDCService) Login(
+	ctx context.Context,
+	providerName, code, state string,
+	client ClientInfo,
+) (*LoginResult, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// state 一次性使用，且必须由同一提供方签发
+	data, err := s.redis.GetDel(ctx, oidcStatePrefix+hashToken(state))
+	if err != nil {
+		return nil, tools.ErrBadRequest("授权请求无效或已过期，请重新登录")
+	}
+	var st oidcState
+	if err := json.Unmarshal([]byte(data), &st); err != nil || st.Provider != providerName {
+		return nil, tools.ErrBadRequest("授权请求无效或已过期，请重新登录")
+	}
+
+	idToken, err := provider.Exchange(ctx, code, st.CodeVerifier)
+	if err != nil {
+		tools.Logf("OIDC 授权码换取失败 provider=%s: %v", providerName, err)
+		return nil, tools.ErrUnauthorized("第三方登录失败")
+	}
+	claims, err := provider.VerifyIDToken(ctx, idToken, st.Nonce)
+	if err != nil {
+		tools.Logf("OIDC ID Token 验证失败 provider=%s: %v", providerName, err)
+		return nil, tools.ErrUnauthorized("第三方登录失败")
+	}
+
+	user, err := s.resolveUser(ctx, providerName, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := userStatusError(user); err != nil {
+		return nil, err
+	}
+	return s.auth.loginAuthenticated(ctx, &loginInfo{
+		ID:          user.ID,
+		Username:    user.Username,
+		TOTPEnabled: user.TOTPEnabled,
+	}, RoleUser, client)
+}
+
+// resolveUser 查找第三方身份关联的用户（不存在时按已验证邮箱关联或创建）
+func (s *OIDCService) resolveUser(
+	ctx context.Context,
+	providerName string,
+	claims *infrastructure.OIDCIDTokenClaims,
+) (*models.User, error) {
+	identity, err := query.UserIdentity.
+		Where(query.UserIdentity.Provider.Eq(providerName), query.UserIdentity.Subject.Eq(claims.Subject)).
+		First()
+	switch {
+	case err == nil:
+		user, err := query.User.Where(query.User.ID.Eq(identity.UserID)).First()
+		if err == nil {
+			return user, nil
+		}
+		if err != gorm.ErrRecordNotFound {
+			return nil, tools.ErrInternalServer("第三方登录失败")
+		}
+		// 关联的用户已删除：清除残留的身份，按首次登录处理
+		if _, err := query.UserIdentity.Where(query.UserIdentity.ID.Eq(identity.ID)).Delete(); err != nil {
+			return nil, tools.ErrInternalServer("第三方登录失败")
+		}
+	case err != gorm.ErrRecordNotFound:
+		return nil, tools.ErrInternalServer("第三方登录失败")
+	}
+
+	// 首次登录：只信任提供方已验证的邮箱，防止通过伪造邮箱接管已有账号
+	email := strings.TrimSpace(claims.Email)
+	if email == "" || !claims.EmailVerified {
+		return nil, tools.ErrBadRequest("第三方账号邮箱未验证，无法登录")
+	}
+
+	var user *models.User
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		existing, err := tx.User.Where(tx.User.Email.Eq(email)).First()
+		switch {
+		case err == nil:
+			user = existing
+		case err == gorm.ErrRecordNotFound:
+			user, err = s.createUser(ctx, tx, email, claims)
+			if err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+		return tx.UserIdentity.Create(&models.UserIdentity{
+			UserID:   user.ID,
+			Provider: providerName,
+			Subject:  claims.Subject,
+			Email:    email,
+		})
+	})
+	if err != nil {
+		if appErr, ok := err.(*tools.AppError); ok {
+			return nil, appErr
+		}
+		return nil, tools.ErrInternalServer("第三方登录失败")
+	}
+
+	// 未登录请求：以被关联的用户作为操作者
+	actor := AuditActorFromContext(ctx)
+	actor.ID, actor.Role = user.ID, RoleUser
+	s.audit.Record(WithAuditActor(ctx, actor), AuditActionUserIdentityLink, AuditTargetUser, user.ID,
+		nil, AuditFields{"provider": providerName, "subject": claims.Subject, "email": email})
+	return user, nil
+}
+
+// createUser 为首次登录的第三方身份创建用户（随机密码，可通过忘记密码重新设置），并发放注册奖励
+func (s *OIDCService) createUser(
+	ctx context.Context,
+	tx *query.Query,
+	email string,
+	claims *infrastructure.OIDCIDTokenClaims,
+) (*models.User, error) {
+	password, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	username, err := s.availableUsername(tx, claims.PreferredUsername, email)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Username:      username,
+		Email:         email,
+		Password:      string(hashedPassword),
+		EmailVerified: true,
+	}
+	if err := tx.User.Create(user); err != nil {
+		return nil, err
+	}
+	if err := s.rewards.RegisterTx(ctx, tx, user, nil); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// availableUsername 生成未被占用的用户名（优先使用提供方用户名，其次邮箱前缀，冲突时追加随机后缀）
+func (s *OIDCService) availableUsername(tx *query.Query, preferred, email string) (string, error) {
+	base := oidcUsernameInvalidChars.ReplaceAllString(preferred, "")
+	if base == "" {
+		local, _, _ := strings.Cut(email, "@")
+		base = oidcUsernameInvalidChars.ReplaceAllString(local, "")
+	}
+	if len(base) > oidcUsernameMaxLen {
+		base = base[:oidcUsernameMaxLen]
+	}
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	candidate := base
+	for range 5 {
+		count, err := tx.User.Where(tx.User.Username.Eq(candidate)).Count()
+		if err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+		suffix, err := randomToken()
+		if err != nil {
+			return "", err
+		}
+		candidate = base + "_" + suffix[:6]
+	}
+	return "", tools.ErrConflict("用户名生成失败，请重试")
+}
+
+// provider 获取身份提供方
+func (s *OIDCService) provider(name string) (*infrastructure.OIDCProvider, error) {
+	provider, ok := s.providers[name]
+	if !ok {
+		return nil, tools.ErrNotFound("不支持的登录方式")
+	}
+	return provider, nil
+}
+
+// pkceChallenge 计算 PKCE code_challenge（S256）
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
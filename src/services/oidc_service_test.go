@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure/oidctest"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// newTestOIDCService 创建指向模拟身份提供方（名称 mock）的第三方登录服务
+func newTestOIDCService(t *testing.T, issuer *oidctest.Issuer) *OIDCService {
+	t.Helper()
+	setupTestDB(t)
+	redis := setupTestRedis(t)
+	cfg := loadTestConfig(t)
+
+	auth := newTestAuthService(t, cfg, redis)
+	rewards := NewRewardService(newTestLedger(t, cfg), &cfg.Rewards)
+	oidcCfg := &config.OIDCConfig{Providers: []config.OIDCProviderConfig{issuer.ProviderConfig("mock")}}
+	return NewOIDCService(oidcCfg, redis, auth, auth.audit, rewards)
+}
+
+// oidcLogin 走完授权地址 → 提供方授权 → 回调登录
+func oidcLogin(t *testing.T, s *OIDCService, issuer *oidctest.Issuer, claims jwt.MapClaims) (*LoginResult, error) {
+	t.Helper()
+	ctx := context.Background()
+	authURL, state, err := s.AuthorizationURL(ctx, "mock")
+	if err != nil {
+		t.Fatalf("AuthorizationURL: %v", err)
+	}
+	code, err := issuer.Authorize(authURL, claims)
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	return s.Login(ctx, "mock", code, state, ClientInfo{IP: "127.0.0.1"})
+}
+
+// testEmail 生成不重复的测试邮箱
+func testEmail() string {
+	return strings.ToLower(rand.Text()[:10]) + "@example.com"
+}
+
+func TestOIDCLoginRejectsUnverifiedEmail(t *testing.T) {
+	issuer := oidctest.NewIssuer(t)
+	s := newTestOIDCService(t, issuer)
+	email := testEmail()
+
+	_, err := oidcLogin(t, s, issuer, jwt.MapClaims{"sub": "sub-unverified", "email": email, "email_verified": false})
+	if tools.GetCode(err) != http.StatusBadRequest {
+		t.Fatalf("err = %v, want 400", err)
+	}
+	if n, _ := query.User.Where(query.User.Email.Eq(email)).Count(); n != 0 {
+		t.Fatalf("不应创建用户，got %d", n)
+	}
+	if n, _ := query.UserIdentity.Where(query.UserIdentity.Subject.Eq("sub-unverified")).Count(); n != 0 {
+		t.Fatalf("不应关联第三方身份，got %d", n)
+	}
+}
+
+func TestOIDCLoginLinksExistingUserByEmail(t *testing.T) {
+	issuer := oidctest.NewIssuer(t)
+	s := newTestOIDCService(t, issuer)
+	email := testEmail()
+	user := createTestUser(t, s.rewards.ledger, email, 0)
+
+	claims := jwt.MapClaims{"sub": "sub-link", "email": email, "email_verified": true}
+	result, err := oidcLogin(t, s, issuer, claims)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if result.Tokens == nil {
+		t.Fatal("未启用两步验证时应直接签发令牌")
+	}
+
+	identity, err := query.UserIdentity.Where(query.UserIdentity.Provider.Eq("mock"),
+		query.UserIdentity.Subject.Eq("sub-link")).First()
+	if err != nil {
+		t.Fatalf("第三方身份未关联: %v", err)
+	}
+	if identity.UserID != user.ID {
+		t.Fatalf("关联用户 = %d, want %d", identity.UserID, user.ID)
+	}
+	if n, _ := query.User.Where(query.User.Email.Eq(email)).Count(); n != 1 {
+		t.Fatalf("不应创建新用户，got %d", n)
+	}
+
+	// 再次登录走已关联身份（即使邮箱改为未验证）
+	claims["email_verified"] = false
+	if _, err := oidcLogin(t, s, issuer, claims); err != nil {
+		t.Fatalf("已关联身份再次登录: %v", err)
+	}
+}
+
+func TestOIDCLoginRejectsMismatchedState(t *testing.T) {
+	issuer := oidctest.NewIssuer(t)
+	s := newTestOIDCService(t, issuer)
+	ctx := context.Background()
+
+	// 授权码来自第一次授权，state 来自第二次授权：code_verifier 与 code_challenge 不匹配
+	authURL, _, err := s.AuthorizationURL(ctx, "mock")
+	if err != nil {
+		t.Fatalf("AuthorizationURL: %v", err)
+	}
+	_, otherState, err := s.AuthorizationURL(ctx, "mock")
+	if err != nil {
+		t.Fatalf("AuthorizationURL: %v", err)
+	}
+	code, err := issuer.Authorize(authURL, jwt.MapClaims{"sub": "sub-pkce", "email": testEmail(), "email_verified": true})
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	_, err = s.Login(ctx, "mock", code, otherState, ClientInfo{IP: "127.0.0.1"})
+	if tools.GetCode(err) != http.StatusUnauthorized {
+		t.Fatalf("err = %v, want 401", err)
+	}
+}
+
+func TestOIDCLoginAfterUserDeleted(t *testing.T) {
+	issuer := oidctest.NewIssuer(t)
+	s := newTestOIDCService(t, issuer)
+	users := NewUserService(nil, s.auth, s.audit, s.rewards)
+	ctx := context.Background()
+
+	claims := jwt.MapClaims{"sub": "sub-deleted", "email": testEmail(), "email_verified": true}
+	if _, err := oidcLogin(t, s, issuer, claims); err != nil {
+		t.Fatalf("首次登录: %v", err)
+	}
+	identity, err := query.UserIdentity.Where(query.UserIdentity.Provider.Eq("mock"),
+		query.UserIdentity.Subject.Eq("sub-deleted")).First()
+	if err != nil {
+		t.Fatalf("第三方身份未关联: %v", err)
+	}
+	userID := identity.UserID
+	if _, _, err := s.auth.apiKeys.Create(ctx, userID, "batch", []string{ScopeProfileRead}, nil); err != nil {
+		t.Fatalf("创建API Key失败: %v", err)
+	}
+	if _, err := s.rewards.ledger.Apply(ctx, userID, 10, PointsReasonAdminGrant, "test"); err != nil {
+		t.Fatalf("发放积分失败: %v", err)
+	}
+
+	if err := users.Delete(ctx, userID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if n, _ := query.UserIdentity.Where(query.UserIdentity.UserID.Eq(userID)).Count(); n != 0 {
+		t.Fatalf("残留第三方身份 %d 个", n)
+	}
+	if n, _ := query.APIKey.Where(query.APIKey.UserID.Eq(userID)).Count(); n != 0 {
+		t.Fatalf("残留API Key %d 个", n)
+	}
+	lots := query.PointsLot
+	if n, _ := lots.Where(lots.UserID.Eq(userID), lots.Remaining.Gt(0)).Count(); n != 0 {
+		t.Fatalf("残留未用完的积分批次 %d 个", n)
+	}
+
+	// 同一第三方身份再次登录：按首次登录创建新用户
+	if _, err := oidcLogin(t, s, issuer, claims); err != nil {
+		t.Fatalf("删除后再次登录: %v", err)
+	}
+	relinked, err := query.UserIdentity.Where(query.UserIdentity.Provider.Eq("mock"),
+		query.UserIdentity.Subject.Eq("sub-deleted")).First()
+	if err != nil {
+		t.Fatalf("第三方身份未重新关联: %v", err)
+	}
+	if relinked.UserID == userID {
+		t.Fatal("应关联到新创建的用户")
+	}
+}
+
+func TestOIDCLoginIgnoresDanglingIdentity(t *testing.T) {
+	issuer := oidctest.NewIssuer(t)
+	s := newTestOIDCService(t, issuer)
+
+	// 旧版本删除用户时残留的身份
+	if err := query.UserIdentity.Create(&models.UserIdentity{
+		UserID:   999999,
+		Provider: "mock",
+		Subject:  "sub-dangling",
+		Email:    "dangling@example.com",
+	}); err != nil {
+		t.Fatalf("创建残留身份失败: %v", err)
+	}
+	claims := jwt.MapClaims{"sub": "sub-dangling", "email": testEmail(), "email_verified": true}
+	if _, err := oidcLogin(t, s, issuer, claims); err != nil {
+		t.Fatalf("残留身份登录: %v", err)
+	}
+	identity, err := query.UserIdentity.Where(query.UserIdentity.Provider.Eq("mock"),
+		query.UserIdentity.Subject.Eq("sub-dangling")).First()
+	if err != nil {
+		t.Fatalf("第三方身份未重新关联: %v", err)
+	}
+	if identity.UserID == 999999 {
+		t.Fatal("残留身份未清除")
+	}
+}
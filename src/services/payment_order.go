@@ -0,0 +1,373 @@
+// Package services 积分订单（下单、支付回调、退款）
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// orderNoRandomLength 订单号随机部分长度（订单号为 下单时间 + 随机字符）
+const orderNoRandomLength = 8
+
+// CreateOrder 购买积分套餐：创建待支付订单并向支付渠道创建支付
+func (s *PaymentService) CreateOrder(
+	ctx context.Context,
+	userID, productID uint,
+) (*models.PointsOrder, *infrastructure.PaymentSession, error) {
+	if s.provider == nil {
+		return nil, nil, tools.ErrNotFound("暂未开放积分购买")
+	}
+	p := query.PointsProduct
+	product, err := p.Where(p.ID.Eq(productID), p.Status.Eq(models.PointsProductStatusActive)).First()
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil, tools.ErrNotFound("积分套餐不存在或已下架")
+	}
+	if err != nil {
+		return nil, nil, tools.ErrInternalServer("积分套餐查询失败")
+	}
+
+	orderNo, err := randomCode(orderNoRandomLength)
+	if err != nil {
+		return nil, nil, tools.ErrInternalServer("订单创建失败")
+	}
+	order := &models.PointsOrder{
+		OrderNo:     time.Now().Format("20060102150405") + orderNo,
+		UserID:      userID,
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Points:      product.Points,
+		Amount:      product.Price,
+		Provider:    s.provider.Name(),
+		Status:      models.PointsOrderStatusCreated,
+	}
+	if err := query.PointsOrder.Create(order); err != nil {
+		tools.Logf("订单创建失败: %v", err)
+		return nil, nil, tools.ErrInternalServer("订单创建失败")
+	}
+
+	session, err := s.provider.CreatePayment(ctx, &infrastructure.PaymentRequest{
+		OrderNo: order.OrderNo,
+		Amount:  order.Amount,
+		Subject: order.ProductName,
+	})
+	if err != nil {
+		tools.Logf("支付创建失败 order=%s: %v", order.OrderNo, err)
+		return nil, nil, tools.ErrBadGateway("支付创建失败")
+	}
+	o := query.PointsOrder
+	if _, err := o.Where(o.ID.Eq(order.ID)).UpdateSimple(o.PaymentID.Value(session.PaymentID)); err != nil {
+		return nil, nil, tools.ErrInternalServer("订单创建失败")
+	}
+	order.PaymentID = session.PaymentID
+	return order, session, nil
+}
+
+// ListUserOrders 查询本人的积分订单（按创建时间倒序）
+func (s *PaymentService) ListUserOrders(
+	userID uint,
+	queryReq *dto.PointsOrderListQueryRequest,
+) ([]*models.PointsOrder, int64, error) {
+	o := query.PointsOrder
+	return s.listOrders(&queryReq.PaginationRequest, queryReq.Status, o.UserID.Eq(userID))
+}
+
+// ListOrders 查询积分订单（管理员权限，按创建时间倒序）
+func (s *PaymentService) ListOrders(
+	queryReq *dto.PointsOrderAdminListQueryRequest,
+) ([]*models.PointsOrder, int64, error) {
+	o := query.PointsOrder
+	conditions := tools.NewConditionBuilder().
+		EqUint(&o.UserID, queryReq.UserID).
+		EqString(&o.OrderNo, queryReq.OrderNo).
+		Build()
+	return s.listOrders(&queryReq.PaginationRequest, queryReq.Status, conditions...)
+}
+
+// HandleWebhook 处理支付渠道回调：验签后标记订单已支付并发放积分，返回处理后的订单（非支付成功事件忽略，返回 nil）
+func (s *PaymentService) HandleWebhook(
+	ctx context.Context,
+	header http.Header,
+	body []byte,
+) (*models.PointsOrder, error) {
+	if s.provider == nil {
+		return nil, tools.ErrNotFound("暂未开放积分购买")
+	}
+	event, err := s.provider.ParseWebhook(header, body)
+	if errors.Is(err, infrastructure.ErrPaymentSignature) {
+		return nil, tools.ErrUnauthorized("支付回调签名无效")
+	}
+	if err != nil {
+		return nil, tools.ErrBadRequest("支付回调格式错误")
+	}
+	if event.Type != infrastructure.PaymentEventSucceeded {
+		return nil, nil
+	}
+
+	order, err := s.markPaid(event)
+	if err != nil {
+		return nil, wrapOrderError(err)
+	}
+	return s.fulfill(ctx, order.ID)
+}
+
+// FakeCheckoutEnabled 是否开启了本地模拟支付渠道（开启时才注册模拟支付接口）
+func (s *PaymentService) FakeCheckoutEnabled() bool {
+	_, ok := s.provider.(*infrastructure.FakePaymentProvider)
+	return ok
+}
+
+// FakeCheckout 模拟支付本人的订单（仅本地模拟支付渠道）：生成已签名的支付成功回调并按正常回调处理
+func (s *PaymentService) FakeCheckout(ctx context.Context, userID uint, orderNo string) (*models.PointsOrder, error) {
+	fake, ok := s.provider.(*infrastructure.FakePaymentProvider)
+	if !ok {
+		return nil, tools.ErrNotFound("模拟支付未开启")
+	}
+	o := query.PointsOrder
+	order, err := o.Where(o.OrderNo.Eq(orderNo), o.UserID.Eq(userID)).First()
+	if err == gorm.ErrRecordNotFound {
+		return nil, tools.ErrNotFound("订单不存在")
+	}
+	if err != nil {
+		return nil, tools.ErrInternalServer("订单查询失败")
+	}
+
+	header, body, err := fake.Pay(order.OrderNo, order.PaymentID, order.Amount)
+	if err != nil {
+		return nil, tools.ErrInternalServer("模拟支付失败")
+	}
+	return s.HandleWebhook(ctx, header, body)
+}
+
+// Refund 积分订单退款（管理员权限）：扣回本订单的积分并向支付渠道全额退款（重复退款直接返回订单）
+// 先在事务中扣回积分并标记退款中，提交后再调用渠道退款，成功后标记已退款；渠道退款失败时订单保持退款中，
+// 再次调用会重试渠道退款（积分不会重复扣回）。用户可用积分不足以扣回时拒绝退款
+func (s *PaymentService) Refund(ctx context.Context, orderID uint, reason string) (*models.PointsOrder, error) {
+	if s.provider == nil {
+		return nil, tools.ErrConflict("支付渠道未开启，不能退款")
+	}
+	order, err := s.startRefund(ctx, orderID, reason)
+	if err != nil {
+		return nil, wrapOrderError(err)
+	}
+	if order.Status == models.PointsOrderStatusRefunded {
+		return order, nil
+	}
+
+	// 渠道退款不在数据库事务中执行，避免持有用户和订单的行锁等待渠道响应
+	if err := s.provider.Refund(ctx, order.PaymentID, order.Amount); err != nil {
+		tools.Logf("渠道退款失败 order=%s: %v", order.OrderNo, err)
+		return nil, tools.ErrBadGateway("渠道退款失败，订单保持退款中，可稍后重试")
+	}
+
+	now := time.Now().Unix()
+	err = transitOrder(query.Q, order, models.PointsOrderStatusRefunding, models.PointsOrderStatusRefunded,
+		query.PointsOrder.RefundedAt.Value(now))
+	if err != nil {
+		// 渠道已退款但订单状态未更新：记录日志供对账，订单保持退款中
+		tools.Logf("渠道已退款但订单状态更新失败，需对账 order=%s payment=%s: %v", order.OrderNo, order.PaymentID, err)
+		return nil, wrapOrderError(err)
+	}
+	order.RefundedAt = &now
+	s.audit.Record(ctx, AuditActionPointsOrderRefund, AuditTargetPointsOrder, order.ID,
+		AuditFields{"status": models.PointsOrderStatusRefunding},
+		AuditFields{"status": order.Status, "refunded_at": now})
+	return order, nil
+}
+
+// startRefund 退款第一步：扣回积分并将已到账订单标记为退款中（退款中和已退款的订单直接返回）
+func (s *PaymentService) startRefund(ctx context.Context, orderID uint, reason string) (*models.PointsOrder, error) {
+	var order *models.PointsOrder
+	started := false
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		o := tx.PointsOrder
+		found, err := o.Where(o.ID.Eq(orderID)).First()
+		if err == gorm.ErrRecordNotFound {
+			return tools.ErrNotFound("订单不存在")
+		}
+		if err != nil {
+			return err
+		}
+		// 锁定顺序与积分账本一致：先用户后订单
+		user, err := lockUser(tx, found.UserID)
+		if err != nil {
+			return err
+		}
+		order, err = o.Clauses(clause.Locking{Strength: "UPDATE"}).Where(o.ID.Eq(orderID)).First()
+		if err != nil {
+			return err
+		}
+
+		if order.Status == models.PointsOrderStatusRefunded || order.Status == models.PointsOrderStatusRefunding {
+			return nil
+		}
+		if order.Status != models.PointsOrderStatusFulfilled {
+			return tools.ErrConflict("订单积分未到账，不能退款")
+		}
+		if order.Provider != s.provider.Name() {
+			return tools.ErrConflict("订单的支付渠道已停用，不能退款")
+		}
+		if err := s.ledger.expireDueLots(tx, user); err != nil {
+			return err
+		}
+		if pointsValue(user.Points)-user.HeldPoints < order.Points {
+			return tools.ErrConflict("用户可用积分不足，无法扣回本订单积分")
+		}
+
+		txn, err := s.ledger.apply(ctx, tx, user, -order.Points, PointsReasonPurchaseRefund, orderReference(order))
+		if err != nil {
+			return err
+		}
+		reason = truncate(reason, 255)
+		err = transitOrder(tx, order, models.PointsOrderStatusFulfilled, models.PointsOrderStatusRefunding,
+			o.RefundTransactionID.Value(txn.ID), o.RefundReason.Value(reason))
+		if err != nil {
+			return err
+		}
+		order.RefundTransactionID, order.RefundReason = &txn.ID, reason
+		started = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if started {
+		s.audit.Record(ctx, AuditActionPointsOrderRefund, AuditTargetPointsOrder, order.ID,
+			AuditFields{"status": models.PointsOrderStatusFulfilled, "refund_reason": ""},
+			AuditFields{"status": order.Status, "refund_reason": order.RefundReason})
+	}
+	return order, nil
+}
+
+// listOrders 分页查询订单（status 不为 nil 时按状态过滤）
+func (s *PaymentService) listOrders(
+	page *dto.PaginationRequest,
+	status *int8,
+	conditions ...gen.Condition,
+) ([]*models.PointsOrder, int64, error) {
+	o := query.PointsOrder
+	if status != nil {
+		conditions = append(conditions, o.Status.Eq(*status))
+	}
+	orders, count, err := o.Where(conditions...).
+		Order(o.ID.Desc()).
+		FindByPage(page.GetOffset(), page.GetLimit())
+	if err != nil {
+		return nil, 0, tools.ErrInternalServer("订单查询失败")
+	}
+	return orders, count, nil
+}
+
+// markPaid 支付成功回调：校验回调与订单一致后将待支付订单标记为已支付（已支付及之后的状态直接返回）
+func (s *PaymentService) markPaid(event *infrastructure.PaymentEvent) (*models.PointsOrder, error) {
+	var order *models.PointsOrder
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		o := tx.PointsOrder
+		var err error
+		order, err = o.Clauses(clause.Locking{Strength: "UPDATE"}).Where(o.OrderNo.Eq(event.OrderNo)).First()
+		if err == gorm.ErrRecordNotFound {
+			return tools.ErrNotFound("订单不存在")
+		}
+		if err != nil {
+			return err
+		}
+		if order.Provider != s.provider.Name() || order.PaymentID != event.PaymentID || order.Amount != event.Amount {
+			tools.Logf("支付回调与订单不一致 order=%s event=%s payment=%s amount=%d",
+				order.OrderNo, event.ID, event.PaymentID, event.Amount)
+			return tools.ErrBadRequest("支付回调与订单不一致")
+		}
+		if order.Status != models.PointsOrderStatusCreated {
+			return nil
+		}
+
+		now := time.Now().Unix()
+		if err := transitOrder(tx, order, models.PointsOrderStatusCreated, models.PointsOrderStatusPaid,
+			o.PaidAt.Value(now)); err != nil {
+			return err
+		}
+		order.PaidAt = &now
+		return nil
+	})
+	return order, err
+}
+
+// fulfill 发放已支付订单的积分（写入 purchase 流水并标记已到账，已到账或已退款的订单直接返回）
+func (s *PaymentService) fulfill(ctx context.Context, orderID uint) (*models.PointsOrder, error) {
+	var order *models.PointsOrder
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		o := tx.PointsOrder
+		found, err := o.Where(o.ID.Eq(orderID)).First()
+		if err != nil {
+			return err
+		}
+		// 锁定顺序与积分账本一致：先用户后订单
+		user, err := lockUser(tx, found.UserID)
+		if err != nil {
+			return err
+		}
+		order, err = o.Clauses(clause.Locking{Strength: "UPDATE"}).Where(o.ID.Eq(orderID)).First()
+		if err != nil {
+			return err
+		}
+		if order.Status != models.PointsOrderStatusPaid {
+			return nil
+		}
+
+		txn, err := s.ledger.apply(ctx, tx, user, order.Points, PointsReasonPurchase, orderReference(order))
+		if err != nil {
+			return err
+		}
+		now := time.Now().Unix()
+		if err := transitOrder(tx, order, models.PointsOrderStatusPaid, models.PointsOrderStatusFulfilled,
+			o.TransactionID.Value(txn.ID), o.FulfilledAt.Value(now)); err != nil {
+			return err
+		}
+		order.TransactionID, order.FulfilledAt = &txn.ID, &now
+		return nil
+	})
+	if err != nil {
+		return nil, wrapOrderError(err)
+	}
+	return order, nil
+}
+
+// transitOrder 订单状态流转：以 WHERE status = from 的条件更新保证只能从上一状态流转
+func transitOrder(tx *query.Query, order *models.PointsOrder, from, to int8, assigns ...field.AssignExpr) error {
+	o := tx.PointsOrder
+	result, err := o.Where(o.ID.Eq(order.ID), o.Status.Eq(from)).
+		UpdateSimple(append(assigns, o.Status.Value(to))...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return tools.ErrConflict("订单状态已变更")
+	}
+	order.Status = to
+	return nil
+}
+
+// orderReference 订单积分流水的关联业务ID
+func orderReference(order *models.PointsOrder) string {
+	return "points_order:" + order.OrderNo
+}
+
+// wrapOrderError 转换事务中的错误
+func wrapOrderError(err error) error {
+	if appErr, ok := err.(*tools.AppError); ok {
+		return appErr
+	}
+	tools.Logf("订单处理失败: %v", err)
+	return tools.ErrInternalServer("订单处理失败")
+}
@@ -0,0 +1,149 @@
+// Package services 积分套餐购买服务
+package services
+
+import (
+	"context"
+
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+// PaymentService 积分套餐购买服务（套餐管理、下单、支付回调、退款）
+// 订单按 待支付 → 已支付 → 已到账 → 已退款 依次流转：支付渠道的回调验签后先标记已支付，
+// 再在另一事务中写入 purchase 流水并标记已到账，重复回调或到账失败后渠道重试回调都不会重复加积分
+type PaymentService struct {
+	provider infrastructure.PaymentProvider
+	ledger   *PointsLedgerService
+	audit    *AuditService
+}
+
+// NewPaymentService 创建积分套餐购买服务（provider 为 nil 时不开放购买）
+func NewPaymentService(
+	provider infrastructure.PaymentProvider,
+	ledger *PointsLedgerService,
+	audit *AuditService,
+) *PaymentService {
+	return &PaymentService{
+		provider: provider,
+		ledger:   ledger,
+		audit:    audit,
+	}
+}
+
+// ListActiveProducts 获取上架的积分套餐（按价格升序）
+func (s *PaymentService) ListActiveProducts() ([]*models.PointsProduct, error) {
+	p := query.PointsProduct
+	products, err := p.Where(p.Status.Eq(models.PointsProductStatusActive)).Order(p.Price, p.ID).Find()
+	if err != nil {
+		return nil, tools.ErrInternalServer("积分套餐查询失败")
+	}
+	return products, nil
+}
+
+// ListProducts 查询积分套餐（管理员权限，按创建时间倒序）
+func (s *PaymentService) ListProducts(
+	queryReq *dto.PointsProductListQueryRequest,
+) ([]*models.PointsProduct, int64, error) {
+	p := query.PointsProduct
+	builder := tools.NewConditionBuilder().Like(&p.Name, queryReq.Name)
+	if queryReq.Status != nil {
+		builder.And(p.Status.Eq(*queryReq.Status))
+	}
+
+	products, count, err := p.Where(builder.Build()...).
+		Order(p.ID.Desc()).
+		FindByPage(queryReq.GetOffset(), queryReq.GetLimit())
+	if err != nil {
+		return nil, 0, tools.ErrInternalServer("积分套餐查询失败")
+	}
+	return products, count, nil
+}
+
+// CreateProduct 创建积分套餐（默认上架）
+func (s *PaymentService) CreateProduct(
+	ctx context.Context,
+	req *dto.PointsProductCreateRequest,
+) (*models.PointsProduct, error) {
+	product := &models.PointsProduct{
+		Name:   req.Name,
+		Points: req.Points,
+		Price:  req.Price,
+		Status: req.Status,
+	}
+	if product.Status == 0 {
+		product.Status = models.PointsProductStatusActive
+	}
+	if err := query.PointsProduct.Create(product); err != nil {
+		tools.Logf("积分套餐创建失败: %v", err)
+		return nil, tools.ErrInternalServer("积分套餐创建失败")
+	}
+
+	s.audit.Record(ctx, AuditActionPointsProductCreate, AuditTargetPointsProduct, product.ID, nil,
+		productAuditFields(product))
+	return product, nil
+}
+
+// UpdateProduct 修改积分套餐（含上架/下架，已创建的订单保存了下单时的快照，不受影响）
+func (s *PaymentService) UpdateProduct(
+	ctx context.Context,
+	id uint,
+	req *dto.PointsProductUpdateRequest,
+) (*models.PointsProduct, error) {
+	p := query.PointsProduct
+	existing, err := p.Where(p.ID.Eq(id)).First()
+	if err == gorm.ErrRecordNotFound {
+		return nil, tools.ErrNotFound("积分套餐不存在")
+	}
+	if err != nil {
+		return nil, tools.ErrInternalServer("积分套餐查询失败")
+	}
+
+	updated := *existing
+	var assigns []field.AssignExpr
+	if req.Name != nil {
+		updated.Name = *req.Name
+		assigns = append(assigns, p.Name.Value(*req.Name))
+	}
+	if req.Points != nil {
+		updated.Points = *req.Points
+		assigns = append(assigns, p.Points.Value(*req.Points))
+	}
+	if req.Price != nil {
+		updated.Price = *req.Price
+		assigns = append(assigns, p.Price.Value(*req.Price))
+	}
+	if req.Status != nil {
+		updated.Status = *req.Status
+		assigns = append(assigns, p.Status.Value(*req.Status))
+	}
+	if len(assigns) == 0 {
+		return existing, nil
+	}
+	if _, err := p.Where(p.ID.Eq(id)).UpdateSimple(assigns...); err != nil {
+		tools.Logf("积分套餐修改失败: %v", err)
+		return nil, tools.ErrInternalServer("积分套餐修改失败")
+	}
+
+	s.audit.Record(ctx, AuditActionPointsProductUpdate, AuditTargetPointsProduct, id,
+		productAuditFields(existing), productAuditFields(&updated))
+	product, err := p.Where(p.ID.Eq(id)).First()
+	if err != nil {
+		return nil, tools.ErrInternalServer("积分套餐查询失败")
+	}
+	return product, nil
+}
+
+// productAuditFields 积分套餐的审计字段
+func productAuditFields(product *models.PointsProduct) AuditFields {
+	return AuditFields{
+		"name":   product.Name,
+		"points": product.Points,
+		"price":  product.Price,
+		"status": product.Status,
+	}
+}
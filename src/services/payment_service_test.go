@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+// testPaymentConfig 测试用支付配置（本地模拟支付渠道）
+func testPaymentConfig() *config.PaymentConfig {
+	return &config.PaymentConfig{
+		Provider:         infrastructure.FakePaymentProviderName,
+		WebhookSecret:    "test-webhook-secret",
+		WebhookTolerance: 300,
+	}
+}
+
+// TestPaymentWebhookFlow 下单 → 模拟支付 → 回调到账，重放同一回调不会重复加积分
+func TestPaymentWebhookFlow(t *testing.T) {
+	setupTestDB(t)
+	ledger := newTestLedger(t, loadTestConfig(t))
+	provider := infrastructure.NewFakePaymentProvider(testPaymentConfig())
+	payments := NewPaymentService(provider, ledger, NewAuditService())
+	user := createTestUser(t, ledger, "buyer@example.com", 0)
+	ctx := context.Background()
+
+	product := &models.PointsProduct{Name: "100积分", Points: 100, Price: 1000, Status: models.PointsProductStatusActive}
+	if err := query.PointsProduct.Create(product); err != nil {
+		t.Fatalf("创建积分套餐失败: %v", err)
+	}
+
+	order, session, err := payments.CreateOrder(ctx, user.ID, product.ID)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	created := reloadTestOrder(t, order.ID)
+	if created.Status != models.PointsOrderStatusCreated || created.PaidAt != nil || created.FulfilledAt != nil {
+		t.Fatalf("下单后订单状态 = %d, want created", created.Status)
+	}
+	if session.PaymentID != created.PaymentID {
+		t.Fatalf("支付ID = %q, want %q", created.PaymentID, session.PaymentID)
+	}
+
+	header, body, err := provider.Pay(created.OrderNo, created.PaymentID, created.Amount)
+	if err != nil {
+		t.Fatalf("Pay: %v", err)
+	}
+	fulfilled, err := payments.HandleWebhook(ctx, header, body)
+	if err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+	if fulfilled.Status != models.PointsOrderStatusFulfilled {
+		t.Fatalf("回调后订单状态 = %d, want fulfilled", fulfilled.Status)
+	}
+
+	// 渠道重试：重放同一回调
+	replayed, err := payments.HandleWebhook(ctx, header, body)
+	if err != nil {
+		t.Fatalf("重放回调: %v", err)
+	}
+	if replayed.Status != models.PointsOrderStatusFulfilled {
+		t.Fatalf("重放后订单状态 = %d, want fulfilled", replayed.Status)
+	}
+
+	// 依次经过 待支付 → 已支付 → 已到账
+	got := reloadTestOrder(t, order.ID)
+	if got.Status != models.PointsOrderStatusFulfilled || got.PaidAt == nil || got.FulfilledAt == nil ||
+		got.TransactionID == nil {
+		t.Fatalf("订单 = %+v, want fulfilled 且记录支付时间、到账时间和到账流水", got)
+	}
+	if *got.PaidAt < got.CreatedAt || *got.FulfilledAt < *got.PaidAt {
+		t.Fatalf("created_at = %d paid_at = %d fulfilled_at = %d, want 依次流转", got.CreatedAt, *got.PaidAt,
+			*got.FulfilledAt)
+	}
+
+	pt := query.PointsTransaction
+	txns, err := pt.Where(pt.UserID.Eq(user.ID), pt.Reason.Eq(PointsReasonPurchase)).Find()
+	if err != nil {
+		t.Fatalf("查询流水失败: %v", err)
+	}
+	if len(txns) != 1 {
+		t.Fatalf("purchase 流水 %d 笔, want 1", len(txns))
+	}
+	if txns[0].ID != *got.TransactionID || txns[0].Delta != 100 ||
+		txns[0].ReferenceID != "points_order:"+got.OrderNo {
+		t.Fatalf("purchase 流水 = %+v, want 订单 %s 的 100 积分", txns[0], got.OrderNo)
+	}
+	if points := *reloadTestUser(t, user.ID).Points; points != 100 {
+		t.Fatalf("points = %d, want 100", points)
+	}
+	assertLedgerConsistent(t, user.ID, 1)
+}
+
+// failingRefundProvider 前 failures 次渠道退款失败的模拟支付渠道
+type failingRefundProvider struct {
+	*infrastructure.FakePaymentProvider
+	failures int
+	refunds  int
+}
+
+// Refund 记录调用次数，前 failures 次返回错误
+func (p *failingRefundProvider) Refund(ctx context.Context, paymentID string, amount int) error {
+	p.refunds++
+	if p.refunds <= p.failures {
+		return errors.New("渠道暂时不可用")
+	}
+	return p.FakePaymentProvider.Refund(ctx, paymentID, amount)
+}
+
+// TestPaymentRefundRetry 渠道退款失败时积分已扣回、订单保持退款中，重试只调用渠道退款不会重复扣回积分
+func TestPaymentRefundRetry(t *testing.T) {
+	setupTestDB(t)
+	ledger := newTestLedger(t, loadTestConfig(t))
+	fake := infrastructure.NewFakePaymentProvider(testPaymentConfig())
+	provider := &failingRefundProvider{FakePaymentProvider: fake, failures: 1}
+	payments := NewPaymentService(provider, ledger, NewAuditService())
+	user := createTestUser(t, ledger, "refund@example.com", 0)
+	ctx := context.Background()
+
+	product := &models.PointsProduct{Name: "100积分", Points: 100, Price: 1000, Status: models.PointsProductStatusActive}
+	if err := query.PointsProduct.Create(product); err != nil {
+		t.Fatalf("创建积分套餐失败: %v", err)
+	}
+	order, _, err := payments.CreateOrder(ctx, user.ID, product.ID)
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	header, body, err := fake.Pay(order.OrderNo, order.PaymentID, order.Amount)
+	if err != nil {
+		t.Fatalf("Pay: %v", err)
+	}
+	if _, err := payments.HandleWebhook(ctx, header, body); err != nil {
+		t.Fatalf("HandleWebhook: %v", err)
+	}
+
+	// 渠道退款失败：积分已扣回，订单保持退款中
+	if _, err := payments.Refund(ctx, order.ID, "用户申请退款"); tools.GetCode(err) != http.StatusBadGateway {
+		t.Fatalf("Refund err = %v, want 502", err)
+	}
+	refunding := reloadTestOrder(t, order.ID)
+	if refunding.Status != models.PointsOrderStatusRefunding || refunding.RefundTransactionID == nil ||
+		refunding.RefundedAt != nil {
+		t.Fatalf("渠道退款失败后订单 = %+v, want 退款中且已记录扣回流水", refunding)
+	}
+	if points := *reloadTestUser(t, user.ID).Points; points != 0 {
+		t.Fatalf("points = %d, want 0", points)
+	}
+
+	// 重试：只调用渠道退款
+	refunded, err := payments.Refund(ctx, order.ID, "用户申请退款")
+	if err != nil {
+		t.Fatalf("重试 Refund: %v", err)
+	}
+	if refunded.Status != models.PointsOrderStatusRefunded || refunded.RefundedAt == nil {
+		t.Fatalf("重试后订单 = %+v, want 已退款", refunded)
+	}
+	// 已退款的订单直接返回，不再调用渠道
+	if _, err := payments.Refund(ctx, order.ID, "用户申请退款"); err != nil {
+		t.Fatalf("重复 Refund: %v", err)
+	}
+	if provider.refunds != 2 {
+		t.Fatalf("渠道退款调用 %d 次, want 2", provider.refunds)
+	}
+
+	got := reloadTestOrder(t, order.ID)
+	if got.Status != models.PointsOrderStatusRefunded || *got.RefundTransactionID != *refunding.RefundTransactionID {
+		t.Fatalf("订单 = %+v, want 已退款且扣回流水不变", got)
+	}
+	pt := query.PointsTransaction
+	count, err := pt.Where(pt.UserID.Eq(user.ID), pt.Reason.Eq(PointsReasonPurchaseRefund)).Count()
+	if err != nil {
+		t.Fatalf("查询流水失败: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("purchase_refund 流水 %d 笔, want 1", count)
+	}
+	if points := *reloadTestUser(t, user.ID).Points; points != 0 {
+		t.Fatalf("points = %d, want 0", points)
+	}
+	assertLedgerConsistent(t, user.ID, 2)
+}
+
+// TestPaymentWebhookSignature 签名错误或过期的回调返回 401（验签在查询订单之前，不需要数据库）
+func TestPaymentWebhookSignature(t *testing.T) {
+	cfg := testPaymentConfig()
+	payments := NewPaymentService(infrastructure.NewFakePaymentProvider(cfg), nil, NewAuditService())
+	body := []byte(`{"id":"evt_1","type":"payment.succeeded","order_no":"20260101080000K7M2Q9XD",` +
+		`"payment_id":"fake_20260101080000K7M2Q9XD","amount":1000}`)
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name      string
+		signature string
+	}{
+		{name: "缺少签名", signature: ""},
+		{name: "密钥错误", signature: infrastructure.SignPaymentWebhook("other-secret", now, body)},
+		{name: "签名过期", signature: infrastructure.SignPaymentWebhook(cfg.WebhookSecret, now-301, body)},
+		{name: "格式错误", signature: "t=abc,v1=00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(infrastructure.PaymentSignatureHeader, tt.signature)
+			order, err := payments.HandleWebhook(context.Background(), header, body)
+			if tools.GetCode(err) != http.StatusUnauthorized || order != nil {
+				t.Fatalf("HandleWebhook = %v, %v, want 401", order, err)
+			}
+		})
+	}
+}
+
+// TestPaymentFakeCheckoutEnabled 仅本地模拟支付渠道开放模拟支付接口
+func TestPaymentFakeCheckoutEnabled(t *testing.T) {
+	fake := NewPaymentService(infrastructure.NewFakePaymentProvider(testPaymentConfig()), nil, nil)
+	if !fake.FakeCheckoutEnabled() {
+		t.Fatal("fake 渠道 FakeCheckoutEnabled = false, want true")
+	}
+	provider, err := infrastructure.NewPaymentProvider(&config.PaymentConfig{})
+	if err != nil {
+		t.Fatalf("NewPaymentProvider: %v", err)
+	}
+	if disabled := NewPaymentService(provider, nil, nil); disabled.FakeCheckoutEnabled() {
+		t.Fatal("未配置渠道 FakeCheckoutEnabled = true, want false")
+	}
+}
+
+// reloadTestOrder 重新读取订单
+func reloadTestOrder(t *testing.T, id uint) *models.PointsOrder {
+	t.Helper()
+	order, err := query.PointsOrder.Where(query.PointsOrder.ID.Eq(id)).First()
+	if err != nil {
+		t.Fatalf("读取订单失败: %v", err)
+	}
+	return order
+}
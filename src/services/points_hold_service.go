@@ -0,0 +1,249 @@
+// Package services 积分预留服务
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// holdExpireBatch 每次扫描释放的过期预留数量
+const holdExpireBatch = 100
+
+// PointsHoldService 积分预留服务
+// 预留时积分计入用户的已预留积分（不可用但不扣除），扣除时才通过积分账本写入流水，释放或过期时只减少已预留积分
+type PointsHoldService struct {
+	ledger *PointsLedgerService
+}
+
+// NewPointsHoldService 创建积分预留服务
+func NewPointsHoldService(ledger *PointsLedgerService) *PointsHoldService {
+	return &PointsHoldService{
+		ledger: ledger,
+	}
+}
+
+// ReserveTx 在调用方事务中预留积分（可用积分不足时返回 ErrInsufficientPoints，ttl 后未结算自动释放）
+func (s *PointsHoldService) ReserveTx(
+	tx *query.Query,
+	userID uint,
+	points int,
+	reason, referenceID string,
+	ttl time.Duration,
+) (*models.PointsHold, error) {
+	if points <= 0 {
+		return nil, tools.ErrBadRequest("预留积分必须大于0")
+	}
+	if !IsPointsReason(reason) {
+		return nil, tools.ErrInternalServer("未登记的积分变动原因: " + reason)
+	}
+	user, err := lockUser(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// 已过期的积分不能预留
+	if err := s.ledger.expireDueLots(tx, user); err != nil {
+		return nil, err
+	}
+	if pointsValue(user.Points)-user.HeldPoints < points {
+		return nil, ErrInsufficientPoints()
+	}
+
+	// 条件更新兜底：可用积分不足时不生效
+	result, err := tx.User.
+		Where(tx.User.ID.Eq(userID), field.NewUnsafeFieldRaw("COALESCE(`points`, 0) - `held_points` >= ?", points)).
+		UpdateSimple(tx.User.HeldPoints.Add(points))
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInsufficientPoints()
+	}
+
+	hold := &models.PointsHold{
+		UserID:      userID,
+		Points:      points,
+		Reason:      reason,
+		ReferenceID: referenceID,
+		Status:      models.PointsHoldStatusHeld,
+		ExpiresAt:   time.Now().Add(ttl).Unix(),
+	}
+	if err := tx.PointsHold.Create(hold); err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// Capture 扣除预留积分：points 为实际扣除值（不超过预留值，为 nil 时全额扣除），剩余部分释放
+// 重复扣除同一预留直接返回结果；已释放或已过期的预留返回 409
+func (s *PointsHoldService) Capture(ctx context.Context, holdID uint, points *int) (*models.PointsHold, error) {
+	return s.settle(holdID, models.PointsHoldStatusCaptured, func(tx *query.Query, user *models.User, hold *models.PointsHold) error {
+		amount := hold.Points
+		if points != nil {
+			amount = *points
+		}
+		if amount < 0 || amount > hold.Points {
+			return tools.ErrBadRequest("扣除积分不能超过预留积分")
+		}
+		hold.CapturedPoints = amount
+		if amount == 0 {
+			return nil
+		}
+		txn, err := s.ledger.apply(ctx, tx, user, -amount, hold.Reason, hold.ReferenceID)
+		if err != nil {
+			return err
+		}
+		hold.TransactionID = &txn.ID
+		return nil
+	})
+}
+
+// Release 释放预留积分并记录原因（重复释放直接返回结果；已扣除的预留返回 409）
+func (s *PointsHoldService) Release(holdID uint, reason string) (*models.PointsHold, error) {
+	return s.settle(holdID, models.PointsHoldStatusReleased, func(_ *query.Query, _ *models.User, hold *models.PointsHold) error {
+		hold.ReleaseReason = truncate(reason, 255)
+		return nil
+	})
+}
+
+// Extend 延长未结算预留的有效期（已结算的预留不受影响）
+func (s *PointsHoldService) Extend(holdID uint, ttl time.Duration) error {
+	h := query.PointsHold
+	_, err := h.Where(h.ID.Eq(holdID), h.Status.Eq(models.PointsHoldStatusHeld)).
+		UpdateSimple(h.ExpiresAt.Value(time.Now().Add(ttl).Unix()))
+	return err
+}
+
+// ExpireDue 释放已过期的预留，返回释放数量
+func (s *PointsHoldService) ExpireDue() (int, error) {
+	h := query.PointsHold
+	holds, err := h.Select(h.ID).
+		Where(h.Status.Eq(models.PointsHoldStatusHeld), h.ExpiresAt.Lte(time.Now().Unix())).
+		Order(h.ExpiresAt).
+		Limit(holdExpireBatch).
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, hold := range holds {
+		_, err := s.settle(hold.ID, models.PointsHoldStatusExpired, func(_ *query.Query, _ *models.User, held *models.PointsHold) error {
+			held.ReleaseReason = "预留超时未结算"
+			return nil
+		})
+		if err != nil {
+			tools.Logf("积分预留过期释放失败 hold=%d: %v", hold.ID, err)
+			continue
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// RunExpiry 定期释放过期预留（阻塞运行，ctx 结束时退出）
+func (s *PointsHoldService) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ExpireDue(); err != nil {
+				tools.Logf("积分预留过期扫描失败: %v", err)
+			}
+		}
+	}
+}
+
+// settle 结算预留：锁定用户和预留，减少已预留积分，由 fn 完成扣除或记录原因后更新预留状态
+// 预留已过期（即使尚未被扫描释放）时只能以过期状态结算，未过期时不能以过期状态结算
+func (s *PointsHoldService) settle(
+	holdID uint,
+	status int8,
+	fn func(tx *query.Query, user *models.User, hold *models.PointsHold) error,
+) (*models.PointsHold, error) {
+	var result *models.PointsHold
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		hold, err := tx.PointsHold.Where(tx.PointsHold.ID.Eq(holdID)).First()
+		if err == gorm.ErrRecordNotFound {
+			return tools.ErrNotFound("积分预留不存在")
+		}
+		if err != nil {
+			return err
+		}
+		// 锁定顺序与预留时一致：先用户后预留
+		user, err := lockUser(tx, hold.UserID)
+		if err != nil {
+			return err
+		}
+		hold, err = tx.PointsHold.Clauses(clause.Locking{Strength: "UPDATE"}).Where(tx.PointsHold.ID.Eq(holdID)).First()
+		if err != nil {
+			return err
+		}
+		result = hold
+
+		if hold.Status != models.PointsHoldStatusHeld {
+			if hold.Status == status {
+				return nil
+			}
+			return tools.ErrConflict("积分预留已结算")
+		}
+		now := time.Now().Unix()
+		if status != models.PointsHoldStatusExpired && hold.ExpiresAt <= now {
+			return tools.ErrConflict("积分预留已过期")
+		}
+		// 扫描后、加锁前被延长的预留不再过期
+		if status == models.PointsHoldStatusExpired && hold.ExpiresAt > now {
+			return tools.ErrConflict("积分预留未过期")
+		}
+
+		if _, err := tx.User.Where(tx.User.ID.Eq(user.ID)).UpdateSimple(tx.User.HeldPoints.Sub(hold.Points)); err != nil {
+			return err
+		}
+		user.HeldPoints -= hold.Points
+		if err := fn(tx, user, hold); err != nil {
+			return err
+		}
+
+		hold.Status = status
+		hold.SettledAt = &now
+		_, err = tx.PointsHold.Where(tx.PointsHold.ID.Eq(hold.ID)).Updates(map[string]interface{}{
+			"status":          hold.Status,
+			"captured_points": hold.CapturedPoints,
+			"release_reason":  hold.ReleaseReason,
+			"transaction_id":  hold.TransactionID,
+			"settled_at":      hold.SettledAt,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, wrapHoldError(err)
+	}
+	return result, nil
+}
+
+// wrapHoldError 转换事务中的错误
+func wrapHoldError(err error) error {
+	if appErr, ok := err.(*tools.AppError); ok {
+		return appErr
+	}
+	tools.Logf("积分预留结算失败: %v", err)
+	return tools.ErrInternalServer("积分预留结算失败")
+}
+
+// truncate 按字符截断字符串
+func truncate(value string, maxLen int) string {
+	runes := []rune(value)
+	if len(runes) <= maxLen {
+		return value
+	}
+	return string(runes[:maxLen])
+}
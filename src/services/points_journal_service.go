@@ -0,0 +1,238 @@
+// Package services 积分变更日志服务（哈希链）
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// journalHeadID 链头固定行ID
+	journalHeadID = 1
+	// journalVerifyBatch 校验时每批读取的行数
+	journalVerifyBatch = 1000
+	// journalCheckpointSubject 检查点令牌的 sub
+	journalCheckpointSubject = "points_journal"
+	// JournalCheckpointType 检查点令牌的 typ（访问令牌验签时拒绝）
+	JournalCheckpointType = "points-checkpoint"
+	// JournalCheckpointAudience 检查点令牌的 aud
+	JournalCheckpointAudience = "points-journal-verifier"
+	// JournalGenesisHash 第一行的 prev_hash
+	JournalGenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+// JournalCheckpoint 链头检查点（Signature 为使用 JWT 签名密钥签发的令牌，可通过 JWKS 验签）
+type JournalCheckpoint struct {
+	Seq       uint64
+	Hash      string
+	CreatedAt int64
+	Signature string
+}
+
+// journalCheckpointClaims 检查点令牌 Claims
+type journalCheckpointClaims struct {
+	Type string `json:"typ"`
+	Seq  uint64 `json:"seq"`
+	Hash string `json:"hash"`
+	jwt.RegisteredClaims
+}
+
+// JournalVerifyResult 哈希链校验结果
+type JournalVerifyResult struct {
+	Checked  uint64 // 已校验的行数
+	HeadSeq  uint64 // 最后一行序号
+	HeadHash string // 最后一行哈希
+	BrokenAt uint64 // 第一个断开的序号（0 表示完整）
+	Problem  string // 断开原因
+}
+
+// OK 哈希链是否完整
+func (r *JournalVerifyResult) OK() bool {
+	return r.BrokenAt == 0 && r.Problem == ""
+}
+
+// PointsJournalService 积分变更日志服务
+type PointsJournalService struct {
+	signer *JWTSigner
+}
+
+// NewPointsJournalService 创建积分变更日志服务（signer 仅签发检查点时需要）
+func NewPointsJournalService(signer *JWTSigner) *PointsJournalService {
+	return &PointsJournalService{
+		signer: signer,
+	}
+}
+
+// Append 在事务中追加一条积分变更（锁定链头，保证序号连续、哈希链不分叉）
+func (s *PointsJournalService) Append(
+	ctx context.Context,
+	tx *query.Query,
+	userID uint,
+	before, after *int,
+	reason string,
+) error {
+	head, err := tx.PointsJournalHead.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(tx.PointsJournalHead.ID.Eq(journalHeadID)).
+		First()
+	if err == gorm.ErrRecordNotFound {
+		return tools.ErrInternalServer("积分日志未初始化")
+	}
+	if err != nil {
+		return err
+	}
+
+	actor := AuditActorFromContext(ctx)
+	entry := &models.PointsJournal{
+		Seq:          head.Seq + 1,
+		UserID:       userID,
+		Delta:        pointsValue(after) - pointsValue(before),
+		PointsBefore: before,
+		PointsAfter:  after,
+		Reason:       reason,
+		ActorID:      actor.ID,
+		ActorRole:    actor.Role,
+		RequestID:    actor.RequestID,
+		PrevHash:     head.Hash,
+		CreatedAt:    time.Now().Unix(),
+	}
+	entry.Hash = JournalHash(entry)
+
+	if err := tx.PointsJournal.Create(entry); err != nil {
+		return err
+	}
+	_, err = tx.PointsJournalHead.
+		Where(tx.PointsJournalHead.ID.Eq(journalHeadID)).
+		UpdateSimple(tx.PointsJournalHead.Seq.Value(entry.Seq), tx.PointsJournalHead.Hash.Value(entry.Hash))
+	return err
+}
+
+// Verify 从第一行开始逐行校验哈希链，返回第一个断开的位置
+// 检查序号连续、prev_hash 与上一行一致、本行哈希与内容一致，最后核对链头
+func (s *PointsJournalService) Verify() (*JournalVerifyResult, error) {
+	result := &JournalVerifyResult{HeadHash: JournalGenesisHash}
+	for {
+		rows, err := query.PointsJournal.
+			Where(query.PointsJournal.Seq.Gt(result.HeadSeq)).
+			Order(query.PointsJournal.Seq).
+			Limit(journalVerifyBatch).
+			Find()
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			expected := result.HeadSeq + 1
+			switch {
+			case row.Seq != expected:
+				result.BrokenAt, result.Problem = expected, fmt.Sprintf("缺少序号 %d（下一行为 %d）", expected, row.Seq)
+			case row.PrevHash != result.HeadHash:
+				result.BrokenAt, result.Problem = row.Seq, "prev_hash 与上一行哈希不一致"
+			case row.Hash != JournalHash(row):
+				result.BrokenAt, result.Problem = row.Seq, "本行内容与哈希不一致（内容被修改）"
+			}
+			if !result.OK() {
+				return result, nil
+			}
+			result.Checked++
+			result.HeadSeq, result.HeadHash = row.Seq, row.Hash
+		}
+		if len(rows) < journalVerifyBatch {
+			break
+		}
+	}
+
+	head, err := query.PointsJournalHead.Where(query.PointsJournalHead.ID.Eq(journalHeadID)).First()
+	if err != nil {
+		return nil, err
+	}
+	if head.Seq != result.HeadSeq || head.Hash != result.HeadHash {
+		result.BrokenAt = result.HeadSeq + 1
+		result.Problem = fmt.Sprintf("链头（序号 %d）与最后一行（序号 %d）不一致，日志末尾可能被删除", head.Seq, result.HeadSeq)
+	}
+	return result, nil
+}
+
+// Checkpoint 导出当前链头的签名检查点（外部保存后，可证明此前的日志未被改写或截断）
+// 只使用非对称密钥签名：HS256 共享密钥签发的检查点可被任何持有 jwt.secret 的一方伪造，不具备证明作用
+func (s *PointsJournalService) Checkpoint() (*JournalCheckpoint, error) {
+	if !s.CheckpointEnabled() {
+		return nil, tools.ErrServiceUnavailable("未配置非对称签名密钥（jwt.checkpoint_key_id 或 jwt.signing_key_id），无法导出检查点")
+	}
+	head, err := query.PointsJournalHead.Where(query.PointsJournalHead.ID.Eq(journalHeadID)).First()
+	if err != nil {
+		return nil, tools.ErrInternalServer("积分日志查询失败")
+	}
+
+	now := time.Now()
+	signature, err := s.signer.SignCheckpoint(&journalCheckpointClaims{
+		Type: JournalCheckpointType,
+		Seq:  head.Seq,
+		Hash: head.Hash,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  journalCheckpointSubject,
+			Audience: jwt.ClaimStrings{JournalCheckpointAudience},
+			IssuedAt: jwt.NewNumericDate(now),
+		},
+	})
+	if err != nil {
+		return nil, tools.ErrInternalServer("检查点签名失败")
+	}
+	return &JournalCheckpoint{
+		Seq:       head.Seq,
+		Hash:      head.Hash,
+		CreatedAt: now.Unix(),
+		Signature: signature,
+	}, nil
+}
+
+// CheckpointEnabled 是否可以导出检查点（已配置 RS256/EdDSA 签名密钥）
+func (s *PointsJournalService) CheckpointEnabled() bool {
+	return s.signer != nil && s.signer.CanSignCheckpoint()
+}
+
+// JournalHash 计算日志行哈希：SHA-256(各字段以 | 连接，最后为 prev_hash)
+func JournalHash(entry *models.PointsJournal) string {
+	content := strings.Join([]string{
+		strconv.FormatUint(entry.Seq, 10),
+		strconv.FormatUint(uint64(entry.UserID), 10),
+		strconv.Itoa(entry.Delta),
+		nullablePoints(entry.PointsBefore),
+		nullablePoints(entry.PointsAfter),
+		entry.Reason,
+		strconv.FormatUint(uint64(entry.ActorID), 10),
+		entry.ActorRole,
+		entry.RequestID,
+		strconv.FormatInt(entry.CreatedAt, 10),
+		entry.PrevHash,
+	}, "|")
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// pointsValue 积分值（NULL 视为 0）
+func pointsValue(points *int) int {
+	if points == nil {
+		return 0
+	}
+	return *points
+}
+
+// nullablePoints 积分的哈希表示（NULL 与 0 区分）
+func nullablePoints(points *int) string {
+	if points == nil {
+		return "null"
+	}
+	return strconv.Itoa(*points)
+}
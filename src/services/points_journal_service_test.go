@@ -0,0 +1,164 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeEd25519Key 生成 Ed25519 私钥 PEM 文件
+func writeEd25519Key(t *testing.T) string {
+	t.Helper()
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("编码密钥失败: %v", err)
+	}
+	file := filepath.Join(t.TempDir(), "jwt.pem")
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("写入密钥失败: %v", err)
+	}
+	return file
+}
+
+func TestCheckpointRequiresAsymmetricKey(t *testing.T) {
+	signer, err := NewJWTSigner(&config.JWTConfig{Secret: "shared-secret"})
+	if err != nil {
+		t.Fatalf("NewJWTSigner: %v", err)
+	}
+	journal := NewPointsJournalService(signer)
+	if journal.CheckpointEnabled() {
+		t.Fatal("HS256 共享密钥不应允许导出检查点")
+	}
+	if _, err := journal.Checkpoint(); tools.GetCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want 503", err)
+	}
+	if NewPointsJournalService(nil).CheckpointEnabled() {
+		t.Fatal("未配置签名器不应允许导出检查点")
+	}
+
+	signer, err = NewJWTSigner(&config.JWTConfig{
+		Secret:       "shared-secret",
+		SigningKeyID: "k1",
+		Keys: []config.JWTKeyConfig{{
+			ID:             "k1",
+			Algorithm:      JWTAlgorithmEdDSA,
+			PrivateKeyFile: writeEd25519Key(t),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("NewJWTSigner: %v", err)
+	}
+	if !NewPointsJournalService(signer).CheckpointEnabled() {
+		t.Fatal("配置 EdDSA 签名密钥后应允许导出检查点")
+	}
+}
+
+// checkpointToken 按 Checkpoint 的格式签发检查点令牌
+func checkpointToken(t *testing.T, signer *JWTSigner) string {
+	t.Helper()
+	token, err := signer.SignCheckpoint(&journalCheckpointClaims{
+		Type: JournalCheckpointType,
+		Seq:  1,
+		Hash: JournalGenesisHash,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  journalCheckpointSubject,
+			Audience: jwt.ClaimStrings{JournalCheckpointAudience},
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	})
+	if err != nil {
+		t.Fatalf("SignCheckpoint: %v", err)
+	}
+	return token
+}
+
+// accessClaims 访问令牌 Claims
+func accessClaims() *Claims {
+	now := time.Now()
+	return &Claims{
+		Type:   TokenTypeAccess,
+		UserID: 1,
+		Role:   RoleUser,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "sid",
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+}
+
+func TestCheckpointTokenIsNotAccessToken(t *testing.T) {
+	jwtCfg := &config.JWTConfig{
+		Secret:       "shared-secret",
+		SigningKeyID: "k1",
+		Keys: []config.JWTKeyConfig{{
+			ID:             "k1",
+			Algorithm:      JWTAlgorithmEdDSA,
+			PrivateKeyFile: writeEd25519Key(t),
+		}},
+	}
+	signer, err := NewJWTSigner(jwtCfg)
+	if err != nil {
+		t.Fatalf("NewJWTSigner: %v", err)
+	}
+	auth := NewAuthService(jwtCfg, signer, nil, nil, nil, nil, nil)
+
+	access, err := signer.Sign(accessClaims())
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if _, err := auth.VerifyToken(access); err != nil {
+		t.Fatalf("访问令牌验签失败: %v", err)
+	}
+	// 与访问令牌共用签名密钥时按 typ 拒绝
+	if _, err := auth.VerifyToken(checkpointToken(t, signer)); err == nil {
+		t.Fatal("检查点令牌不应通过访问令牌验签")
+	}
+	// 升级前签发的检查点（无 typ、无 exp）
+	legacy, err := signer.Sign(&journalCheckpointClaims{Seq: 1, Hash: JournalGenesisHash,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: journalCheckpointSubject}})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if _, err := auth.VerifyToken(legacy); err == nil {
+		t.Fatal("无 exp 的令牌不应通过访问令牌验签")
+	}
+
+	// 独立的检查点密钥：即使载荷伪装成访问令牌也拒绝
+	jwtCfg.CheckpointKeyID = "k2"
+	jwtCfg.Keys = append(jwtCfg.Keys, config.JWTKeyConfig{
+		ID:             "k2",
+		Algorithm:      JWTAlgorithmEdDSA,
+		PrivateKeyFile: writeEd25519Key(t),
+	})
+	signer, err = NewJWTSigner(jwtCfg)
+	if err != nil {
+		t.Fatalf("NewJWTSigner: %v", err)
+	}
+	auth = NewAuthService(jwtCfg, signer, nil, nil, nil, nil, nil)
+	token, _, err := jwt.NewParser().ParseUnverified(checkpointToken(t, signer), &journalCheckpointClaims{})
+	if err != nil || token.Header["kid"] != "k2" {
+		t.Fatalf("检查点应使用 k2 签名: kid = %v err = %v", token.Header["kid"], err)
+	}
+	forged, err := signer.SignCheckpoint(accessClaims())
+	if err != nil {
+		t.Fatalf("SignCheckpoint: %v", err)
+	}
+	if _, err := auth.VerifyToken(forged); err == nil {
+		t.Fatal("检查点密钥签发的令牌不应通过访问令牌验签")
+	}
+}
@@ -0,0 +1,233 @@
+// Package services 积分批次（有效期与先进先出扣除）
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"gorm.io/gorm/clause"
+)
+
+// lotExpireBatch 过期扫描每批读取的批次数量
+const lotExpireBatch = 100
+
+// ExpiringPoints 即将过期的积分
+type ExpiringPoints struct {
+	Points    int    // 即将过期的积分合计
+	ExpiresAt *int64 // 最早的过期时间（没有即将过期的积分时为 nil）
+	Days      int    // 统计范围（天）
+}
+
+// ExpiringSoon 统计用户在配置的天数内将过期的积分（已预留的积分也计入）
+func (s *PointsLedgerService) ExpiringSoon(userID uint) (*ExpiringPoints, error) {
+	now := time.Now()
+	days := s.cfg.ExpiringSoonDays
+	var row struct {
+		Points    *int
+		ExpiresAt *int64
+	}
+	l := query.PointsLot
+	err := l.Select(l.Remaining.Sum().As("points"), l.ExpiresAt.Min().As("expires_at")).
+		Where(
+			l.UserID.Eq(userID),
+			l.Remaining.Gt(0),
+			l.ExpiresAt.Gt(now.Unix()),
+			l.ExpiresAt.Lte(now.AddDate(0, 0, days).Unix()),
+		).
+		Scan(&row)
+	if err != nil {
+		return nil, tools.ErrInternalServer("积分有效期查询失败")
+	}
+	return &ExpiringPoints{
+		Points:    pointsValue(row.Points),
+		ExpiresAt: row.ExpiresAt,
+		Days:      days,
+	}, nil
+}
+
+// ExpireLots 扣除已过期批次的剩余积分，每个批次一笔 expire 流水，返回处理的批次数量
+func (s *PointsLedgerService) ExpireLots(ctx context.Context) (int, error) {
+	now := time.Now().Unix()
+	l := query.PointsLot
+	var lastID uint
+	expired := 0
+	for {
+		lots, err := l.Select(l.ID).
+			Where(l.ID.Gt(lastID), l.Remaining.Gt(0), l.ExpiresAt.Lte(now)).
+			Order(l.ID).
+			Limit(lotExpireBatch).
+			Find()
+		if err != nil {
+			return expired, err
+		}
+		for _, lot := range lots {
+			lastID = lot.ID
+			ok, err := s.expireLot(ctx, lot.ID, now)
+			if err != nil {
+				tools.Logf("积分批次过期处理失败 lot=%d: %v", lot.ID, err)
+				continue
+			}
+			if ok {
+				expired++
+			}
+		}
+		if len(lots) < lotExpireBatch {
+			return expired, nil
+		}
+	}
+}
+
+// RunLotExpiry 定期处理过期批次（阻塞运行，ctx 结束时退出）
+func (s *PointsLedgerService) RunLotExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ExpireLots(ctx); err != nil {
+				tools.Logf("积分批次过期扫描失败: %v", err)
+			}
+		}
+	}
+}
+
+// expireLot 扣除一个过期批次的剩余积分
+func (s *PointsLedgerService) expireLot(ctx context.Context, lotID uint, now int64) (bool, error) {
+	expired := false
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		lot, err := tx.PointsLot.Where(tx.PointsLot.ID.Eq(lotID)).First()
+		if err != nil {
+			return err
+		}
+		// 锁定顺序与积分变动一致：先用户后批次
+		user, err := lockUser(tx, lot.UserID)
+		if err != nil {
+			return err
+		}
+		lot, err = tx.PointsLot.Clauses(clause.Locking{Strength: "UPDATE"}).Where(tx.PointsLot.ID.Eq(lotID)).First()
+		if err != nil {
+			return err
+		}
+		expired, err = s.expireLotTx(ctx, tx, user, lot, now)
+		return err
+	})
+	return expired, err
+}
+
+// expireDueLots 在调用方事务中扣除用户已到期但尚未被扫描处理的批次（调用方已锁定用户）
+// 扣除和预留积分前调用，保证余额校验和先进先出扣除都不会动用已过期的积分；
+// 扣除预留时不调用（预留对应的积分不过期，且结算时已先减少了已预留积分）
+func (s *PointsLedgerService) expireDueLots(tx *query.Query, user *models.User) error {
+	now := time.Now().Unix()
+	l := tx.PointsLot
+	lots, err := l.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(l.UserID.Eq(user.ID), l.Remaining.Gt(0), l.ExpiresAt.Lte(now)).
+		Order(l.ID).
+		Find()
+	if err != nil {
+		return err
+	}
+	for _, lot := range lots {
+		// 过期扣除是系统行为，不记录当前请求的操作人
+		if _, err := s.expireLotTx(context.Background(), tx, user, lot, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expireLotTx 扣除已锁定批次的过期积分（调用方已锁定用户和批次）
+// 已预留的积分不过期（保证预留可以扣除）：只扣除不超过可用积分的部分，其余留在批次中，预留释放后的扫描再扣除
+func (s *PointsLedgerService) expireLotTx(
+	ctx context.Context,
+	tx *query.Query,
+	user *models.User,
+	lot *models.PointsLot,
+	now int64,
+) (bool, error) {
+	if lot.Remaining <= 0 || !lotExpired(lot, now) {
+		return false, nil
+	}
+	amount := min(lot.Remaining, pointsValue(user.Points)-user.HeldPoints)
+	if amount <= 0 {
+		return false, nil
+	}
+	referenceID := "points_lot:" + strconv.FormatUint(uint64(lot.ID), 10)
+	if _, err := s.apply(ctx, tx, user, -amount, PointsReasonExpire, referenceID); err != nil {
+		return false, err
+	}
+	_, err := tx.PointsLot.Where(tx.PointsLot.ID.Eq(lot.ID)).UpdateSimple(
+		tx.PointsLot.Remaining.Sub(amount),
+		tx.PointsLot.ExpiredPoints.Add(amount),
+		tx.PointsLot.ExpiredAt.Value(now),
+	)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// addLot 为获得的积分创建批次（按变动原因计算有效期）
+func (s *PointsLedgerService) addLot(tx *query.Query, userID uint, points int, reason string, txnID uint) error {
+	lot := &models.PointsLot{
+		UserID:        userID,
+		Reason:        reason,
+		TransactionID: txnID,
+		Points:        points,
+		Remaining:     points,
+	}
+	if days := s.cfg.ExpireDaysFor(reason); days > 0 {
+		expiresAt := time.Now().AddDate(0, 0, days).Unix()
+		lot.ExpiresAt = &expiresAt
+	}
+	return tx.PointsLot.Create(lot)
+}
+
+// consumeLots 按先进先出从批次中扣除积分：先扣未过期的批次（按获得顺序），不足时再扣已过期的批次
+// （扣除前已由 expireDueLots 处理到期批次，剩下的已过期积分只可能是因预留而保留的部分，供扣除预留时使用）
+// 余额以 a_users.points 为准，批次不足（数据不一致）时只记录日志
+func (s *PointsLedgerService) consumeLots(tx *query.Query, userID uint, amount int) error {
+	now := time.Now().Unix()
+	l := tx.PointsLot
+	lots, err := l.Where(l.UserID.Eq(userID), l.Remaining.Gt(0)).Order(l.ID).Find()
+	if err != nil {
+		return err
+	}
+	ordered := make([]*models.PointsLot, 0, len(lots))
+	for _, lot := range lots {
+		if !lotExpired(lot, now) {
+			ordered = append(ordered, lot)
+		}
+	}
+	for _, lot := range lots {
+		if lotExpired(lot, now) {
+			ordered = append(ordered, lot)
+		}
+	}
+
+	for _, lot := range ordered {
+		if amount == 0 {
+			break
+		}
+		take := min(lot.Remaining, amount)
+		if _, err := l.Where(l.ID.Eq(lot.ID)).UpdateSimple(l.Remaining.Sub(take)); err != nil {
+			return err
+		}
+		amount -= take
+	}
+	if amount > 0 {
+		tools.Logf("积分批次不足 user=%d 缺少 %d", userID, amount)
+	}
+	return nil
+}
+
+// lotExpired 批次是否已到期
+func lotExpired(lot *models.PointsLot, now int64) bool {
+	return lot.ExpiresAt != nil && *lot.ExpiresAt <= now
+}
@@ -0,0 +1,317 @@
+// Package services 积分账本服务
+package services
+
+import (
+	"context"
+
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 积分变动原因
+const (
+	// PointsReasonAdminGrant 管理员增加积分
+	PointsReasonAdminGrant = "admin_grant"
+	// PointsReasonAdminDeduct 管理员扣减积分
+	PointsReasonAdminDeduct = "admin_deduct"
+	// PointsReasonProcessImage 调用图片处理接口
+	PointsReasonProcessImage = "process_image"
+	// PointsReasonProcessVideo 调用视频处理接口
+	PointsReasonProcessVideo = "process_video"
+	// PointsReasonRefund 退还积分
+	PointsReasonRefund = "refund"
+	// PointsReasonPurchase 购买积分
+	PointsReasonPurchase = "purchase"
+	// PointsReasonPurchaseRefund 积分订单退款扣回
+	PointsReasonPurchaseRefund = "purchase_refund"
+	// PointsReasonRedeem 兑换码兑换积分
+	PointsReasonRedeem = "redeem"
+	// PointsReasonSignupBonus 注册奖励
+	PointsReasonSignupBonus = "signup_bonus"
+	// PointsReasonCheckIn 每日签到奖励
+	PointsReasonCheckIn = "check_in"
+	// PointsReasonReferralBonus 邀请奖励（邀请人）
+	PointsReasonReferralBonus = "referral_bonus"
+	// PointsReasonInviteeBonus 受邀注册奖励（被邀请人）
+	PointsReasonInviteeBonus = "invitee_bonus"
+	// PointsReasonExpire 积分过期
+	PointsReasonExpire = "expire"
+)
+
+// 系统账户（流水的另一方）
+const (
+	// AccountSystemAdmin 管理员调整
+	AccountSystemAdmin = "system:admin"
+	// AccountSystemUsage 接口调用消耗与退还
+	AccountSystemUsage = "system:usage"
+	// AccountSystemSales 积分销售
+	AccountSystemSales = "system:sales"
+	// AccountSystemPromotion 营销活动赠送
+	AccountSystemPromotion = "system:promotion"
+	// AccountSystemExpiry 积分过期
+	AccountSystemExpiry = "system:expiry"
+)
+
+// pointsCounterAccounts 各变动原因对应的系统账户（新增原因时需在此登记）
+var pointsCounterAccounts = map[string]string{ //nolint:gochecknoglobals // 只读映射
+	PointsReasonAdminGrant:     AccountSystemAdmin,
+	PointsReasonAdminDeduct:    AccountSystemAdmin,
+	PointsReasonProcessImage:   AccountSystemUsage,
+	PointsReasonProcessVideo:   AccountSystemUsage,
+	PointsReasonRefund:         AccountSystemUsage,
+	PointsReasonPurchase:       AccountSystemSales,
+	PointsReasonPurchaseRefund: AccountSystemSales,
+	PointsReasonRedeem:         AccountSystemPromotion,
+	PointsReasonSignupBonus:    AccountSystemPromotion,
+	PointsReasonCheckIn:        AccountSystemPromotion,
+	PointsReasonReferralBonus:  AccountSystemPromotion,
+	PointsReasonInviteeBonus:   AccountSystemPromotion,
+	PointsReasonExpire:         AccountSystemExpiry,
+}
+
+// IsPointsReason 是否为已登记的积分变动原因
+func IsPointsReason(reason string) bool {
+	_, ok := pointsCounterAccounts[reason]
+	return ok
+}
+
+// PointsLedgerService 积分账本服务
+// 用户积分余额只能通过本服务变更：锁定用户行、更新余额、写入流水和哈希链日志、维护积分批次在同一事务中完成
+type PointsLedgerService struct {
+	journal *PointsJournalService
+	audit   *AuditService
+	cfg     *config.PointsConfig
+}
+
+// NewPointsLedgerService 创建积分账本服务
+func NewPointsLedgerService(
+	journal *PointsJournalService,
+	audit *AuditService,
+	cfg *config.PointsConfig,
+) *PointsLedgerService {
+	return &PointsLedgerService{
+		journal: journal,
+		audit:   audit,
+		cfg:     cfg,
+	}
+}
+
+// ErrInsufficientPoints 积分不足（402，与数据库错误区分）
+func ErrInsufficientPoints() *tools.AppError {
+	return tools.ErrPaymentRequired("积分不足")
+}
+
+// Apply 变动用户积分（delta 为正增加、为负扣除，余额不足时返回 ErrInsufficientPoints）
+func (s *PointsLedgerService) Apply(
+	ctx context.Context,
+	userID uint,
+	delta int,
+	reason, referenceID string,
+) (*models.PointsTransaction, error) {
+	var txn *models.PointsTransaction
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		var err error
+		txn, err = s.ApplyTx(ctx, tx, userID, delta, reason, referenceID)
+		return err
+	})
+	if err != nil {
+		return nil, wrapLedgerError(err)
+	}
+	return txn, nil
+}
+
+// ApplyTx 在调用方事务中变动用户积分（供需要与其他数据一起提交的业务使用）
+func (s *PointsLedgerService) ApplyTx(
+	ctx context.Context,
+	tx *query.Query,
+	userID uint,
+	delta int,
+	reason, referenceID string,
+) (*models.PointsTransaction, error) {
+	if delta == 0 {
+		return nil, tools.ErrBadRequest("积分变动值不能为0")
+	}
+	user, err := lockUser(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// 扣除前先处理已到期的批次，已过期的积分不能再被使用
+	if delta < 0 {
+		if err := s.expireDueLots(tx, user); err != nil {
+			return nil, err
+		}
+	}
+	return s.apply(ctx, tx, user, delta, reason, referenceID)
+}
+
+// SetBalance 管理员将用户积分调整为指定值（nil 视为 0），按差额记一笔 admin_grant 或 admin_deduct 流水
+func (s *PointsLedgerService) SetBalance(ctx context.Context, userID uint, points *int) error {
+	target := pointsValue(points)
+	if target < 0 {
+		return tools.ErrBadRequest("积分不能为负数")
+	}
+
+	var before int
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		user, err := lockUser(tx, userID)
+		if err != nil {
+			return err
+		}
+		// 先处理已到期的批次，按过期后的余额计算差额
+		if err := s.expireDueLots(tx, user); err != nil {
+			return err
+		}
+		before = pointsValue(user.Points)
+		delta := target - before
+		if delta == 0 {
+			return nil
+		}
+		reason := PointsReasonAdminGrant
+		if delta < 0 {
+			reason = PointsReasonAdminDeduct
+		}
+		_, err = s.apply(ctx, tx, user, delta, reason, "")
+		return err
+	})
+	if err != nil {
+		return wrapLedgerError(err)
+	}
+	if before != target {
+		s.audit.Record(ctx, AuditActionUserPointsUpdate, AuditTargetUser, userID,
+			AuditFields{"points": before}, AuditFields{"points": target})
+	}
+	return nil
+}
+
+// History 获取用户本人的积分流水（按时间倒序）
+func (s *PointsLedgerService) History(
+	userID uint,
+	queryReq *dto.PointsHistoryRequest,
+) ([]*models.PointsTransaction, int64, error) {
+	t := query.PointsTransaction
+	conditions := tools.NewConditionBuilder().
+		EqString(&t.Reason, queryReq.Reason).
+		GteInt64(&t.CreatedAt, queryReq.CreatedAtMin).
+		LteInt64(&t.CreatedAt, queryReq.CreatedAtMax).
+		Build()
+
+	txns, count, err := t.Where(t.UserID.Eq(userID)).Where(conditions...).
+		Order(t.ID.Desc()).
+		FindByPage(queryReq.GetOffset(), queryReq.GetLimit())
+	if err != nil {
+		return nil, 0, tools.ErrInternalServer("积分流水查询失败")
+	}
+	return txns, count, nil
+}
+
+// List 查询积分流水（管理员权限，条件查询、范围查询，按时间倒序）
+func (s *PointsLedgerService) List(
+	queryReq *dto.PointsTransactionListQueryRequest,
+) ([]*models.PointsTransaction, int64, error) {
+	t := query.PointsTransaction
+	conditions := tools.NewConditionBuilder().
+		EqUint(&t.UserID, queryReq.UserID).
+		EqString(&t.Reason, queryReq.Reason).
+		EqString(&t.ReferenceID, queryReq.ReferenceID).
+		EqUint(&t.ActorID, queryReq.ActorID).
+		GteInt64(&t.CreatedAt, queryReq.CreatedAtMin).
+		LteInt64(&t.CreatedAt, queryReq.CreatedAtMax).
+		Build()
+
+	txns, count, err := t.Where(conditions...).
+		Order(t.ID.Desc()).
+		FindByPage(queryReq.GetOffset(), queryReq.GetLimit())
+	if err != nil {
+		return nil, 0, tools.ErrInternalServer("积分流水查询失败")
+	}
+	return txns, count, nil
+}
+
+// apply 对已锁定的用户写入一笔流水并更新余额
+func (s *PointsLedgerService) apply(
+	ctx context.Context,
+	tx *query.Query,
+	user *models.User,
+	delta int,
+	reason, referenceID string,
+) (*models.PointsTransaction, error) {
+	counterAccount, ok := pointsCounterAccounts[reason]
+	if !ok {
+		return nil, tools.ErrInternalServer("未登记的积分变动原因: " + reason)
+	}
+	before := pointsValue(user.Points)
+	balance := before + delta
+	// 扣除不能动用已预留的积分
+	if balance < 0 || (delta < 0 && balance-user.HeldPoints < 0) {
+		return nil, ErrInsufficientPoints()
+	}
+
+	// 条件更新：余额在数据库中原子地增减，且只有变动后不为负（扣除时不低于已预留积分）才生效
+	// 行锁已保证串行，条件兜底防止绕过锁的并发写入造成超扣
+	condition := field.NewUnsafeFieldRaw("COALESCE(`points`, 0) + ? >= 0", delta)
+	if delta < 0 {
+		condition = field.NewUnsafeFieldRaw("COALESCE(`points`, 0) - `held_points` + ? >= 0", delta)
+	}
+	result, err := tx.User.
+		Where(tx.User.ID.Eq(user.ID), condition).
+		Update(tx.User.Points, gorm.Expr("COALESCE(`points`, 0) + ?", delta))
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInsufficientPoints()
+	}
+
+	actor := AuditActorFromContext(ctx)
+	txn := &models.PointsTransaction{
+		UserID:         user.ID,
+		Delta:          delta,
+		BalanceAfter:   balance,
+		Reason:         reason,
+		CounterAccount: counterAccount,
+		ReferenceID:    referenceID,
+		ActorID:        actor.ID,
+		ActorRole:      actor.Role,
+		RequestID:      actor.RequestID,
+	}
+	if err := tx.PointsTransaction.Create(txn); err != nil {
+		return nil, err
+	}
+	if err := s.journal.Append(ctx, tx, user.ID, user.Points, &balance, reason); err != nil {
+		return nil, err
+	}
+	// 获得的积分记为新批次；扣除按先进先出消耗批次（过期扣除由 expireLot 直接扣减对应批次）
+	if delta > 0 {
+		err = s.addLot(tx, user.ID, delta, reason, txn.ID)
+	} else if reason != PointsReasonExpire {
+		err = s.consumeLots(tx, user.ID, -delta)
+	}
+	if err != nil {
+		return nil, err
+	}
+	user.Points = &balance
+	return txn, nil
+}
+
+// lockUser 在事务中锁定用户行（串行化同一用户的积分变动）
+func lockUser(tx *query.Query, userID uint) (*models.User, error) {
+	return tx.User.Clauses(clause.Locking{Strength: "UPDATE"}).Where(tx.User.ID.Eq(userID)).First()
+}
+
+// wrapLedgerError 转换事务中的错误
+func wrapLedgerError(err error) error {
+	if err == gorm.ErrRecordNotFound {
+		return tools.ErrNotFound("用户不存在")
+	}
+	if appErr, ok := err.(*tools.AppError); ok {
+		return appErr
+	}
+	tools.Logf("积分更新失败: %v", err)
+	return tools.ErrInternalServer("积分更新失败")
+}
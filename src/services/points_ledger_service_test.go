@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+// TestPointsConcurrentDeductAndHold 同一用户并发扣除和预留：成功次数不超过余额，余额和已预留积分不为负，流水与余额一致
+func TestPointsConcurrentDeductAndHold(t *testing.T) {
+	setupTestDB(t)
+	cfg := loadTestConfig(t)
+	ledger := newTestLedger(t, cfg)
+	charges := NewProxyChargeService(NewPointsHoldService(ledger))
+
+	const (
+		initial  = 100
+		cost     = 10
+		deducts  = 20
+		holdReqs = 20
+	)
+	user := createTestUser(t, ledger, "concurrent@example.com", initial)
+	ctx := context.Background()
+
+	var deducted, held atomic.Int32
+	var wg sync.WaitGroup
+	errs := make(chan error, deducts+holdReqs)
+	for range deducts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ledger.Apply(ctx, user.ID, -cost, PointsReasonProcessImage, "concurrent")
+			if err == nil {
+				deducted.Add(1)
+			} else if tools.GetCode(err) != http.StatusPaymentRequired {
+				errs <- err
+			}
+		}()
+	}
+	for range holdReqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := charges.Hold(ctx, user.ID, "/process/image", PointsReasonProcessImage, cost, nil, time.Hour)
+			if err == nil {
+				held.Add(1)
+			} else if tools.GetCode(err) != http.StatusPaymentRequired {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("并发扣除或预留返回非余额不足错误: %v", err)
+	}
+
+	a, h := int(deducted.Load()), int(held.Load())
+	if (a+h)*cost != initial {
+		t.Fatalf("成功扣除 %d 次、预留 %d 次，共 %d 积分，want %d", a, h, (a+h)*cost, initial)
+	}
+	got := reloadTestUser(t, user.ID)
+	if got.Points == nil || *got.Points != initial-a*cost {
+		t.Fatalf("points = %v, want %d", got.Points, initial-a*cost)
+	}
+	if got.HeldPoints != h*cost {
+		t.Fatalf("held_points = %d, want %d", got.HeldPoints, h*cost)
+	}
+	if *got.Points-got.HeldPoints != 0 {
+		t.Fatalf("可用积分 = %d, want 0", *got.Points-got.HeldPoints)
+	}
+	assertLedgerConsistent(t, user.ID, 1+a)
+
+	holds, err := query.PointsHold.Where(query.PointsHold.UserID.Eq(user.ID),
+		query.PointsHold.Status.Eq(models.PointsHoldStatusHeld)).Find()
+	if err != nil {
+		t.Fatalf("查询预留失败: %v", err)
+	}
+	if len(holds) != h {
+		t.Fatalf("预留中记录 = %d, want %d", len(holds), h)
+	}
+
+	// 并发扣除全部预留：余额归零，每个预留一笔流水
+	holdSvc := NewPointsHoldService(ledger)
+	var captureWG sync.WaitGroup
+	captureErrs := make(chan error, len(holds))
+	for _, hold := range holds {
+		captureWG.Add(1)
+		go func(id uint) {
+			defer captureWG.Done()
+			if _, err := holdSvc.Capture(ctx, id, nil); err != nil {
+				captureErrs <- err
+			}
+		}(hold.ID)
+	}
+	captureWG.Wait()
+	close(captureErrs)
+	for err := range captureErrs {
+		t.Fatalf("并发扣除预留失败: %v", err)
+	}
+	got = reloadTestUser(t, user.ID)
+	if *got.Points != 0 || got.HeldPoints != 0 {
+		t.Fatalf("points = %d held_points = %d, want 0 0", *got.Points, got.HeldPoints)
+	}
+	assertLedgerConsistent(t, user.ID, 1+a+h)
+}
+
+// assertLedgerConsistent 校验用户流水笔数、流水合计等于余额、变动后余额均不为负，以及哈希链完整
+func assertLedgerConsistent(t *testing.T, userID uint, wantTxns int) {
+	t.Helper()
+	txns, err := query.PointsTransaction.Where(query.PointsTransaction.UserID.Eq(userID)).
+		Order(query.PointsTransaction.ID).Find()
+	if err != nil {
+		t.Fatalf("查询流水失败: %v", err)
+	}
+	if len(txns) != wantTxns {
+		t.Fatalf("流水笔数 = %d, want %d", len(txns), wantTxns)
+	}
+	sum := 0
+	for _, txn := range txns {
+		sum += txn.Delta
+		if txn.BalanceAfter < 0 || txn.BalanceAfter != sum {
+			t.Fatalf("流水 %d 变动后余额 = %d，累计 = %d", txn.ID, txn.BalanceAfter, sum)
+		}
+	}
+	if user := reloadTestUser(t, userID); user.Points == nil || *user.Points != sum {
+		t.Fatalf("余额 = %v，流水合计 = %d", user.Points, sum)
+	}
+
+	result, err := NewPointsJournalService(nil).Verify()
+	if err != nil {
+		t.Fatalf("校验哈希链失败: %v", err)
+	}
+	if !result.OK() {
+		t.Fatalf("哈希链断开于 %d: %s", result.BrokenAt, result.Problem)
+	}
+}
+
+// TestPointsExpiredLotsNotSpendable 已到期但尚未被扫描处理的批次不能被扣除或预留：扣除前先按过期处理
+func TestPointsExpiredLotsNotSpendable(t *testing.T) {
+	setupTestDB(t)
+	ledger := newTestLedger(t, loadTestConfig(t))
+	charges := NewProxyChargeService(NewPointsHoldService(ledger))
+	ctx := context.Background()
+
+	user := createTestUser(t, ledger, "expired-lots@example.com", 100)
+	expireTestLots(t, user.ID)
+	if _, err := ledger.Apply(ctx, user.ID, 50, PointsReasonAdminGrant, "test"); err != nil {
+		t.Fatalf("发放积分失败: %v", err)
+	}
+
+	// 余额 150 中 100 已过期：可用 50
+	_, err := charges.Hold(ctx, user.ID, "/process/image", PointsReasonProcessImage, 60, nil, time.Hour)
+	if tools.GetCode(err) != http.StatusPaymentRequired {
+		t.Fatalf("预留过期积分 err = %v, want 402", err)
+	}
+	_, err = ledger.Apply(ctx, user.ID, -60, PointsReasonProcessImage, "test")
+	if tools.GetCode(err) != http.StatusPaymentRequired {
+		t.Fatalf("扣除过期积分 err = %v, want 402", err)
+	}
+	if _, err := ledger.Apply(ctx, user.ID, -50, PointsReasonProcessImage, "test"); err != nil {
+		t.Fatalf("扣除未过期积分: %v", err)
+	}
+
+	if points := *reloadTestUser(t, user.ID).Points; points != 0 {
+		t.Fatalf("points = %d, want 0", points)
+	}
+	l := query.PointsLot
+	lots, err := l.Where(l.UserID.Eq(user.ID)).Order(l.ID).Find()
+	if err != nil {
+		t.Fatalf("查询批次失败: %v", err)
+	}
+	if len(lots) != 2 || lots[0].Remaining != 0 || lots[0].ExpiredPoints != 100 || lots[1].Remaining != 0 {
+		t.Fatalf("批次 = %+v, want 过期批次扣除 100、新批次用完", lots)
+	}
+	// 发放 100、发放 50、过期 -100、扣除 -50
+	assertLedgerConsistent(t, user.ID, 4)
+}
+
+// expireTestLots 将用户现有的批次改为已到期（模拟尚未被扫描处理的过期批次）
+func expireTestLots(t *testing.T, userID uint) {
+	t.Helper()
+	l := query.PointsLot
+	if _, err := l.Where(l.UserID.Eq(userID)).UpdateSimple(l.ExpiresAt.Value(time.Now().Unix() - 1)); err != nil {
+		t.Fatalf("更新批次有效期失败: %v", err)
+	}
+}
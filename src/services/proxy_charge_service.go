@@ -0,0 +1,116 @@
+// Package services Python 处理接口计费服务
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+// ProxyChargeService Python 处理接口计费服务
+// 调用前预留积分（Hold），上游返回 2xx 时扣除（Capture），否则释放并记录原因（Release）；
+// 异步接口在上游接受任务后保持预留（Accept），由 Python 服务回调结算
+type ProxyChargeService struct {
+	holds *PointsHoldService
+}
+
+// NewProxyChargeService 创建 Python 处理接口计费服务
+func NewProxyChargeService(holds *PointsHoldService) *ProxyChargeService {
+	return &ProxyChargeService{
+		holds: holds,
+	}
+}
+
+// Hold 预留积分：预留和计费记录在同一事务中写入（可用积分不足时返回 ErrInsufficientPoints）
+// media 为按视频计价时解析出的视频信息，随计费记录保存
+func (s *ProxyChargeService) Hold(
+	ctx context.Context,
+	userID uint,
+	path, reason string,
+	points int,
+	media *tools.MediaInfo,
+	ttl time.Duration,
+) (*models.ProxyCharge, error) {
+	requestID := AuditActorFromContext(ctx).RequestID
+	var charge *models.ProxyCharge
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		hold, err := s.holds.ReserveTx(tx, userID, points, reason, requestID, ttl)
+		if err != nil {
+			return err
+		}
+		charge = &models.ProxyCharge{
+			UserID:    userID,
+			RequestID: requestID,
+			Path:      path,
+			Points:    points,
+			HoldID:    hold.ID,
+		}
+		if media != nil {
+			charge.MediaDurationMs = media.Duration.Milliseconds()
+			charge.MediaWidth = media.Width
+			charge.MediaHeight = media.Height
+		}
+		return tx.ProxyCharge.Create(charge)
+	})
+	if err != nil {
+		return nil, wrapLedgerError(err)
+	}
+	return charge, nil
+}
+
+// KeepAlive 同步接口转发期间每隔 ttl/2 延长一次预留有效期，上游响应慢于 hold_timeout 时预留也不会被过期释放；
+// 返回的 stop 在转发完成后调用。进程异常退出后不再延长，预留仍会在 ttl 后自动释放
+func (s *ProxyChargeService) KeepAlive(charge *models.ProxyCharge, ttl time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(ttl / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.holds.Extend(charge.HoldID, ttl); err != nil {
+					tools.Logf("积分预留延长失败 charge=%d request_id=%s: %v", charge.ID, charge.RequestID, err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// Capture 上游返回 2xx：扣除预留积分
+func (s *ProxyChargeService) Capture(ctx context.Context, charge *models.ProxyCharge, upstreamStatus int) error {
+	s.recordUpstream(charge, upstreamStatus)
+	if _, err := s.holds.Capture(ctx, charge.HoldID, nil); err != nil {
+		tools.Logf("积分预留扣除失败 charge=%d request_id=%s: %v", charge.ID, charge.RequestID, err)
+		return err
+	}
+	return nil
+}
+
+// Accept 异步接口上游已接受任务：保持预留，等待 Python 服务回调结算（超时未结算自动释放）
+func (s *ProxyChargeService) Accept(charge *models.ProxyCharge, upstreamStatus int) {
+	s.recordUpstream(charge, upstreamStatus)
+}
+
+// Release 释放预留积分并记录原因（upstreamStatus 为 0 表示未收到上游响应）
+func (s *ProxyChargeService) Release(charge *models.ProxyCharge, upstreamStatus int, reason string) error {
+	s.recordUpstream(charge, upstreamStatus)
+	if _, err := s.holds.Release(charge.HoldID, reason); err != nil {
+		tools.Logf("积分预留释放失败 charge=%d request_id=%s: %v", charge.ID, charge.RequestID, err)
+		return err
+	}
+	return nil
+}
+
+// recordUpstream 记录上游响应状态码（失败只记录日志）
+func (s *ProxyChargeService) recordUpstream(charge *models.ProxyCharge, upstreamStatus int) {
+	p := query.ProxyCharge
+	if _, err := p.Where(p.ID.Eq(charge.ID)).UpdateSimple(p.UpstreamStatus.Value(upstreamStatus)); err != nil {
+		tools.Logf("计费记录更新失败 charge=%d request_id=%s: %v", charge.ID, charge.RequestID, err)
+	}
+}
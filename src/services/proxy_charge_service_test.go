@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+)
+
+// TestProxyChargeKeepAliveSlowUpstream 同步接口上游响应慢于 hold_timeout：转发期间预留不过期，返回 2xx 后正常扣除
+func TestProxyChargeKeepAliveSlowUpstream(t *testing.T) {
+	setupTestDB(t)
+	ledger := newTestLedger(t, loadTestConfig(t))
+	holds := NewPointsHoldService(ledger)
+	charges := NewProxyChargeService(holds)
+	user := createTestUser(t, ledger, "slow@example.com", 10)
+	ctx := context.Background()
+
+	// 转发中的同步请求（保持预留）与未保持的预留对照
+	const ttl = 2 * time.Second
+	inFlight, err := charges.Hold(ctx, user.ID, "/process_image", PointsReasonProcessImage, 3, nil, ttl)
+	if err != nil {
+		t.Fatalf("Hold: %v", err)
+	}
+	abandoned, err := charges.Hold(ctx, user.ID, "/process_image", PointsReasonProcessImage, 4, nil, time.Second)
+	if err != nil {
+		t.Fatalf("Hold: %v", err)
+	}
+	stop := charges.KeepAlive(inFlight, ttl)
+	defer stop()
+
+	time.Sleep(ttl + 2*time.Second)
+	expired, err := holds.ExpireDue()
+	if err != nil {
+		t.Fatalf("ExpireDue: %v", err)
+	}
+	if expired != 1 {
+		t.Fatalf("过期释放 %d 个预留, want 1（仅未保持的预留）", expired)
+	}
+	if err := charges.Capture(ctx, abandoned, http.StatusOK); tools.GetCode(err) != http.StatusConflict {
+		t.Fatalf("已过期预留扣除 err = %v, want 409", err)
+	}
+
+	// 上游最终返回 2xx
+	if err := charges.Capture(ctx, inFlight, http.StatusOK); err != nil {
+		t.Fatalf("慢响应的同步请求扣除失败: %v", err)
+	}
+
+	got := reloadTestUser(t, user.ID)
+	if *got.Points != 7 || got.HeldPoints != 0 {
+		t.Fatalf("points = %d held_points = %d, want 7 0", *got.Points, got.HeldPoints)
+	}
+	hold, err := query.PointsHold.Where(query.PointsHold.ID.Eq(inFlight.HoldID)).First()
+	if err != nil {
+		t.Fatalf("查询预留失败: %v", err)
+	}
+	if hold.Status != models.PointsHoldStatusCaptured || hold.CapturedPoints != 3 {
+		t.Fatalf("预留状态 = %d 扣除 = %d, want captured 3", hold.Status, hold.CapturedPoints)
+	}
+	assertLedgerConsistent(t, user.ID, 2)
+}
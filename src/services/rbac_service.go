@@ -0,0 +1,146 @@
+// Package services 角色权限服务
+package services
+
+import (
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"gorm.io/gorm"
+)
+
+const (
+	// RoleCodeSuperAdmin 超级管理员（代码中拥有全部权限）
+	RoleCodeSuperAdmin = "super_admin"
+	// RoleCodeSupport 客服
+	RoleCodeSupport = "support"
+	// RoleCodeFinance 财务
+	RoleCodeFinance = "finance"
+)
+
+// 权限编码（与 sql/009_a_permissions.sql 中的预置权限一致）
+const (
+	// PermAdminsRead 查看管理员
+	PermAdminsRead = "admins:read"
+	// PermAdminsWrite 创建管理员
+	PermAdminsWrite = "admins:write"
+	// PermRolesRead 查看角色
+	PermRolesRead = "roles:read"
+	// PermUsersRead 查看用户
+	PermUsersRead = "users:read"
+	// PermUsersPointsWrite 调整用户积分
+	PermUsersPointsWrite = "users:points:write"
+	// PermPointsRead 查看积分流水
+	PermPointsRead = "points:read"
+	// PermUsersStatusWrite 暂停/封禁用户
+	PermUsersStatusWrite = "users:status:write"
+	// PermLoginLockoutsRead 查看登录锁定
+	PermLoginLockoutsRead = "login_lockouts:read"
+	// PermLoginLockoutsWrite 解除登录锁定
+	PermLoginLockoutsWrite = "login_lockouts:write"
+	// PermAuditLogsRead 查看审计日志
+	PermAuditLogsRead = "audit_logs:read"
+	// PermPointsJournalRead 导出积分变更日志检查点
+	PermPointsJournalRead = "points_journal:read"
+	// PermRedeemRead 查看和导出兑换码
+	PermRedeemRead = "redeem:read"
+	// PermRedeemWrite 创建兑换码活动
+	PermRedeemWrite = "redeem:write"
+	// PermProductsRead 查看积分套餐
+	PermProductsRead = "products:read"
+	// PermProductsWrite 管理积分套餐
+	PermProductsWrite = "products:write"
+	// PermOrdersRead 查看积分订单
+	PermOrdersRead = "orders:read"
+	// PermOrdersRefund 积分订单退款
+	PermOrdersRefund = "orders:refund"
+)
+
+// RBACService 角色权限服务
+type RBACService struct{}
+
+// NewRBACService 创建角色权限服务
+func NewRBACService() *RBACService {
+	return &RBACService{}
+}
+
+// HasPermission 检查管理员是否拥有指定权限（每次实时查询，角色调整立即生效）
+func (s *RBACService) HasPermission(adminID uint, permission string) (bool, error) {
+	role, err := s.adminRole(adminID)
+	if err != nil || role == nil {
+		return false, err
+	}
+	if role.Code == RoleCodeSuperAdmin {
+		return true, nil
+	}
+
+	count, err := query.RolePermission.
+		Join(query.Permission, query.Permission.ID.EqCol(query.RolePermission.PermissionID)).
+		Where(query.RolePermission.RoleID.Eq(role.ID), query.Permission.Code.Eq(permission)).
+		Count()
+	if err != nil {
+		return false, tools.ErrInternalServer("权限查询失败")
+	}
+	return count > 0, nil
+}
+
+// ListRoles 获取全部角色及其权限编码
+func (s *RBACService) ListRoles() ([]*models.Role, map[uint][]string, error) {
+	roles, err := query.Role.Order(query.Role.ID).Find()
+	if err != nil {
+		return nil, nil, tools.ErrInternalServer("角色查询失败")
+	}
+
+	var rows []struct {
+		RoleID uint
+		Code   string
+	}
+	err = query.RolePermission.
+		Select(query.RolePermission.RoleID, query.Permission.Code).
+		Join(query.Permission, query.Permission.ID.EqCol(query.RolePermission.PermissionID)).
+		Order(query.Permission.Code).
+		Scan(&rows)
+	if err != nil {
+		return nil, nil, tools.ErrInternalServer("角色查询失败")
+	}
+
+	permissions := make(map[uint][]string, len(roles))
+	for _, row := range rows {
+		permissions[row.RoleID] = append(permissions[row.RoleID], row.Code)
+	}
+	return roles, permissions, nil
+}
+
+// ValidateRole 校验角色是否存在
+func (s *RBACService) ValidateRole(roleID uint) error {
+	_, err := query.Role.Where(query.Role.ID.Eq(roleID)).First()
+	if err == gorm.ErrRecordNotFound {
+		return tools.ErrBadRequest("角色不存在")
+	}
+	if err != nil {
+		return tools.ErrInternalServer("角色查询失败")
+	}
+	return nil
+}
+
+// adminRole 获取管理员的角色（未分配角色时返回 nil）
+func (s *RBACService) adminRole(adminID uint) (*models.Role, error) {
+	admin, err := query.Admin.Select(query.Admin.RoleID).Where(query.Admin.ID.Eq(adminID)).First()
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, tools.ErrInternalServer("权限查询失败")
+	}
+	if admin.RoleID == nil {
+		return nil, nil
+	}
+
+	role, err := query.Role.Where(query.Role.ID.Eq(*admin.RoleID)).First()
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, tools.ErrInternalServer("权限查询失败")
+	}
+	return role, nil
+}
@@ -0,0 +1,316 @@
+// Package services 兑换码服务
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// redeemCodeLength 兑换码长度（不含分隔符，32 个字符 16 位约 80 位熵，不可枚举）
+	redeemCodeLength = 16
+	// redeemCodeGroup 导出时每组字符数（以 - 分隔）
+	redeemCodeGroup = 4
+	// redeemCodeAlphabet 兑换码和邀请码字符集（去掉易混淆的 0/O/1/I，共 32 个字符）
+	redeemCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	// redeemCodeBatch 批量写入兑换码的每批数量
+	redeemCodeBatch = 500
+	// RedeemMaxCodeCount 单个活动最多生成的兑换码数量
+	RedeemMaxCodeCount = 10000
+)
+
+// RedeemService 兑换码服务
+// 兑换时锁定用户和兑换码，检查有效期、兑换码使用次数和每用户次数后，使用次数加一、写入积分流水和兑换记录在同一事务中完成
+type RedeemService struct {
+	ledger *PointsLedgerService
+	audit  *AuditService
+}
+
+// NewRedeemService 创建兑换码服务
+func NewRedeemService(ledger *PointsLedgerService, audit *AuditService) *RedeemService {
+	return &RedeemService{
+		ledger: ledger,
+		audit:  audit,
+	}
+}
+
+// CreateCampaign 创建兑换码活动并批量生成兑换码（活动和兑换码在同一事务中写入）
+func (s *RedeemService) CreateCampaign(
+	ctx context.Context,
+	req *dto.RedeemCampaignCreateRequest,
+) (*models.RedeemCampaign, error) {
+	campaign := &models.RedeemCampaign{
+		Name:           req.Name,
+		Points:         req.Points,
+		CodeCount:      req.CodeCount,
+		MaxUsesPerCode: req.MaxUsesPerCode,
+		PerUserLimit:   req.PerUserLimit,
+		StartsAt:       time.Now().Unix(),
+		EndsAt:         req.EndsAt,
+		CreatedBy:      AuditActorFromContext(ctx).ID,
+	}
+	if campaign.MaxUsesPerCode == 0 {
+		campaign.MaxUsesPerCode = 1
+	}
+	if campaign.PerUserLimit == 0 {
+		campaign.PerUserLimit = 1
+	}
+	if req.StartsAt != nil {
+		campaign.StartsAt = *req.StartsAt
+	}
+	if campaign.EndsAt <= campaign.StartsAt {
+		return nil, tools.ErrBadRequest("截止时间必须晚于生效时间")
+	}
+
+	codes, err := generateRedeemCodes(campaign.CodeCount)
+	if err != nil {
+		return nil, tools.ErrInternalServer("兑换码生成失败")
+	}
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.RedeemCampaign.Create(campaign); err != nil {
+			return err
+		}
+		rows := make([]*models.RedeemCode, len(codes))
+		for i, code := range codes {
+			rows[i] = &models.RedeemCode{CampaignID: campaign.ID, Code: code}
+		}
+		return tx.RedeemCode.CreateInBatches(rows, redeemCodeBatch)
+	})
+	if err != nil {
+		tools.Logf("兑换码活动创建失败: %v", err)
+		return nil, tools.ErrInternalServer("兑换码活动创建失败")
+	}
+
+	s.audit.Record(ctx, AuditActionRedeemCampaignCreate, AuditTargetRedeemCampaign, campaign.ID, nil, AuditFields{
+		"name":              campaign.Name,
+		"points":            campaign.Points,
+		"code_count":        campaign.CodeCount,
+		"max_uses_per_code": campaign.MaxUsesPerCode,
+		"per_user_limit":    campaign.PerUserLimit,
+		"starts_at":         campaign.StartsAt,
+		"ends_at":           campaign.EndsAt,
+	})
+	return campaign, nil
+}
+
+// ListCampaigns 查询兑换码活动（按创建时间倒序），同时返回各活动的已兑换次数
+func (s *RedeemService) ListCampaigns(
+	queryReq *dto.RedeemCampaignListQueryRequest,
+) ([]*models.RedeemCampaign, map[uint]int, int64, error) {
+	c := query.RedeemCampaign
+	conditions := tools.NewConditionBuilder().
+		Like(&c.Name, queryReq.Name).
+		Build()
+
+	campaigns, count, err := c.Where(conditions...).
+		Order(c.ID.Desc()).
+		FindByPage(queryReq.GetOffset(), queryReq.GetLimit())
+	if err != nil {
+		return nil, nil, 0, tools.ErrInternalServer("兑换码活动查询失败")
+	}
+
+	redeemed := make(map[uint]int, len(campaigns))
+	if len(campaigns) == 0 {
+		return campaigns, redeemed, count, nil
+	}
+	ids := make([]uint, len(campaigns))
+	for i, campaign := range campaigns {
+		ids[i] = campaign.ID
+	}
+	var rows []struct {
+		CampaignID uint
+		Redeemed   int
+	}
+	r := query.RedeemRedemption
+	err = r.Select(r.CampaignID, r.ID.Count().As("redeemed")).
+		Where(r.CampaignID.In(ids...)).
+		Group(r.CampaignID).
+		Scan(&rows)
+	if err != nil {
+		return nil, nil, 0, tools.ErrInternalServer("兑换码活动查询失败")
+	}
+	for _, row := range rows {
+		redeemed[row.CampaignID] = row.Redeemed
+	}
+	return campaigns, redeemed, count, nil
+}
+
+// ExportCodes 获取活动及其全部兑换码（按生成顺序），记录导出审计
+func (s *RedeemService) ExportCodes(
+	ctx context.Context,
+	campaignID uint,
+) (*models.RedeemCampaign, []*models.RedeemCode, error) {
+	campaign, err := query.RedeemCampaign.Where(query.RedeemCampaign.ID.Eq(campaignID)).First()
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil, tools.ErrNotFound("兑换码活动不存在")
+	}
+	if err != nil {
+		return nil, nil, tools.ErrInternalServer("兑换码活动查询失败")
+	}
+	codes, err := query.RedeemCode.
+		Where(query.RedeemCode.CampaignID.Eq(campaignID)).
+		Order(query.RedeemCode.ID).
+		Find()
+	if err != nil {
+		return nil, nil, tools.ErrInternalServer("兑换码查询失败")
+	}
+
+	s.audit.Record(ctx, AuditActionRedeemCampaignExport, AuditTargetRedeemCampaign, campaign.ID, nil,
+		AuditFields{"code_count": len(codes)})
+	return campaign, codes, nil
+}
+
+// Redeem 用户兑换积分（不区分大小写，忽略分隔符和空格）
+// 同一用户重复兑换同一兑换码直接返回已有记录，不重复加积分
+func (s *RedeemService) Redeem(ctx context.Context, userID uint, code string) (*models.RedeemRedemption, error) {
+	code = normalizeRedeemCode(code)
+	if len(code) != redeemCodeLength {
+		return nil, tools.ErrNotFound("兑换码无效")
+	}
+
+	var redemption *models.RedeemRedemption
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		// 锁定顺序与积分账本一致：先用户后兑换码；同一用户的兑换串行执行，每用户次数不会被并发突破
+		user, err := lockUser(tx, userID)
+		if err != nil {
+			return err
+		}
+		redeemCode, err := tx.RedeemCode.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(tx.RedeemCode.Code.Eq(code)).
+			First()
+		if err == gorm.ErrRecordNotFound {
+			return tools.ErrNotFound("兑换码无效")
+		}
+		if err != nil {
+			return err
+		}
+
+		existing, err := tx.RedeemRedemption.
+			Where(tx.RedeemRedemption.CodeID.Eq(redeemCode.ID), tx.RedeemRedemption.UserID.Eq(userID)).
+			First()
+		if err == nil {
+			redemption = existing
+			return nil
+		}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		campaign, err := tx.RedeemCampaign.Where(tx.RedeemCampaign.ID.Eq(redeemCode.CampaignID)).First()
+		if err != nil {
+			return err
+		}
+		now := time.Now().Unix()
+		if now < campaign.StartsAt {
+			return tools.ErrConflict("兑换码尚未生效")
+		}
+		if now >= campaign.EndsAt {
+			return tools.ErrConflict("兑换码已过期")
+		}
+		if redeemCode.UsedCount >= campaign.MaxUsesPerCode {
+			return tools.ErrConflict("兑换码已被使用")
+		}
+		used, err := tx.RedeemRedemption.
+			Where(tx.RedeemRedemption.CampaignID.Eq(campaign.ID), tx.RedeemRedemption.UserID.Eq(userID)).
+			Count()
+		if err != nil {
+			return err
+		}
+		if int(used) >= campaign.PerUserLimit {
+			return tools.ErrConflict("已达到该活动的兑换次数上限")
+		}
+
+		// 条件更新兜底：使用次数达到上限时不生效
+		result, err := tx.RedeemCode.
+			Where(tx.RedeemCode.ID.Eq(redeemCode.ID), tx.RedeemCode.UsedCount.Lt(campaign.MaxUsesPerCode)).
+			UpdateSimple(tx.RedeemCode.UsedCount.Add(1))
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return tools.ErrConflict("兑换码已被使用")
+		}
+
+		txn, err := s.ledger.apply(ctx, tx, user, campaign.Points, PointsReasonRedeem,
+			"redeem_code:"+strconv.FormatUint(uint64(redeemCode.ID), 10))
+		if err != nil {
+			return err
+		}
+		redemption = &models.RedeemRedemption{
+			CampaignID:    campaign.ID,
+			CodeID:        redeemCode.ID,
+			UserID:        userID,
+			Points:        campaign.Points,
+			TransactionID: txn.ID,
+		}
+		return tx.RedeemRedemption.Create(redemption)
+	})
+	if err != nil {
+		return nil, wrapLedgerError(err)
+	}
+	return redemption, nil
+}
+
+// FormatRedeemCode 兑换码展示格式（每 4 个字符以 - 分隔）
+func FormatRedeemCode(code string) string {
+	var b strings.Builder
+	for i, r := range code {
+		if i > 0 && i%redeemCodeGroup == 0 {
+			b.WriteByte('-')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// normalizeRedeemCode 统一为存储格式（大写，去掉分隔符和空白）
+func normalizeRedeemCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', ' ', '\t':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// generateRedeemCodes 生成 n 个互不相同的随机兑换码（跨活动的重复由唯一索引兜底）
+func generateRedeemCodes(n int) ([]string, error) {
+	seen := make(map[string]bool, n)
+	codes := make([]string, 0, n)
+	for len(codes) < n {
+		code, err := randomCode(redeemCodeLength)
+		if err != nil {
+			return nil, err
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// randomCode 生成指定长度的随机码（字符集为 32 个字符，取低 5 位无取模偏差）
+func randomCode(length int) (string, error) {
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = redeemCodeAlphabet[b&31]
+	}
+	return string(buf), nil
+}
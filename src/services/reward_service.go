@@ -0,0 +1,285 @@
+// Package services 积分奖励服务
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/tools"
+	"gorm.io/gorm"
+)
+
+const (
+	// inviteCodeLength 邀请码长度
+	inviteCodeLength = 8
+	// inviteCodeAttempts 生成邀请码时遇到重复的最大重试次数
+	inviteCodeAttempts = 5
+	// rewardKeyEmailPrefix 注册和邀请奖励的发放键前缀（按邮箱发放，注销后用同一邮箱重新注册不再发放）
+	rewardKeyEmailPrefix = "email:"
+)
+
+var (
+	// errRewardDisabled 奖励未开启（配置的积分为 0）
+	errRewardDisabled = errors.New("积分奖励未开启")
+	// errRewardGranted 该奖励已发放
+	errRewardGranted = errors.New("积分奖励已发放")
+	// errRewardLimit 已达到奖励次数上限
+	errRewardLimit = errors.New("已达到积分奖励次数上限")
+)
+
+// ReferralSummary 邀请统计
+type ReferralSummary struct {
+	InviteCode   string // 本人的邀请码
+	InvitedCount int64  // 已邀请注册的用户数
+	RewardCount  int64  // 获得邀请奖励的次数
+	RewardPoints int    // 邀请奖励积分合计
+}
+
+// rewardSpec 一次待发放的奖励
+type rewardSpec struct {
+	userID     uint
+	rewardType string
+	key        string
+	rule       config.RewardRuleConfig
+	anyUser    bool // 发放键在全部用户（含已注销的用户）中只发放一次
+}
+
+// RewardService 积分奖励服务（注册、每日签到、邀请）
+// 每次发放写入 a_reward_grants，(用户, 奖励类型, 发放键) 唯一，同一奖励不会重复发放；
+// 注册和邀请奖励的发放键为被邀请人邮箱的摘要，且在全部用户中唯一：注销后用同一邮箱重新注册不会再次获得奖励，
+// 邀请人也不能通过同一邮箱反复注销注册获得邀请奖励（发放记录在注销用户时保留）；
+// 发放前锁定获奖用户，同一用户的奖励串行发放，每日和累计次数上限不会被并发突破
+type RewardService struct {
+	ledger *PointsLedgerService
+	cfg    *config.RewardsConfig
+}
+
+// NewRewardService 创建积分奖励服务
+func NewRewardService(ledger *PointsLedgerService, cfg *config.RewardsConfig) *RewardService {
+	return &RewardService{
+		ledger: ledger,
+		cfg:    cfg,
+	}
+}
+
+// FindInviter 根据邀请码查找邀请人（不区分大小写）
+func (s *RewardService) FindInviter(inviteCode string) (*models.User, error) {
+	code := normalizeRedeemCode(inviteCode)
+	inviter, err := query.User.Where(query.User.InviteCode.Eq(code)).First()
+	if err == gorm.ErrRecordNotFound {
+		return nil, tools.ErrBadRequest("邀请码无效")
+	}
+	if err != nil {
+		return nil, tools.ErrInternalServer("邀请码查询失败")
+	}
+	return inviter, nil
+}
+
+// NewInviteCodeTx 在事务中生成未被占用的邀请码
+func (s *RewardService) NewInviteCodeTx(tx *query.Query) (string, error) {
+	for range inviteCodeAttempts {
+		code, err := randomCode(inviteCodeLength)
+		if err != nil {
+			return "", err
+		}
+		count, err := tx.User.Where(tx.User.InviteCode.Eq(code)).Count()
+		if err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return code, nil
+		}
+	}
+	return "", errors.New("邀请码生成失败")
+}
+
+// RegisterTx 在注册事务中发放注册奖励；inviter 不为 nil 时同时发放受邀奖励和邀请人奖励
+// 奖励未开启或邀请人已达到次数上限时跳过，不影响注册
+func (s *RewardService) RegisterTx(ctx context.Context, tx *query.Query, user, inviter *models.User) error {
+	now := time.Now()
+	key := rewardEmailKey(user.Email)
+	grants := []*rewardSpec{
+		{userID: user.ID, rewardType: PointsReasonSignupBonus, key: key, rule: s.cfg.Signup, anyUser: true},
+	}
+	if inviter != nil {
+		inviteeRule := config.RewardRuleConfig{Points: s.cfg.Referral.InviteePoints}
+		grants = append(grants,
+			&rewardSpec{userID: user.ID, rewardType: PointsReasonInviteeBonus, key: key, rule: inviteeRule, anyUser: true},
+			&rewardSpec{
+				userID:     inviter.ID,
+				rewardType: PointsReasonReferralBonus,
+				key:        key,
+				rule:       s.cfg.Referral.RewardRuleConfig,
+				anyUser:    true,
+			},
+		)
+	}
+
+	for _, g := range grants {
+		if _, err := s.grantTx(ctx, tx, g, now); err != nil && !rewardSkipped(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewardEmailKey 注册和邀请奖励的发放键：规范化邮箱（去除首尾空格、转小写）的 SHA-256 摘要前 16 字节
+func rewardEmailKey(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return rewardKeyEmailPrefix + hex.EncodeToString(sum[:16])
+}
+
+// CheckIn 每日签到（按服务器时区计算日期，每天一次）
+func (s *RewardService) CheckIn(ctx context.Context, userID uint) (*models.RewardGrant, error) {
+	now := time.Now()
+	var grant *models.RewardGrant
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		var err error
+		grant, err = s.grantTx(ctx, tx, &rewardSpec{
+			userID:     userID,
+			rewardType: PointsReasonCheckIn,
+			key:        now.Format(time.DateOnly),
+			rule:       s.cfg.CheckIn,
+		}, now)
+		return err
+	})
+	switch {
+	case err == nil:
+		return grant, nil
+	case errors.Is(err, errRewardDisabled):
+		return nil, tools.ErrNotFound("签到奖励未开启")
+	case errors.Is(err, errRewardGranted):
+		return nil, tools.ErrConflict("今日已签到")
+	case errors.Is(err, errRewardLimit):
+		return nil, tools.ErrConflict("已达到签到奖励次数上限")
+	default:
+		return nil, wrapLedgerError(err)
+	}
+}
+
+// Referral 获取本人的邀请码和邀请统计（尚无邀请码时生成）
+func (s *RewardService) Referral(userID uint) (*ReferralSummary, error) {
+	summary := &ReferralSummary{}
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		user, err := lockUser(tx, userID)
+		if err != nil {
+			return err
+		}
+		if user.InviteCode == nil {
+			code, err := s.NewInviteCodeTx(tx)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.User.Where(tx.User.ID.Eq(userID)).UpdateSimple(tx.User.InviteCode.Value(code)); err != nil {
+				return err
+			}
+			user.InviteCode = &code
+		}
+		summary.InviteCode = *user.InviteCode
+		return nil
+	})
+	if err == gorm.ErrRecordNotFound {
+		return nil, tools.ErrNotFound("用户不存在")
+	}
+	if err != nil {
+		tools.Logf("邀请码生成失败: %v", err)
+		return nil, tools.ErrInternalServer("邀请码生成失败")
+	}
+
+	summary.InvitedCount, err = query.User.Where(query.User.InvitedBy.Eq(userID)).Count()
+	if err != nil {
+		return nil, tools.ErrInternalServer("邀请统计查询失败")
+	}
+	var total struct {
+		Count  int64
+		Points *int // 没有奖励时 SUM 为 NULL
+	}
+	g := query.RewardGrant
+	err = g.Select(g.ID.Count().As("count"), g.Points.Sum().As("points")).
+		Where(g.UserID.Eq(userID), g.Type.Eq(PointsReasonReferralBonus)).
+		Scan(&total)
+	if err != nil {
+		return nil, tools.ErrInternalServer("邀请统计查询失败")
+	}
+	summary.RewardCount, summary.RewardPoints = total.Count, pointsValue(total.Points)
+	return summary, nil
+}
+
+// grantTx 在事务中向用户发放一次奖励：锁定用户，检查是否已发放和次数上限，写入积分流水和发放记录
+// anyUser 的奖励按发放键检查全部用户（按邮箱发放时，同一邮箱同时只能属于一个用户，邮箱唯一索引保证不会并发注册）
+func (s *RewardService) grantTx(
+	ctx context.Context,
+	tx *query.Query,
+	spec *rewardSpec,
+	now time.Time,
+) (*models.RewardGrant, error) {
+	userID, rewardType, key, rule := spec.userID, spec.rewardType, spec.key, spec.rule
+	if rule.Points <= 0 {
+		return nil, errRewardDisabled
+	}
+	user, err := lockUser(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	g := tx.RewardGrant
+	granted := g.Where(g.Type.Eq(rewardType), g.GrantKey.Eq(key))
+	if !spec.anyUser {
+		granted = granted.Where(g.UserID.Eq(userID))
+	}
+	existing, err := granted.First()
+	if err == nil {
+		return existing, errRewardGranted
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	day := now.Format(time.DateOnly)
+	if rule.DailyLimit > 0 {
+		count, err := g.Where(g.UserID.Eq(userID), g.Type.Eq(rewardType), g.Day.Eq(day)).Count()
+		if err != nil {
+			return nil, err
+		}
+		if count >= int64(rule.DailyLimit) {
+			return nil, errRewardLimit
+		}
+	}
+	if rule.TotalLimit > 0 {
+		count, err := g.Where(g.UserID.Eq(userID), g.Type.Eq(rewardType)).Count()
+		if err != nil {
+			return nil, err
+		}
+		if count >= int64(rule.TotalLimit) {
+			return nil, errRewardLimit
+		}
+	}
+
+	txn, err := s.ledger.apply(ctx, tx, user, rule.Points, rewardType, key)
+	if err != nil {
+		return nil, err
+	}
+	grant := &models.RewardGrant{
+		UserID:        userID,
+		Type:          rewardType,
+		GrantKey:      key,
+		Day:           day,
+		Points:        rule.Points,
+		TransactionID: txn.ID,
+	}
+	if err := g.Create(grant); err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+// rewardSkipped 奖励未开启、已发放或已达到次数上限（注册时跳过，不作为错误）
+func rewardSkipped(err error) bool {
+	return errors.Is(err, errRewardDisabled) || errors.Is(err, errRewardGranted) || errors.Is(err, errRewardLimit)
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+)
+
+// registerTestUser 按注册流程创建用户并发放注册奖励（inviter 不为 nil 时同时发放邀请奖励）
+func registerTestUser(t *testing.T, rewards *RewardService, email string, inviter *models.User) *models.User {
+	t.Helper()
+	user := &models.User{
+		Username:      "u_" + strings.ToLower(strings.ReplaceAll(email, "@", "_")),
+		Email:         email,
+		Password:      "x",
+		EmailVerified: true,
+	}
+	if inviter != nil {
+		user.InvitedBy = &inviter.ID
+	}
+	err := query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.User.Create(user); err != nil {
+			return err
+		}
+		return rewards.RegisterTx(context.Background(), tx, user, inviter)
+	})
+	if err != nil {
+		t.Fatalf("注册失败: %v", err)
+	}
+	return reloadTestUser(t, user.ID)
+}
+
+// TestRewardsNotRegrantedAfterReRegister 注销后用同一邮箱（大小写不同）重新注册，不再发放注册、受邀和邀请奖励
+func TestRewardsNotRegrantedAfterReRegister(t *testing.T) {
+	setupTestDB(t)
+	redis := setupTestRedis(t)
+	cfg := loadTestConfig(t)
+	cfg.Rewards = config.RewardsConfig{
+		Signup: config.RewardRuleConfig{Points: 10},
+		Referral: config.ReferralRewardConfig{
+			RewardRuleConfig: config.RewardRuleConfig{Points: 20, TotalLimit: 50},
+			InviteePoints:    5,
+		},
+	}
+	ledger := newTestLedger(t, cfg)
+	rewards := NewRewardService(ledger, &cfg.Rewards)
+	auth := newTestAuthService(t, cfg, redis)
+	users := NewUserService(nil, auth, auth.audit, rewards)
+	ctx := context.Background()
+
+	inviter := createTestUser(t, ledger, "inviter@example.com", 0)
+	otherInviter := createTestUser(t, ledger, "other-inviter@example.com", 0)
+
+	first := registerTestUser(t, rewards, "farm@example.com", inviter)
+	if *first.Points != 15 {
+		t.Fatalf("首次注册积分 = %d, want 15（注册 10 + 受邀 5）", *first.Points)
+	}
+	if points := *reloadTestUser(t, inviter.ID).Points; points != 20 {
+		t.Fatalf("邀请人积分 = %d, want 20", points)
+	}
+
+	for i, again := range []struct {
+		email   string
+		inviter *models.User
+	}{
+		{"farm@example.com", inviter},
+		{" Farm@Example.COM", otherInviter},
+	} {
+		previous := reloadTestUserByEmail(t, strings.TrimSpace(again.email))
+		if err := users.Delete(ctx, previous.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		user := registerTestUser(t, rewards, strings.TrimSpace(again.email), again.inviter)
+		if user.Points != nil && *user.Points != 0 {
+			t.Fatalf("第 %d 次重新注册积分 = %d, want 0", i+1, *user.Points)
+		}
+	}
+
+	if points := *reloadTestUser(t, inviter.ID).Points; points != 20 {
+		t.Fatalf("邀请人积分 = %d, want 20（同一邮箱只发放一次邀请奖励）", points)
+	}
+	if points := reloadTestUser(t, otherInviter.ID).Points; points != nil && *points != 0 {
+		t.Fatalf("其他邀请人积分 = %d, want 0", *points)
+	}
+	g := query.RewardGrant
+	for _, rewardType := range []string{PointsReasonSignupBonus, PointsReasonInviteeBonus, PointsReasonReferralBonus} {
+		if n, _ := g.Where(g.Type.Eq(rewardType)).Count(); n != 1 {
+			t.Fatalf("%s 发放 %d 次, want 1", rewardType, n)
+		}
+	}
+}
+
+// reloadTestUserByEmail 按邮箱读取用户
+func reloadTestUserByEmail(t *testing.T, email string) *models.User {
+	t.Helper()
+	user, err := query.User.Where(query.User.Email.Eq(email)).First()
+	if err != nil {
+		t.Fatalf("读取用户失败: %v", err)
+	}
+	return user
+}
+
+func TestRewardEmailKey(t *testing.T) {
+	key := rewardEmailKey("farm@example.com")
+	if !strings.HasPrefix(key, rewardKeyEmailPrefix) || len(key) > 64 {
+		t.Fatalf("发放键 = %q，应以 %s 开头且不超过 64 个字符", key, rewardKeyEmailPrefix)
+	}
+	if rewardEmailKey(" Farm@Example.COM ") != key {
+		t.Fatal("邮箱大小写和首尾空格不应影响发放键")
+	}
+	if rewardEmailKey("farm2@example.com") == key {
+		t.Fatal("不同邮箱的发放键不应相同")
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// sqlMigrationName 迁移文件名：三位编号_说明.sql
+var sqlMigrationName = regexp.MustCompile(`^(\d{3})_[a-z_]+\.sql$`) //nolint:gochecknoglobals // 正则为只读常量
+
+// sqlTableRef 匹配语句中引用的表名
+var sqlTableRef = regexp.MustCompile("`(a_[a-z_]+)`") //nolint:gochecknoglobals // 正则为只读常量
+
+// TestSQLMigrationOrder 迁移文件连续编号，且每条语句引用的表都已由之前的语句创建
+func TestSQLMigrationOrder(t *testing.T) {
+	created := map[string]bool{}
+	for i, file := range sqlMigrationFiles(t) {
+		name := filepath.Base(file)
+		m := sqlMigrationName.FindStringSubmatch(name)
+		if m == nil {
+			t.Fatalf("迁移文件名格式错误: %s", name)
+		}
+		if want := fmt.Sprintf("%03d", i+1); m[1] != want {
+			t.Fatalf("%s 编号不连续，want %s", name, want)
+		}
+		for _, stmt := range sqlStatements(t, file) {
+			if c := sqlCreateTable.FindStringSubmatch(stmt); c != nil {
+				if created[c[1]] {
+					t.Fatalf("%s 重复创建表 %s", name, c[1])
+				}
+				created[c[1]] = true
+				continue
+			}
+			for _, ref := range sqlTableRef.FindAllStringSubmatch(stmt, -1) {
+				if !created[ref[1]] {
+					t.Errorf("%s 引用了尚未创建的表 %s", name, ref[1])
+				}
+			}
+		}
+	}
+}
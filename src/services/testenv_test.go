@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+	"github.com/Company-Automation-1/video-backend-go/src/models"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 集成测试使用的环境变量（未设置时跳过对应测试）
+const (
+	// testMySQLDSNEnv 测试数据库 DSN，如 root:root@tcp(127.0.0.1:3306)/video_test?charset=utf8mb4
+	// 测试会删除并按 sql/ 目录重建所有表，只能指向专用的测试库
+	testMySQLDSNEnv = "TEST_MYSQL_DSN"
+	// testRedisAddrEnv 测试 Redis 地址，如 127.0.0.1:6379
+	testRedisAddrEnv = "TEST_REDIS_ADDR"
+)
+
+// sqlCreateTable 匹配建表语句中的表名
+var sqlCreateTable = regexp.MustCompile("(?i)^CREATE TABLE `([a-z_]+)`") //nolint:gochecknoglobals // 正则为只读常量
+
+// testDBMu 串行化使用数据库的测试（每个测试都会重建表）
+var testDBMu sync.Mutex //nolint:gochecknoglobals // 测试全局锁
+
+// setupTestDB 连接测试数据库、按编号顺序执行 sql/ 目录的迁移重建所有表并设置 query 默认连接（未设置 TEST_MYSQL_DSN 时跳过）
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(testMySQLDSNEnv)
+	if dsn == "" {
+		t.Skipf("未设置 %s，跳过数据库集成测试", testMySQLDSNEnv)
+	}
+	testDBMu.Lock()
+	t.Cleanup(testDBMu.Unlock)
+
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("连接测试数据库失败: %v", err)
+	}
+	for _, file := range sqlMigrationFiles(t) {
+		for _, stmt := range sqlStatements(t, file) {
+			if m := sqlCreateTable.FindStringSubmatch(stmt); m != nil {
+				if err := db.Exec("DROP TABLE IF EXISTS `" + m[1] + "`").Error; err != nil {
+					t.Fatalf("删除表 %s 失败: %v", m[1], err)
+				}
+			}
+			if err := db.Exec(stmt).Error; err != nil {
+				t.Fatalf("执行 %s 失败: %v", filepath.Base(file), err)
+			}
+		}
+	}
+
+	query.SetDefault(db)
+	return db
+}
+
+// sqlMigrationFiles sql/ 目录下的迁移文件（按文件名即编号顺序）
+func sqlMigrationFiles(t *testing.T) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join("..", "..", "sql", "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("未找到迁移文件: %v", err)
+	}
+	return files
+}
+
+// sqlStatements 读取 SQL 文件并按语句拆分（忽略注释行）
+func sqlStatements(t *testing.T, file string) []string {
+	t.Helper()
+	data, err := os.ReadFile(file) //nolint:gosec // 测试读取仓库内的 SQL 文件
+	if err != nil {
+		t.Fatalf("读取 %s 失败: %v", file, err)
+	}
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+	var stmts []string
+	for _, stmt := range strings.Split(strings.Join(lines, "\n"), ";\n") {
+		if stmt = strings.TrimSuffix(strings.TrimSpace(stmt), ";"); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
+
+// setupTestRedis 连接测试 Redis（未设置 TEST_REDIS_ADDR 时跳过）
+func setupTestRedis(t *testing.T) *infrastructure.Redis {
+	t.Helper()
+	addr := os.Getenv(testRedisAddrEnv)
+	if addr == "" {
+		t.Skipf("未设置 %s，跳过 Redis 集成测试", testRedisAddrEnv)
+	}
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		t.Fatalf("%s 格式错误: %v", testRedisAddrEnv, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatalf("%s 端口错误: %v", testRedisAddrEnv, err)
+	}
+	redis, err := infrastructure.NewRedis(&config.Config{Redis: config.RedisConfig{Host: host, Port: port}})
+	if err != nil {
+		t.Fatalf("连接测试 Redis 失败: %v", err)
+	}
+	return redis
+}
+
+// loadTestConfig 加载仓库中的示例配置（使用其默认值，JWT 密钥使用测试值）
+func loadTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+	cfg, err := config.Load(filepath.Join("..", "..", "config.yaml"))
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	cfg.JWT.Secret = "test-jwt-secret" // 示例配置不含密钥
+	return cfg
+}
+
+// newTestLedger 创建积分账本服务
+func newTestLedger(t *testing.T, cfg *config.Config) *PointsLedgerService {
+	t.Helper()
+	signer, err := NewJWTSigner(&cfg.JWT)
+	if err != nil {
+		t.Fatalf("加载JWT密钥失败: %v", err)
+	}
+	return NewPointsLedgerService(NewPointsJournalService(signer), NewAuditService(), &cfg.Points)
+}
+
+// newTestAuthService 创建认证服务
+func newTestAuthService(t *testing.T, cfg *config.Config, redis *infrastructure.Redis) *AuthService {
+	t.Helper()
+	signer, err := NewJWTSigner(&cfg.JWT)
+	if err != nil {
+		t.Fatalf("加载JWT密钥失败: %v", err)
+	}
+	audit := NewAuditService()
+	return NewAuthService(&cfg.JWT, signer, redis,
+		NewLoginGuardService(&cfg.LoginGuard, redis, audit),
+		NewMFAService(&cfg.MFA, redis, audit),
+		NewAPIKeyService(audit), audit)
+}
+
+// createTestUser 创建测试用户，points 大于 0 时通过积分账本发放
+func createTestUser(t *testing.T, ledger *PointsLedgerService, email string, points int) *models.User {
+	t.Helper()
+	user := &models.User{
+		Username: "u_" + strings.ToLower(rand.Text()[:12]),
+		Email:    email,
+		Password: "x",
+	}
+	if err := query.User.Create(user); err != nil {
+		t.Fatalf("创建用户失败: %v", err)
+	}
+	if points > 0 {
+		if _, err := ledger.Apply(context.Background(), user.ID, points, PointsReasonAdminGrant, "test"); err != nil {
+			t.Fatalf("发放积分失败: %v", err)
+		}
+	}
+	return user
+}
+
+// reloadTestUser 重新读取用户
+func reloadTestUser(t *testing.T, id uint) *models.User {
+	t.Helper()
+	user, err := query.User.Where(query.User.ID.Eq(id)).First()
+	if err != nil {
+		t.Fatalf("读取用户失败: %v", err)
+	}
+	return user
+}
@@ -4,6 +4,7 @@ package services
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/Company-Automation-1/video-backend-go/src/api/dto"
 	"github.com/Company-Automation-1/video-backend-go/src/models"
@@ -17,12 +18,18 @@ import (
 // UserService 用户业务服务
 type UserService struct {
 	captcha *CaptchaService
+	auth    *AuthService
+	audit   *AuditService
+	rewards *RewardService
 }
 
 // NewUserService 创建用户业务服务
-func NewUserService(captcha *CaptchaService) *UserService {
+func NewUserService(captcha *CaptchaService, auth *AuthService, audit *AuditService, rewards *RewardService) *UserService {
 	return &UserService{
 		captcha: captcha,
+		auth:    auth,
+		audit:   audit,
+		rewards: rewards,
 	}
 }
 
@@ -42,6 +49,21 @@ func (s *UserService) GetListWithQuery(queryReq *dto.UserListQueryRequest) ([]*m
 
 	q := query.User.Where(conditions...)
 
+	// 状态查询（按当前生效状态：暂停到期的用户视为正常）
+	if queryReq.Status != nil {
+		now := time.Now().Unix()
+		u := query.User
+		switch *queryReq.Status {
+		case models.UserStatusActive:
+			q = q.Where(u.Where(u.Status.Eq(models.UserStatusActive)).
+				Or(u.Status.Eq(models.UserStatusSuspended), u.SuspendedUntil.Lte(now)))
+		case models.UserStatusSuspended:
+			q = q.Where(u.Status.Eq(models.UserStatusSuspended), u.SuspendedUntil.Gt(now))
+		default:
+			q = q.Where(u.Status.Eq(*queryReq.Status))
+		}
+	}
+
 	// 2. 积分范围查询（特殊处理：NULL 在业务逻辑上等于 0）
 	// points_min: 当值为 0 时匹配 NULL 或 0，当值 > 0 时只匹配 points >= min（不包含 NULL）
 	if queryReq.PointsMin != nil {
@@ -89,6 +111,11 @@ func (s *UserService) GetOne(conditions ...gen.Condition) (*models.User, error)
 func (s *UserService) Update(ctx context.Context, id uint, user *models.User, emailCode *string) (*models.User, error) {
 	excludeID := &id
 
+	existing, err := s.GetOne(query.User.ID.Eq(id))
+	if err != nil {
+		return nil, err
+	}
+
 	// 组合调用：按需校验和处理
 	if err := s.validateUsername(user.Username, excludeID); err != nil {
 		return nil, err
@@ -124,37 +151,120 @@ func (s *UserService) Update(ctx context.Context, id uint, user *models.User, em
 		return nil, tools.ErrInternalServer("用户更新失败")
 	}
 
+	before := AuditFields{"username": existing.Username, "email": existing.Email, "password_changed": false}
+	after := AuditFields{"username": existing.Username, "email": existing.Email, "password_changed": hashedPassword != ""}
+	if user.Username != "" {
+		after["username"] = user.Username
+	}
+	if user.Email != "" {
+		after["email"] = user.Email
+	}
+	s.audit.Record(ctx, AuditActionUserUpdate, AuditTargetUser, id, before, after)
+
+	// 修改密码后所有设备下线
+	if hashedPassword != "" {
+		if err := s.auth.RevokeAllTokens(ctx, RoleUser, id); err != nil {
+			return nil, tools.ErrInternalServer("登录状态清理失败")
+		}
+	}
+
 	// 返回更新后的用户
 	return s.GetOne(query.User.ID.Eq(id))
 }
 
-// UpdatePoints 更新用户积分（管理员权限）
-func (s *UserService) UpdatePoints(id uint, points *int) (*models.User, error) {
-	// 如果提供了积分（包括置空），单独更新
-	if points != nil {
-		_, err := query.User.Where(query.User.ID.Eq(id)).Update(query.User.Points, points)
-		if err != nil {
-			return nil, tools.ErrInternalServer("积分更新失败")
+// SetStatus 设置用户状态（管理员权限）：暂停需指定截止时间，暂停和封禁需填写原因，并吊销该用户的全部令牌
+func (s *UserService) SetStatus(
+	ctx context.Context,
+	id uint,
+	status int8,
+	reason string,
+	suspendedUntil *int64,
+) (*models.User, error) {
+	existing, err := s.GetOne(query.User.ID.Eq(id))
+	if err != nil {
+		return nil, err
+	}
+
+	switch status {
+	case models.UserStatusActive:
+		reason = ""
+		suspendedUntil = nil
+	case models.UserStatusSuspended:
+		if suspendedUntil == nil || *suspendedUntil <= time.Now().Unix() {
+			return nil, tools.ErrBadRequest("暂停截止时间必须晚于当前时间")
 		}
+	case models.UserStatusBanned:
+		suspendedUntil = nil
+	default:
+		return nil, tools.ErrBadRequest("无效的用户状态")
+	}
+	reason = strings.TrimSpace(reason)
+	if status != models.UserStatusActive && reason == "" {
+		return nil, tools.ErrBadRequest("请填写暂停或封禁原因")
 	}
 
-	// 返回更新后的用户
+	_, err = query.User.Where(query.User.ID.Eq(id)).Updates(map[string]interface{}{
+		"status":          status,
+		"status_reason":   reason,
+		"suspended_until": suspendedUntil,
+	})
+	if err != nil {
+		return nil, tools.ErrInternalServer("用户状态更新失败")
+	}
+	s.audit.Record(ctx, AuditActionUserStatusUpdate, AuditTargetUser, id,
+		AuditFields{"status": existing.Status, "status_reason": existing.StatusReason, "suspended_until": existing.SuspendedUntil},
+		AuditFields{"status": status, "status_reason": reason, "suspended_until": suspendedUntil})
+
+	if status != models.UserStatusActive {
+		if err := s.auth.RevokeAllTokens(ctx, RoleUser, id); err != nil {
+			return nil, tools.ErrInternalServer("登录状态清理失败")
+		}
+	}
 	return s.GetOne(query.User.ID.Eq(id))
 }
 
-// Delete 删除用户
-func (s *UserService) Delete(id uint) error {
-	_, err := query.User.Where(query.User.ID.Eq(id)).Delete()
-	return err
+// Delete 删除用户（同时吊销该用户的全部令牌）
+// 第三方身份、API Key、两步验证恢复码和未用完的积分批次与用户在同一事务中删除，
+// 避免第三方登录关联到已删除的用户、后台任务扫描到不存在用户的积分批次
+func (s *UserService) Delete(ctx context.Context, id uint) error {
+	existing, err := s.GetOne(query.User.ID.Eq(id))
+	if err != nil {
+		return err
+	}
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if _, err := tx.UserIdentity.Where(tx.UserIdentity.UserID.Eq(id)).Delete(); err != nil {
+			return err
+		}
+		if _, err := tx.APIKey.Where(tx.APIKey.UserID.Eq(id)).Delete(); err != nil {
+			return err
+		}
+		if _, err := tx.MFARecoveryCode.
+			Where(tx.MFARecoveryCode.Role.Eq(RoleUser), tx.MFARecoveryCode.AccountID.Eq(id)).
+			Delete(); err != nil {
+			return err
+		}
+		if _, err := tx.PointsLot.Where(tx.PointsLot.UserID.Eq(id), tx.PointsLot.Remaining.Gt(0)).Delete(); err != nil {
+			return err
+		}
+		_, err := tx.User.Where(tx.User.ID.Eq(id)).Delete()
+		return err
+	})
+	if err != nil {
+		tools.Logf("用户删除失败 user=%d: %v", id, err)
+		return tools.ErrInternalServer("用户删除失败")
+	}
+	s.audit.Record(ctx, AuditActionUserDelete, AuditTargetUser, id,
+		AuditFields{"username": existing.Username, "email": existing.Email, "points": existing.Points}, nil)
+	return s.auth.RevokeAllTokens(ctx, RoleUser, id)
 }
 
-// SendVerificationCode 发送验证码
-func (s *UserService) SendVerificationCode(ctx context.Context, email string) error {
+// SendVerificationCode 发送验证码（ip 为请求方IP）
+func (s *UserService) SendVerificationCode(ctx context.Context, email, ip string) error {
 	if err := s.validateEmail(email, nil); err != nil {
 		return err
 	}
 
-	_, err := s.captcha.SendCode(ctx, email, CaptchaTypeRegister)
+	_, err := s.captcha.SendCode(ctx, email, ip, CaptchaTypeRegister)
 	if err != nil {
 		// 保留原始错误的 code 和 message（SendCode 已使用 AppError 处理内部错误）
 		return tools.WrapError(err)
@@ -163,7 +273,7 @@ func (s *UserService) SendVerificationCode(ctx context.Context, email string) er
 }
 
 // Register 用户注册（业务逻辑：验证码验证、密码加密、唯一性校验）
-func (s *UserService) Register(ctx context.Context, username, email, password, captcha string) error {
+func (s *UserService) Register(ctx context.Context, username, email, password, captcha, inviteCode string) error {
 	if err := s.validateUsername(username, nil); err != nil {
 		return err
 	}
@@ -172,6 +282,15 @@ func (s *UserService) Register(ctx context.Context, username, email, password, c
 		return err
 	}
 
+	// 邀请码在验证码之前校验，填错时验证码不会被消耗
+	var inviter *models.User
+	if inviteCode != "" {
+		var err error
+		if inviter, err = s.rewards.FindInviter(inviteCode); err != nil {
+			return err
+		}
+	}
+
 	if err := s.verifyEmailCaptcha(ctx, email, captcha); err != nil {
 		return err
 	}
@@ -188,13 +307,75 @@ func (s *UserService) Register(ctx context.Context, username, email, password, c
 		Password:      hashedPassword,
 		EmailVerified: true,
 	}
+	if inviter != nil {
+		user.InvitedBy = &inviter.ID
+	}
 
-	if err := query.User.Create(user); err != nil {
+	// 用户和注册奖励（含邀请奖励）在同一事务中写入
+	err = query.Q.Transaction(func(tx *query.Query) error {
+		if err := tx.User.Create(user); err != nil {
+			return err
+		}
+		return s.rewards.RegisterTx(ctx, tx, user, inviter)
+	})
+	if err != nil {
+		tools.Logf("用户创建失败: %v", err)
 		return tools.ErrInternalServer("用户创建失败")
 	}
 	return nil
 }
 
+// ForgotPassword 忘记密码：向已注册邮箱发送重置验证码
+// 无论邮箱是否注册、发送是否成功都不返回错误，避免泄露邮箱注册情况
+func (s *UserService) ForgotPassword(ctx context.Context, email, ip string) {
+	user, err := query.User.Where(query.User.Email.Eq(email)).First()
+	if err != nil {
+		return
+	}
+
+	// 异步发送，避免响应耗时差异暴露邮箱是否注册
+	go func(ctx context.Context) {
+		if _, err := s.captcha.SendCode(ctx, user.Email, ip, CaptchaTypeReset); err != nil {
+			tools.Logf("发送重置密码验证码失败: %v\n", err)
+		}
+	}(context.WithoutCancel(ctx))
+}
+
+// ResetPassword 通过邮箱验证码重置密码，重置后该用户所有设备下线
+func (s *UserService) ResetPassword(ctx context.Context, email, code, password string) error {
+	// 未注册邮箱不会收到验证码，统一返回验证码错误，避免泄露邮箱注册情况
+	// （包括错误次数过多导致验证码作废的情况）
+	valid, err := s.captcha.VerifyCaptcha(ctx, email, code, CaptchaTypeReset)
+	if err != nil || !valid {
+		return tools.ErrBadRequest("验证码错误或已过期")
+	}
+
+	user, err := query.User.Where(query.User.Email.Eq(email)).First()
+	if err != nil {
+		return tools.ErrBadRequest("验证码错误或已过期")
+	}
+
+	hashedPassword, err := s.encryptPassword(password)
+	if err != nil {
+		return err
+	}
+	_, err = query.User.Where(query.User.ID.Eq(user.ID)).Update(query.User.Password, hashedPassword)
+	if err != nil {
+		return tools.ErrInternalServer("密码重置失败")
+	}
+
+	// 未登录请求：以验证码持有人（该用户）作为操作者
+	actor := AuditActorFromContext(ctx)
+	actor.ID, actor.Role = user.ID, RoleUser
+	s.audit.Record(WithAuditActor(ctx, actor), AuditActionUserPasswordReset, AuditTargetUser, user.ID,
+		nil, AuditFields{"email": user.Email})
+
+	if err := s.auth.RevokeAllTokens(ctx, RoleUser, user.ID); err != nil {
+		return tools.ErrInternalServer("登录状态清理失败")
+	}
+	return nil
+}
+
 // validateUsername 校验用户名唯一性
 func (s *UserService) validateUsername(username string, excludeID *uint) error {
 	if username == "" {
@@ -243,7 +424,8 @@ func (s *UserService) verifyEmailCaptcha(ctx context.Context, email, code string
 	}
 	valid, err := s.captcha.VerifyCaptcha(ctx, email, code, CaptchaTypeRegister)
 	if err != nil {
-		return tools.ErrBadRequest(err.Error())
+		// 保留原始错误的 code 和 message（错误次数过多时为 429）
+		return tools.WrapError(err)
 	}
 	if !valid {
 		return tools.ErrBadRequest("验证码错误")
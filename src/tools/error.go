@@ -2,13 +2,16 @@
 package tools
 
 import (
+	"math"
 	"net/http"
+	"time"
 )
 
 // AppError 应用错误，包含状态码和消息
 type AppError struct {
-	Code    int
-	Message string
+	Code       int
+	Message    string
+	RetryAfter int // 建议重试等待秒数（>0 时响应 Retry-After 头）
 }
 
 func (e *AppError) Error() string {
@@ -47,6 +50,14 @@ func ErrUnauthorized(message string) *AppError {
 	return &AppError{Code: http.StatusUnauthorized, Message: message}
 }
 
+// ErrPaymentRequired 余额不足错误 402
+func ErrPaymentRequired(message string) *AppError {
+	if message == "" {
+		message = "余额不足"
+	}
+	return &AppError{Code: http.StatusPaymentRequired, Message: message}
+}
+
 // ErrForbidden 禁止访问错误（权限不足） 403
 func ErrForbidden(message string) *AppError {
 	if message == "" {
@@ -63,6 +74,14 @@ func ErrConflict(message string) *AppError {
 	return &AppError{Code: http.StatusConflict, Message: message}
 }
 
+// ErrRequestEntityTooLarge 请求体过大错误 413
+func ErrRequestEntityTooLarge(message string) *AppError {
+	if message == "" {
+		message = "请求体过大"
+	}
+	return &AppError{Code: http.StatusRequestEntityTooLarge, Message: message}
+}
+
 // ErrUnprocessableEntity 无法处理的实体错误（验证失败） 422
 func ErrUnprocessableEntity(message string) *AppError {
 	if message == "" {
@@ -71,6 +90,34 @@ func ErrUnprocessableEntity(message string) *AppError {
 	return &AppError{Code: http.StatusUnprocessableEntity, Message: message}
 }
 
+// ErrTooManyRequests 请求过于频繁错误 429（retryAfter 为建议的重试等待时间）
+func ErrTooManyRequests(message string, retryAfter time.Duration) *AppError {
+	if message == "" {
+		message = "请求过于频繁，请稍后再试"
+	}
+	return &AppError{
+		Code:       http.StatusTooManyRequests,
+		Message:    message,
+		RetryAfter: int(math.Ceil(retryAfter.Seconds())),
+	}
+}
+
+// ErrBadGateway 上游服务错误 502
+func ErrBadGateway(message string) *AppError {
+	if message == "" {
+		message = "上游服务不可用"
+	}
+	return &AppError{Code: http.StatusBadGateway, Message: message}
+}
+
+// ErrServiceUnavailable 服务暂不可用错误（如缺少必要配置） 503
+func ErrServiceUnavailable(message string) *AppError {
+	if message == "" {
+		message = "服务暂不可用"
+	}
+	return &AppError{Code: http.StatusServiceUnavailable, Message: message}
+}
+
 // GetCode 获取错误的状态码
 func GetCode(err error) int {
 	if appErr, ok := err.(*AppError); ok {
@@ -87,6 +134,14 @@ func GetMessage(err error) string {
 	return err.Error()
 }
 
+// GetRetryAfter 获取错误的建议重试等待秒数（0 表示未指定）
+func GetRetryAfter(err error) int {
+	if appErr, ok := err.(*AppError); ok {
+		return appErr.RetryAfter
+	}
+	return 0
+}
+
 // WrapError 包装错误，保留原始错误的 code 和 message（如果已经是 AppError）
 // 如果不是 AppError，则转换为 AppError（默认 500）
 func WrapError(err error) *AppError {
@@ -0,0 +1,235 @@
+// Package tools MP4/MOV 容器解析（纯 Go 读取时长和分辨率，不依赖 ffmpeg）
+package tools
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+const (
+	// mp4MaxMoovSize moov 原子的最大读取长度（只读取元数据，媒体数据 mdat 直接跳过）
+	mp4MaxMoovSize = 64 << 20
+	// mp4MaxBoxes 顶层原子的最大数量（防止构造的文件导致长时间循环）
+	mp4MaxBoxes = 1024
+)
+
+// ErrInvalidMP4 无法识别的 MP4/MOV 文件
+var ErrInvalidMP4 = errors.New("无法识别的 MP4/MOV 文件")
+
+// mp4FirstBoxTypes 文件第一个原子允许的类型（旧版 QuickTime 文件可能没有 ftyp）
+var mp4FirstBoxTypes = map[string]bool{ //nolint:gochecknoglobals // 只读映射
+	"ftyp": true, "moov": true, "mdat": true, "free": true, "skip": true, "wide": true, "pnot": true,
+}
+
+// MediaInfo 视频信息
+type MediaInfo struct {
+	Duration time.Duration // 时长
+	Width    int           // 宽度（像素，取视频轨道中最大的）
+	Height   int           // 高度（像素）
+}
+
+// ShortSide 短边像素（横竖屏使用同一分辨率档位）
+func (m *MediaInfo) ShortSide() int {
+	return min(m.Width, m.Height)
+}
+
+// ParseMP4 顺序读取 MP4/MOV 顶层原子，解析 moov 中的 mvhd（时长）和 tkhd（分辨率）
+// 只向前读取，不需要随机访问，可直接解析 multipart 中的文件部分；读取到 moov 后即返回
+func ParseMP4(r io.Reader) (*MediaInfo, error) {
+	for i := 0; i < mp4MaxBoxes; i++ {
+		boxType, size, err := readMP4BoxHeader(r)
+		if err != nil {
+			return nil, ErrInvalidMP4
+		}
+		if i == 0 && !mp4FirstBoxTypes[boxType] {
+			return nil, ErrInvalidMP4
+		}
+		if boxType == "moov" {
+			return readMP4Moov(r, size)
+		}
+		// 延伸到文件末尾的原子之后不会再有 moov
+		if size < 0 {
+			return nil, ErrInvalidMP4
+		}
+		if _, err := io.CopyN(io.Discard, r, size); err != nil {
+			return nil, ErrInvalidMP4
+		}
+	}
+	return nil, ErrInvalidMP4
+}
+
+// readMP4Moov 读取并解析 moov 原子（size 为 -1 时读取到文件末尾）
+func readMP4Moov(r io.Reader, size int64) (*MediaInfo, error) {
+	if size > mp4MaxMoovSize {
+		return nil, ErrInvalidMP4
+	}
+	if size < 0 {
+		buf, err := io.ReadAll(io.LimitReader(r, mp4MaxMoovSize+1))
+		if err != nil || len(buf) > mp4MaxMoovSize {
+			return nil, ErrInvalidMP4
+		}
+		return parseMP4Moov(buf)
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, ErrInvalidMP4
+	}
+	return parseMP4Moov(buf)
+}
+
+// readMP4BoxHeader 读取原子头，返回类型和内容长度（-1 表示延伸到文件末尾）
+func readMP4BoxHeader(r io.Reader) (string, int64, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return "", 0, err
+	}
+	size := int64(binary.BigEndian.Uint32(header[:4]))
+	boxType := string(header[4:])
+	switch size {
+	case 0:
+		return boxType, -1, nil
+	case 1:
+		var large [8]byte
+		if _, err := io.ReadFull(r, large[:]); err != nil {
+			return "", 0, err
+		}
+		size = int64(binary.BigEndian.Uint64(large[:])) //nolint:gosec // 超出范围时下面的校验会拒绝
+		if size < 16 {
+			return "", 0, ErrInvalidMP4
+		}
+		return boxType, size - 16, nil
+	default:
+		if size < 8 {
+			return "", 0, ErrInvalidMP4
+		}
+		return boxType, size - 8, nil
+	}
+}
+
+// parseMP4Moov 解析 moov 原子内容
+func parseMP4Moov(moov []byte) (*MediaInfo, error) {
+	var timescale, duration, fragmentDuration uint64
+	info := &MediaInfo{}
+	err := eachMP4Box(moov, func(boxType string, payload []byte) error {
+		switch boxType {
+		case "mvhd":
+			var err error
+			timescale, duration, err = parseMP4Mvhd(payload)
+			return err
+		case "mvex":
+			return eachMP4Box(payload, func(childType string, child []byte) error {
+				if childType == "mehd" {
+					fragmentDuration = parseMP4Mehd(child)
+				}
+				return nil
+			})
+		case "trak":
+			return eachMP4Box(payload, func(childType string, child []byte) error {
+				if childType != "tkhd" {
+					return nil
+				}
+				width, height, err := parseMP4Tkhd(child)
+				if err != nil {
+					return err
+				}
+				// 音频轨道宽高为 0，取视频轨道中分辨率最大的
+				if width*height > info.Width*info.Height {
+					info.Width, info.Height = width, height
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 分片 MP4 的 mvhd 时长可能为 0，此时使用 mvex/mehd 中的总时长
+	if duration == 0 {
+		duration = fragmentDuration
+	}
+	if timescale == 0 || info.Width == 0 || info.Height == 0 {
+		return nil, ErrInvalidMP4
+	}
+	seconds := float64(duration) / float64(timescale)
+	info.Duration = time.Duration(seconds * float64(time.Second))
+	return info, nil
+}
+
+// eachMP4Box 遍历内存中的子原子
+func eachMP4Box(data []byte, fn func(boxType string, payload []byte) error) error {
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return ErrInvalidMP4
+		}
+		size := uint64(binary.BigEndian.Uint32(data[:4]))
+		boxType := string(data[4:8])
+		headerLen := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return ErrInvalidMP4
+			}
+			size = binary.BigEndian.Uint64(data[8:16])
+			headerLen = 16
+		}
+		if size < headerLen || size > uint64(len(data)) {
+			return ErrInvalidMP4
+		}
+		if err := fn(boxType, data[headerLen:size]); err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+	return nil
+}
+
+// parseMP4Mvhd 解析 mvhd：version 0 为 32 位时间字段，version 1 为 64 位
+func parseMP4Mvhd(payload []byte) (timescale, duration uint64, err error) {
+	if len(payload) < 4 {
+		return 0, 0, ErrInvalidMP4
+	}
+	if payload[0] == 1 {
+		// version(1) flags(3) creation(8) modification(8) timescale(4) duration(8)
+		if len(payload) < 32 {
+			return 0, 0, ErrInvalidMP4
+		}
+		return uint64(binary.BigEndian.Uint32(payload[20:24])), binary.BigEndian.Uint64(payload[24:32]), nil
+	}
+	// version(1) flags(3) creation(4) modification(4) timescale(4) duration(4)
+	if len(payload) < 20 {
+		return 0, 0, ErrInvalidMP4
+	}
+	return uint64(binary.BigEndian.Uint32(payload[12:16])), uint64(binary.BigEndian.Uint32(payload[16:20])), nil
+}
+
+// parseMP4Mehd 解析 mehd 中的分片总时长（使用 mvhd 的 timescale）
+func parseMP4Mehd(payload []byte) uint64 {
+	if len(payload) >= 12 && payload[0] == 1 {
+		return binary.BigEndian.Uint64(payload[4:12])
+	}
+	if len(payload) >= 8 {
+		return uint64(binary.BigEndian.Uint32(payload[4:8]))
+	}
+	return 0
+}
+
+// parseMP4Tkhd 解析 tkhd 末尾的宽高（16.16 定点数）
+func parseMP4Tkhd(payload []byte) (width, height int, err error) {
+	// version 0：头部 4 + 时间和轨道字段 20；version 1：4 + 32；之后为 reserved(8) layer(2) group(2) volume(2) reserved(2) matrix(36)
+	offset := 24
+	if len(payload) > 0 && payload[0] == 1 {
+		offset = 36
+	}
+	offset += 52
+	if len(payload) < offset+8 {
+		return 0, 0, ErrInvalidMP4
+	}
+	width = int(binary.BigEndian.Uint32(payload[offset:offset+4]) >> 16)
+	height = int(binary.BigEndian.Uint32(payload[offset+4:offset+8]) >> 16)
+	return width, height, nil
+}
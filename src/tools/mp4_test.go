@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mp4Box 生成原子（32 位长度）
+func mp4Box(boxType string, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	buf := binary.BigEndian.AppendUint32(nil, uint32(8+len(body))) //nolint:gosec // 测试数据长度可控
+	return append(append(buf, boxType...), body...)
+}
+
+// mp4LargeBox 生成 size==1 的原子（64 位长度）
+func mp4LargeBox(boxType string, payload []byte) []byte {
+	buf := binary.BigEndian.AppendUint32(nil, 1)
+	buf = append(buf, boxType...)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(16+len(payload)))
+	return append(buf, payload...)
+}
+
+// mp4OpenBox 生成 size==0 的原子（延伸到文件末尾）
+func mp4OpenBox(boxType string, payload []byte) []byte {
+	return append(append(make([]byte, 4), boxType...), payload...)
+}
+
+// mp4Header 生成原子头（长度与实际内容无关，用于构造截断或超长的原子）
+func mp4Header(boxType string, size uint32) []byte {
+	return append(binary.BigEndian.AppendUint32(nil, size), boxType...)
+}
+
+// mp4Mvhd 生成 mvhd（version 0 为 32 位时间字段，version 1 为 64 位）
+func mp4Mvhd(version byte, timescale uint32, duration uint64) []byte {
+	buf := []byte{version, 0, 0, 0}
+	if version == 1 {
+		buf = append(buf, make([]byte, 16)...)
+		buf = binary.BigEndian.AppendUint32(buf, timescale)
+		buf = binary.BigEndian.AppendUint64(buf, duration)
+	} else {
+		buf = append(buf, make([]byte, 8)...)
+		buf = binary.BigEndian.AppendUint32(buf, timescale)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(duration)) //nolint:gosec // 测试数据
+	}
+	// rate、volume、matrix 等剩余字段
+	return mp4Box("mvhd", append(buf, make([]byte, 80)...))
+}
+
+// mp4Trak 生成只含 tkhd 的轨道（宽高为 16.16 定点数）
+func mp4Trak(version byte, width, height uint32) []byte {
+	fields := 20
+	if version == 1 {
+		fields = 32
+	}
+	buf := append([]byte{version, 0, 0, 0}, make([]byte, fields+52)...)
+	buf = binary.BigEndian.AppendUint32(buf, width<<16)
+	buf = binary.BigEndian.AppendUint32(buf, height<<16)
+	return mp4Box("trak", mp4Box("tkhd", buf))
+}
+
+func TestParseMP4(t *testing.T) {
+	ftyp := mp4Box("ftyp", []byte("isom"), make([]byte, 4), []byte("isommp41"))
+	moov := mp4Box("moov", mp4Mvhd(0, 1000, 90500), mp4Trak(0, 1920, 1080))
+	mdat := mp4Box("mdat", make([]byte, 4096))
+
+	tests := []struct {
+		name string
+		data []byte
+		want *MediaInfo
+	}{
+		{
+			name: "ftyp+moov（mvhd v0）",
+			data: bytes.Join([][]byte{ftyp, moov}, nil),
+			want: &MediaInfo{Duration: 90500 * time.Millisecond, Width: 1920, Height: 1080},
+		},
+		{
+			name: "mvhd v1 与 tkhd v1",
+			data: bytes.Join([][]byte{ftyp, mp4Box("moov", mp4Mvhd(1, 600, 600*3*3600), mp4Trak(1, 3840, 2160))}, nil),
+			want: &MediaInfo{Duration: 3 * time.Hour, Width: 3840, Height: 2160},
+		},
+		{
+			name: "moov 位于 mdat 之后",
+			data: bytes.Join([][]byte{ftyp, mdat, mp4Box("free"), moov}, nil),
+			want: &MediaInfo{Duration: 90500 * time.Millisecond, Width: 1920, Height: 1080},
+		},
+		{
+			name: "size==1 的 mdat（64 位长度）",
+			data: bytes.Join([][]byte{ftyp, mp4LargeBox("mdat", make([]byte, 100)), moov}, nil),
+			want: &MediaInfo{Duration: 90500 * time.Millisecond, Width: 1920, Height: 1080},
+		},
+		{
+			name: "size==1 的 moov",
+			data: bytes.Join([][]byte{ftyp, mp4LargeBox("moov", moov[8:])}, nil),
+			want: &MediaInfo{Duration: 90500 * time.Millisecond, Width: 1920, Height: 1080},
+		},
+		{
+			name: "size==0 的 moov（延伸到文件末尾）",
+			data: bytes.Join([][]byte{ftyp, mp4OpenBox("moov", moov[8:])}, nil),
+			want: &MediaInfo{Duration: 90500 * time.Millisecond, Width: 1920, Height: 1080},
+		},
+		{
+			name: "无 ftyp 的旧版 QuickTime",
+			data: moov,
+			want: &MediaInfo{Duration: 90500 * time.Millisecond, Width: 1920, Height: 1080},
+		},
+		{
+			name: "音频轨道宽高为 0，取视频轨道",
+			data: mp4Box("moov", mp4Mvhd(0, 1000, 1000), mp4Trak(0, 0, 0), mp4Trak(0, 1080, 1920)),
+			want: &MediaInfo{Duration: time.Second, Width: 1080, Height: 1920},
+		},
+		{
+			name: "分片 MP4 使用 mehd 时长",
+			data: mp4Box("moov", mp4Mvhd(0, 1000, 0), mp4Trak(0, 1280, 720),
+				mp4Box("mvex", mp4Box("mehd", []byte{0, 0, 0, 0, 0, 0, 0x75, 0x30}))),
+			want: &MediaInfo{Duration: 30 * time.Second, Width: 1280, Height: 720},
+		},
+		{name: "空文件", data: nil},
+		{name: "第一个原子类型不支持", data: bytes.Join([][]byte{mp4Box("abcd"), moov}, nil)},
+		{name: "只有 mdat 没有 moov", data: bytes.Join([][]byte{ftyp, mdat}, nil)},
+		{name: "size==0 的 mdat 之后不会再有 moov", data: bytes.Join([][]byte{ftyp, mp4OpenBox("mdat", moov)}, nil)},
+		{name: "原子头不完整", data: ftyp[:6]},
+		{name: "原子长度小于头部", data: bytes.Join([][]byte{ftyp, mp4Header("moov", 4)}, nil)},
+		{name: "size==1 但 64 位长度小于头部", data: append(mp4Header("mdat", 1), make([]byte, 8)...)},
+		{name: "moov 截断", data: bytes.Join([][]byte{ftyp, moov[:len(moov)-10]}, nil)},
+		{name: "mdat 截断", data: bytes.Join([][]byte{ftyp, mp4Header("mdat", 1<<20), make([]byte, 100)}, nil)},
+		{name: "moov 超过读取上限", data: bytes.Join([][]byte{ftyp, mp4Header("moov", mp4MaxMoovSize+9)}, nil)},
+		{name: "子原子超出 moov", data: mp4Box("moov", mp4Header("trak", 1000), make([]byte, 16))},
+		{name: "mvhd 过短", data: mp4Box("moov", mp4Box("mvhd", make([]byte, 10)), mp4Trak(0, 1920, 1080))},
+		{name: "tkhd 过短", data: mp4Box("moov", mp4Mvhd(0, 1000, 1000), mp4Box("trak", mp4Box("tkhd", make([]byte, 20))))},
+		{name: "缺少 mvhd", data: mp4Box("moov", mp4Trak(0, 1920, 1080))},
+		{name: "只有音频轨道", data: mp4Box("moov", mp4Mvhd(0, 1000, 1000), mp4Trak(0, 0, 0))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMP4(bytes.NewReader(tt.data))
+			if tt.want == nil {
+				if !errors.Is(err, ErrInvalidMP4) {
+					t.Fatalf("ParseMP4() = %+v, %v, want ErrInvalidMP4", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMP4() error = %v", err)
+			}
+			if *got != *tt.want {
+				t.Fatalf("ParseMP4() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMP4TooManyBoxes(t *testing.T) {
+	data := bytes.Repeat(mp4Box("free"), mp4MaxBoxes+1)
+	if _, err := ParseMP4(bytes.NewReader(data)); !errors.Is(err, ErrInvalidMP4) {
+		t.Fatalf("ParseMP4() error = %v, want ErrInvalidMP4", err)
+	}
+}
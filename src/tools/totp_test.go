@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret RFC 6238 附录 B 的 SHA1 测试密钥 "12345678901234567890"（Base32）
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// TestTOTPCodeRFC6238 RFC 6238 附录 B 的 SHA1 测试向量（原文为 8 位口令，取末 6 位）
+func TestTOTPCodeRFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TOTPCode(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+
+	// 密钥不区分大小写，允许首尾空白
+	if got, err := TOTPCode(" "+"gezdgnbvgy3tqojqgezdgnbvgy3tqojq"+"\n", 1); err != nil || got != "287082" {
+		t.Errorf("小写密钥 TOTPCode = %s, %v, want 287082", got, err)
+	}
+	if _, err := TOTPCode("not-base32!", 1); err == nil {
+		t.Error("非法密钥 TOTPCode 未返回错误")
+	}
+}
+
+// TestValidateTOTPWindow 只接受前后各一个时间步内的口令
+func TestValidateTOTPWindow(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := TOTPStep(now)
+	for offset := int64(-3); offset <= 3; offset++ {
+		code, err := TOTPCode(rfc6238Secret, current+offset)
+		if err != nil {
+			t.Fatalf("TOTPCode: %v", err)
+		}
+		step, ok := ValidateTOTP(rfc6238Secret, code, now)
+		wantOK := offset >= -totpSkew && offset <= totpSkew
+		if ok != wantOK {
+			t.Errorf("偏差 %d 个时间步 ValidateTOTP ok = %v, want %v", offset, ok, wantOK)
+		}
+		if ok && step != current+offset {
+			t.Errorf("偏差 %d 个时间步 ValidateTOTP step = %d, want %d", offset, step, current+offset)
+		}
+	}
+
+	for _, code := range []string{"", "05047", "0504710", "abcdef"} {
+		if _, ok := ValidateTOTP(rfc6238Secret, code, now); ok {
+			t.Errorf("ValidateTOTP(%q) ok = true, want false", code)
+		}
+	}
+}
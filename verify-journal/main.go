@@ -0,0 +1,43 @@
+// Package main 积分变更日志哈希链校验工具
+// 从第一行开始逐行校验 a_points_journal，报告第一个断开的位置；链完整时退出码为 0，否则为 1
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Company-Automation-1/video-backend-go/src/config"
+	"github.com/Company-Automation-1/video-backend-go/src/infrastructure"
+	"github.com/Company-Automation-1/video-backend-go/src/query"
+	"github.com/Company-Automation-1/video-backend-go/src/services"
+)
+
+func main() {
+	configPath := flag.String("config", "config.yaml", "配置文件路径")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	manager := infrastructure.NewDatabaseManager(cfg)
+	if err := manager.InitDatabase(); err != nil {
+		log.Fatalf("初始化数据库失败: %v", err)
+	}
+	query.SetDefault(manager.GetDB())
+
+	result, err := services.NewPointsJournalService(nil).Verify()
+	if err != nil {
+		log.Fatalf("读取积分日志失败: %v", err)
+	}
+
+	if !result.OK() {
+		fmt.Printf("积分日志哈希链断开：序号 %d，%s\n", result.BrokenAt, result.Problem)
+		fmt.Printf("此前 %d 行校验通过，最后一个有效哈希 %s（序号 %d）\n", result.Checked, result.HeadHash, result.HeadSeq)
+		os.Exit(1)
+	}
+	fmt.Printf("积分日志哈希链完整：共 %d 行，链头序号 %d，哈希 %s\n", result.Checked, result.HeadSeq, result.HeadHash)
+}